@@ -2,11 +2,35 @@
 
 package model
 
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Differences between the baseline cluster and another cluster found by the compareClusters query.
+type ClusterComparison struct {
+	// Name of the baseline cluster.
+	Baseline string `json:"baseline"`
+	// Name of the cluster compared against the baseline.
+	Cluster string `json:"cluster"`
+	// Resources on the baseline cluster that don't exist on the cluster.
+	Missing []map[string]any `json:"missing,omitempty"`
+	// Resources on the cluster that don't exist on the baseline cluster.
+	Extra []map[string]any `json:"extra,omitempty"`
+	// Resources on both clusters with different values for the compared properties.
+	Different []*ResourceDifference `json:"different,omitempty"`
+}
+
 // Event represents a changed resource in the search index.
 type Event struct {
 	// Kubernetes resource UID.
 	UID string `json:"uid"`
 	// Values: INSERT, UPDATE, or DELETE
+	// When watching with initialState: ADDED for the resources in the initial state, and SYNCED after the
+	// initial state is sent. When watching since a sequence number: RESYNC if the changes can't be replayed.
+	// The SYNCED and RESYNC events don't have a uid or data.
 	Operation string `json:"operation"`
 	// New data recorded on the search index.
 	NewData map[string]any `json:"newData,omitempty"`
@@ -15,6 +39,25 @@ type Event struct {
 	// Time the change event is registered in the search index.
 	// Note there's a delay from the time the resource changed in kubernetes.
 	Timestamp string `json:"timestamp"`
+	// Sequence number of the change. Increases with each change received by the API instance.
+	// Use it with watch(since) to resume watching after the connection is lost.
+	// Not set for ADDED events. For SYNCED and RESYNC events, it's the sequence to resume from.
+	Seq *int `json:"seq,omitempty"`
+	// Properties changed by an UPDATE, with the new values, when watching with diff CHANGED_KEYS.
+	// Removed properties have a null value.
+	Changes map[string]any `json:"changes,omitempty"`
+	// JSON patch (RFC 6902) from oldData to newData of an UPDATE, when watching with diff JSON_PATCH.
+	Patch []*PatchOperation `json:"patch,omitempty"`
+	// Names of the queries matched by the event, when watching with queries. Not set for SYNCED and RESYNC events.
+	Queries []string `json:"queries,omitempty"`
+}
+
+// Number of resources in a time bucket of the searchHistogram query.
+type HistogramBucket struct {
+	// Start time of the bucket. Buckets are aligned in UTC, weeks start on Monday.
+	Timestamp string `json:"timestamp"`
+	// Number of resources with the timestamp property within the bucket.
+	Count int `json:"count"`
 }
 
 // A message is used to communicate conditions detected while executing a query on the server.
@@ -28,10 +71,36 @@ type Message struct {
 	Description *string `json:"description,omitempty"`
 }
 
+// Mutations implemented by the Search Query API.
+type Mutation struct {
+}
+
+// An operation of a JSON patch (RFC 6902).
+type PatchOperation struct {
+	// Values: add, remove, or replace
+	Op string `json:"op"`
+	// JSON pointer (RFC 6901) to the property, for example /label/app
+	Path string `json:"path"`
+	// New value of the property. Not set for remove.
+	Value any `json:"value,omitempty"`
+}
+
 // Queries supported by the Search Query API.
 type Query struct {
 }
 
+// A resource with different values on the baseline cluster and the compared cluster.
+type ResourceDifference struct {
+	// Values of the key properties used to match the resource.
+	Key map[string]any `json:"key"`
+	// Properties with different values.
+	Properties []string `json:"properties"`
+	// Resource on the baseline cluster.
+	BaselineItem map[string]any `json:"baselineItem,omitempty"`
+	// Resource on the compared cluster.
+	Item map[string]any `json:"item,omitempty"`
+}
+
 // Defines a key/value to filter results.
 // When multiple values are provided for a property, it is interpreted as an OR operation.
 type SearchFilter struct {
@@ -79,6 +148,334 @@ type SearchInput struct {
 	RelatedKinds []*string `json:"relatedKinds,omitempty"`
 }
 
+// A resource matched by the similar query.
+type SimilarResource struct {
+	// The matched resource.
+	Item map[string]any `json:"item"`
+	// Number of criteria matched. Results are sorted by score.
+	Score int `json:"score"`
+	// Criteria matched by the resource.
+	Reasons []SimilarityCriteria `json:"reasons"`
+}
+
 // Subscriptions implemented by the Search Query API.
 type Subscription struct {
 }
+
+// Events sent together by the watchBatch subscription, in the order received.
+type WatchBatch struct {
+	Events []*Event `json:"events"`
+}
+
+// A named search input, to watch multiple queries with one subscription.
+type WatchQuery struct {
+	// Name of the query, set in the events matching it. Must be unique in the watch.
+	Name string `json:"name"`
+	// Same as the input of watch.
+	Input *SearchInput `json:"input,omitempty"`
+}
+
+// Size of the time buckets used by the searchHistogram query.
+type HistogramInterval string
+
+const (
+	HistogramIntervalHour HistogramInterval = "HOUR"
+	HistogramIntervalDay  HistogramInterval = "DAY"
+	HistogramIntervalWeek HistogramInterval = "WEEK"
+)
+
+var AllHistogramInterval = []HistogramInterval{
+	HistogramIntervalHour,
+	HistogramIntervalDay,
+	HistogramIntervalWeek,
+}
+
+func (e HistogramInterval) IsValid() bool {
+	switch e {
+	case HistogramIntervalHour, HistogramIntervalDay, HistogramIntervalWeek:
+		return true
+	}
+	return false
+}
+
+func (e HistogramInterval) String() string {
+	return string(e)
+}
+
+func (e *HistogramInterval) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HistogramInterval(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HistogramInterval", str)
+	}
+	return nil
+}
+
+func (e HistogramInterval) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *HistogramInterval) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e HistogramInterval) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Status of a search job.
+type SearchJobStatus string
+
+const (
+	SearchJobStatusPending   SearchJobStatus = "PENDING"
+	SearchJobStatusRunning   SearchJobStatus = "RUNNING"
+	SearchJobStatusCompleted SearchJobStatus = "COMPLETED"
+	SearchJobStatusFailed    SearchJobStatus = "FAILED"
+)
+
+var AllSearchJobStatus = []SearchJobStatus{
+	SearchJobStatusPending,
+	SearchJobStatusRunning,
+	SearchJobStatusCompleted,
+	SearchJobStatusFailed,
+}
+
+func (e SearchJobStatus) IsValid() bool {
+	switch e {
+	case SearchJobStatusPending, SearchJobStatusRunning, SearchJobStatusCompleted, SearchJobStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e SearchJobStatus) String() string {
+	return string(e)
+}
+
+func (e *SearchJobStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SearchJobStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchJobStatus", str)
+	}
+	return nil
+}
+
+func (e SearchJobStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SearchJobStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SearchJobStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Criteria used by the similar query to match resources.
+type SimilarityCriteria string
+
+const (
+	// Shares at least one label with the resource.
+	SimilarityCriteriaLabels SimilarityCriteria = "LABELS"
+	// Has an owner with the same kind and name as the owner of the resource.
+	SimilarityCriteriaOwner SimilarityCriteria = "OWNER"
+	// Uses at least one of the container images of the resource.
+	SimilarityCriteriaImage SimilarityCriteria = "IMAGE"
+	// Has the same name, ignoring suffixes generated by kubernetes. For example, `nginx-5f5575c669-x7k2p` matches `nginx-7d9c8b6f4-abcde`.
+	SimilarityCriteriaNamePrefix SimilarityCriteria = "NAME_PREFIX"
+)
+
+var AllSimilarityCriteria = []SimilarityCriteria{
+	SimilarityCriteriaLabels,
+	SimilarityCriteriaOwner,
+	SimilarityCriteriaImage,
+	SimilarityCriteriaNamePrefix,
+}
+
+func (e SimilarityCriteria) IsValid() bool {
+	switch e {
+	case SimilarityCriteriaLabels, SimilarityCriteriaOwner, SimilarityCriteriaImage, SimilarityCriteriaNamePrefix:
+		return true
+	}
+	return false
+}
+
+func (e SimilarityCriteria) String() string {
+	return string(e)
+}
+
+func (e *SimilarityCriteria) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SimilarityCriteria(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SimilarityCriteria", str)
+	}
+	return nil
+}
+
+func (e SimilarityCriteria) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SimilarityCriteria) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SimilarityCriteria) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Format of the UPDATE events sent by a watch.
+type WatchDiff string
+
+const (
+	// Send the full newData and oldData.
+	WatchDiffNone WatchDiff = "NONE"
+	// Send the changed properties in changes, without newData and oldData.
+	WatchDiffChangedKeys WatchDiff = "CHANGED_KEYS"
+	// Send a JSON patch in patch, without newData and oldData.
+	WatchDiffJSONPatch WatchDiff = "JSON_PATCH"
+)
+
+var AllWatchDiff = []WatchDiff{
+	WatchDiffNone,
+	WatchDiffChangedKeys,
+	WatchDiffJSONPatch,
+}
+
+func (e WatchDiff) IsValid() bool {
+	switch e {
+	case WatchDiffNone, WatchDiffChangedKeys, WatchDiffJSONPatch:
+		return true
+	}
+	return false
+}
+
+func (e WatchDiff) String() string {
+	return string(e)
+}
+
+func (e *WatchDiff) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WatchDiff(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WatchDiff", str)
+	}
+	return nil
+}
+
+func (e WatchDiff) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WatchDiff) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WatchDiff) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// What a watch does with the events when the client doesn't receive them fast enough and the buffer is full.
+type WatchOverflow string
+
+const (
+	// Drop the events, then send a RESYNC event when there's space in the buffer.
+	WatchOverflowDropAndNotify WatchOverflow = "DROP_AND_NOTIFY"
+	// Keep only the latest event for each resource in the buffer. When the buffer is full of events for different
+	// resources, the events are dropped like with DROP_AND_NOTIFY.
+	WatchOverflowCoalesce WatchOverflow = "COALESCE"
+	// Close the subscription.
+	WatchOverflowDisconnect WatchOverflow = "DISCONNECT"
+)
+
+var AllWatchOverflow = []WatchOverflow{
+	WatchOverflowDropAndNotify,
+	WatchOverflowCoalesce,
+	WatchOverflowDisconnect,
+}
+
+func (e WatchOverflow) IsValid() bool {
+	switch e {
+	case WatchOverflowDropAndNotify, WatchOverflowCoalesce, WatchOverflowDisconnect:
+		return true
+	}
+	return false
+}
+
+func (e WatchOverflow) String() string {
+	return string(e)
+}
+
+func (e *WatchOverflow) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WatchOverflow(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WatchOverflow", str)
+	}
+	return nil
+}
+
+func (e WatchOverflow) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WatchOverflow) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WatchOverflow) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -13,12 +13,30 @@ import (
 	klog "k8s.io/klog/v2"
 )
 
+// SubmitSearchJob is the resolver for the submitSearchJob field.
+func (r *mutationResolver) SubmitSearchJob(ctx context.Context, input model.SearchInput, includeRelated *bool) (*resolver.SearchJob, error) {
+	klog.V(3).Info("Received SubmitSearchJob mutation")
+	return resolver.SubmitSearchJob(ctx, &input, includeRelated)
+}
+
 // Search is the resolver for the search field.
 func (r *queryResolver) Search(ctx context.Context, input []*model.SearchInput) ([]*resolver.SearchResult, error) {
 	klog.V(3).Infof("--------- Received Search query with %d inputs ---------\n", len(input))
 	return resolver.Search(ctx, input)
 }
 
+// Resource is the resolver for the resource field.
+func (r *queryResolver) Resource(ctx context.Context, uid string) (*resolver.SearchResult, error) {
+	klog.V(3).Infof("Received Resource query with uid %s", uid)
+	return resolver.Resource(ctx, uid)
+}
+
+// Resources is the resolver for the resources field.
+func (r *queryResolver) Resources(ctx context.Context, uids []string) (*resolver.SearchResult, error) {
+	klog.V(3).Infof("Received Resources query with %d uids", len(uids))
+	return resolver.Resources(ctx, uids)
+}
+
 // SearchComplete is the resolver for the searchComplete field.
 func (r *queryResolver) SearchComplete(ctx context.Context, property string, query *model.SearchInput, limit *int) ([]*string, error) {
 	if limit != nil {
@@ -35,6 +53,36 @@ func (r *queryResolver) SearchSchema(ctx context.Context, query *model.SearchInp
 	return resolver.SearchSchemaResolver(ctx, query)
 }
 
+// SearchJob is the resolver for the searchJob field.
+func (r *queryResolver) SearchJob(ctx context.Context, id string) (*resolver.SearchJob, error) {
+	klog.V(3).Infof("Received SearchJob query for job %s", id)
+	return resolver.GetSearchJob(ctx, id)
+}
+
+// SearchHistogram is the resolver for the searchHistogram field.
+func (r *queryResolver) SearchHistogram(ctx context.Context, input *model.SearchInput, property *string, interval model.HistogramInterval, from *string, to *string) ([]*model.HistogramBucket, error) {
+	klog.V(3).Infof("Received SearchHistogram query with interval %s", interval)
+	return resolver.SearchHistogram(ctx, input, property, interval, from, to)
+}
+
+// Orphans is the resolver for the orphans field.
+func (r *queryResolver) Orphans(ctx context.Context, input *model.SearchInput, relatedKinds []*string) (*resolver.SearchResult, error) {
+	klog.V(3).Infof("Received Orphans query with %d relatedKinds", len(relatedKinds))
+	return resolver.Orphans(ctx, input, relatedKinds)
+}
+
+// Similar is the resolver for the similar field.
+func (r *queryResolver) Similar(ctx context.Context, uid string, by []model.SimilarityCriteria, limit *int) ([]*model.SimilarResource, error) {
+	klog.V(3).Infof("Received Similar query for uid %s", uid)
+	return resolver.Similar(ctx, uid, by, limit)
+}
+
+// CompareClusters is the resolver for the compareClusters field.
+func (r *queryResolver) CompareClusters(ctx context.Context, clusters []string, input *model.SearchInput, keyProperties []*string, compareProperties []*string) ([]*model.ClusterComparison, error) {
+	klog.V(3).Infof("Received CompareClusters query for clusters %v", clusters)
+	return resolver.CompareClusters(ctx, clusters, input, keyProperties, compareProperties)
+}
+
 // Messages is the resolver for the messages field.
 func (r *queryResolver) Messages(ctx context.Context) ([]*model.Message, error) {
 	klog.V(3).Infoln("Received Messages query")
@@ -42,16 +90,33 @@ func (r *queryResolver) Messages(ctx context.Context) ([]*model.Message, error)
 }
 
 // Watch is the resolver for the watch field.
-func (r *subscriptionResolver) Watch(ctx context.Context, input *model.SearchInput) (<-chan *model.Event, error) {
+func (r *subscriptionResolver) Watch(ctx context.Context, input *model.SearchInput, initialState *bool, since *int, overflow *model.WatchOverflow, diff *model.WatchDiff, properties []*string, queries []*model.WatchQuery) (<-chan *model.Event, error) {
 	klog.V(3).Infoln("Received watch subscription")
-	return resolver.WatchSubscription(ctx, input)
+	return resolver.WatchSubscription(ctx, input, initialState, since, overflow, diff, properties, queries)
 }
 
+// WatchBatch is the resolver for the watchBatch field.
+func (r *subscriptionResolver) WatchBatch(ctx context.Context, input *model.SearchInput, initialState *bool, since *int, overflow *model.WatchOverflow, diff *model.WatchDiff, properties []*string, batchWindowMs *int, maxBatchSize *int) (<-chan *model.WatchBatch, error) {
+	klog.V(3).Infoln("Received watchBatch subscription")
+	return resolver.WatchBatchSubscription(ctx, input, initialState, since, overflow, diff, properties,
+		batchWindowMs, maxBatchSize)
+}
+
+// WatchCount is the resolver for the watchCount field.
+func (r *subscriptionResolver) WatchCount(ctx context.Context, input *model.SearchInput) (<-chan *int, error) {
+	klog.V(3).Infoln("Received watchCount subscription")
+	return resolver.WatchCountSubscription(ctx, input)
+}
+
+// Mutation returns generated.MutationResolver implementation.
+func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
+
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
 // Subscription returns generated.SubscriptionResolver implementation.
 func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
 
+type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type subscriptionResolver struct{ *Resolver }
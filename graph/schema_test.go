@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/executor"
+	"github.com/stolostron/search-v2-api/graph/generated"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// Resolves watch with a RESYNC event with the since argument as its seq.
+type sinceResolver struct{ *Resolver }
+type sinceSubscriptionResolver struct{ subscriptionResolver }
+
+func (r *sinceResolver) Subscription() generated.SubscriptionResolver {
+	return &sinceSubscriptionResolver{subscriptionResolver{r.Resolver}}
+}
+
+func (r *sinceSubscriptionResolver) Watch(ctx context.Context, input *model.SearchInput, initialState *bool,
+	since *int, overflow *model.WatchOverflow, diff *model.WatchDiff, properties []*string,
+	queries []*model.WatchQuery) (<-chan *model.Event, error) {
+	events := make(chan *model.Event, 1)
+	events <- &model.Event{Operation: "RESYNC", Seq: since}
+	close(events)
+	return events, nil
+}
+
+// The sequence numbers start at the time in microseconds, outside the 32-bit range of Int.
+func Test_Watch_SinceLiteral(t *testing.T) {
+	exec := executor.New(generated.NewExecutableSchema(generated.Config{Resolvers: &sinceResolver{&Resolver{}}}))
+	ctx := graphql.StartOperationTrace(context.Background())
+
+	for _, since := range []string{`1760000000000001`, `"1760000000000001"`} {
+		opCtx, errs := exec.CreateOperationContext(ctx, &graphql.RawParams{
+			Query: `subscription { watch(since: ` + since + `) { operation seq } }`,
+		})
+		assert.Empty(t, errs, "since: %s", since)
+		if len(errs) > 0 {
+			continue
+		}
+		responses, respCtx := exec.DispatchOperation(ctx, opCtx)
+		response := responses(respCtx)
+		assert.Empty(t, response.Errors, "since: %s", since)
+		assert.JSONEq(t, `{"watch":{"operation":"RESYNC","seq":1760000000000001}}`, string(response.Data))
+	}
+}
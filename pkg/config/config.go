@@ -37,6 +37,7 @@ type Config struct {
 	DBPort                 int
 	DBUser                 string
 	DBReconnectDelay       int              // Duration in milliseconds between reconnect attempts. Default: 5 seconds
+	DBStatementCacheSize   int              // Prepared statements cached per connection. 0 disables. Default: 128
 	DevelopmentMode        bool             // Indicates if running in local development mode.
 	Features               featureFlags     // Enable or disable features.
 	Federation             federationConfig // Federated search configuration.
@@ -44,16 +45,21 @@ type Config struct {
 	PlaygroundMode         bool               // Enable the GraphQL Playground client.
 	PodNamespace           string             // Kubernetes namespace where the pod is running.
 	QueryLimit             uint               // Default LIMIT to use on queries. Client can override.  Default: 1000
+	RbacArrayThreshold     int                // Users with access to more namespaces get RBAC as arrays. 0 disables. Default: 100
 	RelationLevel          int                // Number of levels/hops for finding relationships for a resource
 	SlowLog                int                // Logs queries slower than the specified duration in ms. Default: 300ms
 	RequestTimeout         int                // Seconds a request will process before timing out.      Default: 2 mins
+	SearchCache            searchCacheConfig  // Search results cache configuration.
+	SearchJob              searchJobConfig    // Asynchronous search jobs configuration.
 	Subscription           subscriptionConfig // Subscription limits configuration.
 }
 
 // Define feature flags.
 type featureFlags struct {
+	ConsistentSnapshot  bool // Enables a single read-only transaction for the queries of a search result
 	FederatedSearch     bool // Enables federated search
 	FineGrainedRbac     bool // Enables fine-grained RBAC
+	SearchCache         bool // Enables the search results cache
 	SubscriptionEnabled bool // Enables GraphQL Subscriptions
 }
 
@@ -80,6 +86,28 @@ type subscriptionConfig struct {
 	MaxLifetime     int // Maximum lifetime (milliseconds) for a subscription. Default: 12 hours
 	IdleTimeout     int // Idle timeout (milliseconds) to close inactive subscriptions. Default: 1 hour
 	CleanupInterval int // Interval (milliseconds) between cleanup checks for expired subscriptions. Default: 30 seconds
+	EventLogSize    int // Recent events kept to replay to subscriptions that reconnect. 0 disables. Default: 10000
+	StateCacheSize  int // Resources with the last data kept to recover truncated oldData. 0 disables. Default: 10000
+	CountRefresh    int // Interval (milliseconds) to count again the resources of a watchCount. Default: 1 minute
+}
+
+// Search results cache configuration.
+type searchCacheConfig struct {
+	MaxEntries int // Maximum number of cached results. Default: 500
+	MaxItems   int // Results with more items aren't cached. Default: 1000
+	TTL        int // Time-to-live (milliseconds) of a cached result. Default: 5 minutes
+}
+
+// Asynchronous search jobs configuration.
+type searchJobConfig struct {
+	MaxPerUser            int // Maximum number of pending or running jobs per user. Default: 2
+	MaxResults            int // Maximum number of items kept by a job. Default: 100000
+	MaxStored             int // Maximum number of jobs kept for all users, in any status. Default: 100
+	MaxStoredItems        int // Maximum number of items kept by the jobs of all users. Default: 1000000
+	MaxStoredPerUser      int // Maximum number of jobs kept per user, in any status. Default: 10
+	MaxStoredItemsPerUser int // Maximum number of items kept by the jobs of a user. Default: 300000
+	Timeout               int // Maximum time (milliseconds) a job can run. Default: 30 minutes
+	TTL                   int // Time-to-live (milliseconds) of a job after it completes. Default: 1 hour
 }
 
 func new() *Config {
@@ -104,11 +132,14 @@ func new() *Config {
 		DBPass:                 getEnv("DB_PASS", ""),
 		DBPort:                 getEnvAsInt("DB_PORT", 5432),
 		DBUser:                 getEnv("DB_USER", ""),
-		DBReconnectDelay:       getEnvAsInt("DB_RECONNECT_DELAY", 5*1000), // 5 seconds
+		DBReconnectDelay:       getEnvAsInt("DB_RECONNECT_DELAY", 5*1000),   // 5 seconds
+		DBStatementCacheSize:   getEnvAsInt("DB_STATEMENT_CACHE_SIZE", 128), // Overrides pgx default (512)
 		DevelopmentMode:        DEVELOPMENT_MODE,
 		Features: featureFlags{
+			ConsistentSnapshot:  getEnvAsBool("FEATURE_CONSISTENT_SNAPSHOT", true),
 			FederatedSearch:     getEnvAsBool("FEATURE_FEDERATED_SEARCH", false),  // In Dev mode default is true.
 			FineGrainedRbac:     getEnvAsBool("FEATURE_FINE_GRAINED_RBAC", false), // In Dev mode default is true.
+			SearchCache:         getEnvAsBool("FEATURE_SEARCH_CACHE", false),      // Results cache is opt-in.
 			SubscriptionEnabled: getEnvAsBool("FEATURE_SUBSCRIPTION", true),       // In Dev mode default is true.
 		},
 		Federation: federationConfig{
@@ -123,20 +154,39 @@ func new() *Config {
 				RequestTimeout:        getEnvAsInt("FEDERATED_REQUEST_TIMEOUT", 60*1000), // 60 seconds.
 			},
 		},
-		HttpPort:       getEnvAsInt("HTTP_PORT", 4010),
-		PlaygroundMode: getEnvAsBool("PLAYGROUND_MODE", false),
-		PodNamespace:   getEnv("POD_NAMESPACE", "open-cluster-management"),
-		QueryLimit:     getEnvAsUint("QUERY_LIMIT", uint(1000)),
-		SlowLog:        getEnvAsInt("SLOW_LOG", 500),
+		HttpPort:           getEnvAsInt("HTTP_PORT", 4010),
+		PlaygroundMode:     getEnvAsBool("PLAYGROUND_MODE", false),
+		PodNamespace:       getEnv("POD_NAMESPACE", "open-cluster-management"),
+		QueryLimit:         getEnvAsUint("QUERY_LIMIT", uint(1000)),
+		RbacArrayThreshold: getEnvAsInt("RBAC_ARRAY_THRESHOLD", 100), // 100 namespaces
+		SlowLog:            getEnvAsInt("SLOW_LOG", 500),
 		// Setting default level to 0 to check if user has explicitly set this variable
 		// This will be updated to 1 for default searches and 3 for applications - unless set by the user
 		RelationLevel:  getEnvAsInt("RELATION_LEVEL", 0),
 		RequestTimeout: getEnvAsInt("REQUEST_TIMEOUT", 2*60*1000), // 2 minutes
+		SearchCache: searchCacheConfig{
+			MaxEntries: getEnvAsInt("SEARCH_CACHE_MAX_ENTRIES", 500),
+			MaxItems:   getEnvAsInt("SEARCH_CACHE_MAX_ITEMS", 1000),
+			TTL:        getEnvAsInt("SEARCH_CACHE_TTL", 5*60*1000), // 5 minutes
+		},
+		SearchJob: searchJobConfig{
+			MaxPerUser:            getEnvAsInt("SEARCH_JOB_MAX_PER_USER", 2),                   // 2 jobs
+			MaxResults:            getEnvAsInt("SEARCH_JOB_MAX_RESULTS", 100000),               // 100k items
+			MaxStored:             getEnvAsInt("SEARCH_JOB_MAX_STORED", 100),                   // 100 jobs
+			MaxStoredItems:        getEnvAsInt("SEARCH_JOB_MAX_STORED_ITEMS", 1000000),         // 1M items
+			MaxStoredPerUser:      getEnvAsInt("SEARCH_JOB_MAX_STORED_PER_USER", 10),           // 10 jobs
+			MaxStoredItemsPerUser: getEnvAsInt("SEARCH_JOB_MAX_STORED_ITEMS_PER_USER", 300000), // 300k items
+			Timeout:               getEnvAsInt("SEARCH_JOB_TIMEOUT", 30*60*1000),               // 30 minutes
+			TTL:                   getEnvAsInt("SEARCH_JOB_TTL", 60*60*1000),                   // 1 hour
+		},
 		Subscription: subscriptionConfig{
 			MaxActive:       getEnvAsInt("SUBSCRIPTION_MAX_ACTIVE", 200),             // 200 subscriptions
 			MaxLifetime:     getEnvAsInt("SUBSCRIPTION_MAX_LIFETIME", 12*60*60*1000), // 12 hours
 			IdleTimeout:     getEnvAsInt("SUBSCRIPTION_IDLE_TIMEOUT", 1*60*60*1000),  // 1 hour
 			CleanupInterval: getEnvAsInt("SUBSCRIPTION_CLEANUP_INTERVAL", 30*1000),   // 30 seconds
+			EventLogSize:    getEnvAsInt("SUBSCRIPTION_EVENT_LOG_SIZE", 10000),
+			StateCacheSize:  getEnvAsInt("SUBSCRIPTION_STATE_CACHE_SIZE", 10000),
+			CountRefresh:    getEnvAsInt("SUBSCRIPTION_COUNT_REFRESH", 60*1000), // 1 minute
 		},
 	}
 
@@ -171,7 +221,7 @@ func (cfg *Config) Validate() error {
 		return errors.New("required environment DB_PASS is not set")
 	}
 
-	// Validate subscription limits - check for malformed env vars and invalid values
+	// Validate subscription, search cache and search job limits - check for malformed env vars and invalid values
 	type subscriptionCheck struct {
 		envVar string
 		value  int
@@ -181,6 +231,18 @@ func (cfg *Config) Validate() error {
 		{"SUBSCRIPTION_MAX_LIFETIME", cfg.Subscription.MaxLifetime},
 		{"SUBSCRIPTION_IDLE_TIMEOUT", cfg.Subscription.IdleTimeout},
 		{"SUBSCRIPTION_CLEANUP_INTERVAL", cfg.Subscription.CleanupInterval},
+		{"SUBSCRIPTION_COUNT_REFRESH", cfg.Subscription.CountRefresh},
+		{"SEARCH_CACHE_MAX_ENTRIES", cfg.SearchCache.MaxEntries},
+		{"SEARCH_CACHE_MAX_ITEMS", cfg.SearchCache.MaxItems},
+		{"SEARCH_CACHE_TTL", cfg.SearchCache.TTL},
+		{"SEARCH_JOB_MAX_PER_USER", cfg.SearchJob.MaxPerUser},
+		{"SEARCH_JOB_MAX_RESULTS", cfg.SearchJob.MaxResults},
+		{"SEARCH_JOB_MAX_STORED", cfg.SearchJob.MaxStored},
+		{"SEARCH_JOB_MAX_STORED_ITEMS", cfg.SearchJob.MaxStoredItems},
+		{"SEARCH_JOB_MAX_STORED_PER_USER", cfg.SearchJob.MaxStoredPerUser},
+		{"SEARCH_JOB_MAX_STORED_ITEMS_PER_USER", cfg.SearchJob.MaxStoredItemsPerUser},
+		{"SEARCH_JOB_TIMEOUT", cfg.SearchJob.Timeout},
+		{"SEARCH_JOB_TTL", cfg.SearchJob.TTL},
 	}
 
 	for _, check := range checks {
@@ -196,6 +258,17 @@ func (cfg *Config) Validate() error {
 			return fmt.Errorf("invalid %s=%d, must be > 0", check.envVar, check.value)
 		}
 	}
+
+	// The items of a job must fit in the items kept, or the job would be removed when it completes.
+	for _, check := range []subscriptionCheck{
+		{"SEARCH_JOB_MAX_STORED_ITEMS", cfg.SearchJob.MaxStoredItems},
+		{"SEARCH_JOB_MAX_STORED_ITEMS_PER_USER", cfg.SearchJob.MaxStoredItemsPerUser},
+	} {
+		if check.value < cfg.SearchJob.MaxResults {
+			return fmt.Errorf("invalid %s=%d, must be >= SEARCH_JOB_MAX_RESULTS=%d", check.envVar, check.value,
+				cfg.SearchJob.MaxResults)
+		}
+	}
 	return nil
 }
 
@@ -127,3 +127,42 @@ func Test_Validate(t *testing.T) {
 		t.Errorf("Expected %s Got: %s", "required environment DB_NAME is not set", result)
 	}
 }
+
+// Should validate the search job limits.
+func Test_Validate_SearchJob(t *testing.T) {
+	_ = os.Setenv("DB_NAME", "test")
+	_ = os.Setenv("DB_USER", "test")
+	_ = os.Setenv("DB_PASS", "test")
+	defer func() {
+		_ = os.Unsetenv("DB_NAME")
+		_ = os.Unsetenv("DB_USER")
+		_ = os.Unsetenv("DB_PASS")
+		_ = os.Unsetenv("SEARCH_JOB_MAX_PER_USER")
+		_ = os.Unsetenv("SEARCH_JOB_TTL")
+		_ = os.Unsetenv("SEARCH_JOB_MAX_STORED_ITEMS")
+	}()
+
+	_ = os.Setenv("SEARCH_JOB_MAX_PER_USER", "0")
+	conf := new()
+	result := conf.Validate()
+	if result == nil || result.Error() != "invalid SEARCH_JOB_MAX_PER_USER=0, must be > 0" {
+		t.Errorf("Expected %s Got: %v", "invalid SEARCH_JOB_MAX_PER_USER=0, must be > 0", result)
+	}
+
+	_ = os.Setenv("SEARCH_JOB_MAX_PER_USER", "3")
+	_ = os.Setenv("SEARCH_JOB_TTL", "1h")
+	conf = new()
+	result = conf.Validate()
+	if result == nil || result.Error() != `invalid SEARCH_JOB_TTL="1h", must be an integer` {
+		t.Errorf("Expected %s Got: %v", `invalid SEARCH_JOB_TTL="1h", must be an integer`, result)
+	}
+
+	_ = os.Unsetenv("SEARCH_JOB_TTL")
+	_ = os.Setenv("SEARCH_JOB_MAX_STORED_ITEMS", "1000")
+	conf = new()
+	result = conf.Validate()
+	expected := "invalid SEARCH_JOB_MAX_STORED_ITEMS=1000, must be >= SEARCH_JOB_MAX_RESULTS=100000"
+	if result == nil || result.Error() != expected {
+		t.Errorf("Expected %s Got: %v", expected, result)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgconn/stmtcache"
 	"github.com/jackc/pgx/v4"
 	pgxpool "github.com/jackc/pgx/v4/pgxpool"
 	"github.com/stolostron/search-v2-api/pkg/config"
@@ -55,9 +57,16 @@ func initializePool(ctx context.Context) {
 		klog.Error("Error parsing database connection configuration.", configErr)
 	}
 
-	config.ConnConfig.BuildStatementCache = nil // Disable statement cache to reduce connection memory usage.
-	config.AfterConnect = afterConnect          // Checks new connection health before using it.
-	config.BeforeAcquire = beforeAcquire        // Checks idle connection health before using it.
+	// Queries use bind parameters, so each query shape is prepared once per connection. The cache is bounded
+	// to limit the connection memory usage.
+	config.ConnConfig.BuildStatementCache = nil
+	if cacheSize := cfg.DBStatementCacheSize; cacheSize > 0 {
+		config.ConnConfig.BuildStatementCache = func(conn *pgconn.PgConn) stmtcache.Cache {
+			return stmtcache.New(conn, stmtcache.ModePrepare, cacheSize)
+		}
+	}
+	config.AfterConnect = afterConnect   // Checks new connection health before using it.
+	config.BeforeAcquire = beforeAcquire // Checks idle connection health before using it.
 	// Add jitter to prevent all connections from being closed at same time.
 	config.MaxConnLifetimeJitter = time.Duration(cfg.DBMaxConnLifeJitter) * time.Millisecond
 	config.MaxConns = int32(cfg.DBMaxConns)
@@ -0,0 +1,100 @@
+// Copyright Contributors to the Open Cluster Management project
+package database
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+)
+
+var recentEvents = newEventLog(config.Cfg.Subscription.EventLogSize)
+
+// Keeps the recent events in memory, to replay the events missed by a subscription that reconnects.
+// Each event gets a sequence number. The numbers start at the time (microseconds) the log is created, so
+// they keep increasing when the API restarts. A number is skipped when events may have been missed, so the
+// subscriptions can't resume from before the gap.
+type eventLog struct {
+	mu       sync.Mutex
+	events   []*model.Event // Ring buffer, the oldest event is at start.
+	lastSeq  int
+	length   int // Number of events in the log.
+	minSince int // The log has all the events after this sequence.
+	start    int
+}
+
+func newEventLog(size int) *eventLog {
+	if size < 0 {
+		size = 0
+	}
+	seq := int(time.Now().UnixMicro())
+	return &eventLog{events: make([]*model.Event, size), lastSeq: seq, minSince: seq + 1}
+}
+
+// Assigns the next sequence number to the event and adds it to the log, removing the oldest event if full.
+func (l *eventLog) add(event *model.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastSeq++
+	seq := l.lastSeq
+	event.Seq = &seq
+	if len(l.events) == 0 {
+		l.minSince = seq
+		return
+	}
+	if l.length == len(l.events) {
+		l.minSince = *l.events[l.start].Seq
+		l.events[l.start] = nil
+		l.start = (l.start + 1) % len(l.events)
+		l.length--
+	}
+	l.events[(l.start+l.length)%len(l.events)] = event
+	l.length++
+}
+
+// Returns the events after the sequence number, or false if the log doesn't have all of them.
+func (l *eventLog) since(seq int) ([]*model.Event, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if seq < l.minSince || seq > l.lastSeq {
+		return nil, false
+	}
+	count := l.lastSeq - seq
+	result := make([]*model.Event, 0, count)
+	for i := l.length - count; i < l.length; i++ {
+		result = append(result, l.events[(l.start+i)%len(l.events)])
+	}
+	return result, true
+}
+
+// Returns the sequence number of the last event.
+func (l *eventLog) last() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lastSeq
+}
+
+// Removes the events and skips a sequence number. Called when events may have been missed.
+func (l *eventLog) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i := range l.events {
+		l.events[i] = nil
+	}
+	l.start, l.length = 0, 0
+	l.lastSeq++
+	l.minSince = l.lastSeq
+}
+
+// EventsSince returns the events received after the event with the sequence number, or false if some of
+// those events aren't in memory anymore or may have been missed.
+func EventsSince(seq int) ([]*model.Event, bool) {
+	return recentEvents.since(seq)
+}
+
+// LastEventSeq returns the sequence number of the last event received. Watching since this number doesn't
+// miss any events received after calling this function.
+func LastEventSeq() int {
+	return recentEvents.last()
+}
@@ -0,0 +1,96 @@
+// Copyright Contributors to the Open Cluster Management project
+package database
+
+import (
+	"testing"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func uids(events []*model.Event) []string {
+	result := []string{}
+	for _, event := range events {
+		result = append(result, event.UID)
+	}
+	return result
+}
+
+func Test_eventLog_Since(t *testing.T) {
+	log := newEventLog(3)
+	log.reset() // The listener connected.
+	start := log.last()
+
+	for _, uid := range []string{"a", "b"} {
+		log.add(&model.Event{UID: uid})
+	}
+
+	events, ok := log.since(start)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "b"}, uids(events))
+	assert.Equal(t, start+1, *events[0].Seq)
+
+	events, ok = log.since(start + 1)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"b"}, uids(events))
+
+	events, ok = log.since(start + 2)
+	assert.True(t, ok)
+	assert.Empty(t, events)
+
+	_, ok = log.since(start + 3) // Not received yet.
+	assert.False(t, ok)
+}
+
+func Test_eventLog_Full(t *testing.T) {
+	log := newEventLog(3)
+	log.reset()
+	start := log.last()
+
+	for _, uid := range []string{"a", "b", "c", "d", "e"} {
+		log.add(&model.Event{UID: uid})
+	}
+
+	_, ok := log.since(start + 1) // "b" was removed.
+	assert.False(t, ok)
+
+	events, ok := log.since(start + 2)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"c", "d", "e"}, uids(events))
+}
+
+func Test_eventLog_Reset(t *testing.T) {
+	log := newEventLog(3)
+	log.reset()
+	log.add(&model.Event{UID: "a"})
+	seq := log.last()
+
+	log.reset() // Events may have been missed.
+	log.add(&model.Event{UID: "b"})
+
+	_, ok := log.since(seq)
+	assert.False(t, ok)
+	assert.Equal(t, seq+2, log.last()) // A sequence number is skipped.
+}
+
+func Test_eventLog_NotListening(t *testing.T) {
+	log := newEventLog(3)
+
+	_, ok := log.since(log.last()) // Events before the listener connects aren't in the log.
+
+	assert.False(t, ok)
+}
+
+func Test_eventLog_Disabled(t *testing.T) {
+	log := newEventLog(0)
+	log.reset()
+	seq := log.last()
+	log.add(&model.Event{UID: "a"})
+
+	_, ok := log.since(seq)
+	assert.False(t, ok)
+
+	events, ok := log.since(log.last())
+	assert.True(t, ok)
+	assert.Empty(t, events)
+}
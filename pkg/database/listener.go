@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/stolostron/search-v2-api/graph/model"
 	"github.com/stolostron/search-v2-api/pkg/config"
+	"github.com/stolostron/search-v2-api/pkg/metrics"
 	"k8s.io/klog/v2"
 )
 
@@ -39,13 +40,23 @@ type Subscription struct {
 	Cancel       context.CancelFunc // Cancels Context; must be called exactly once on teardown
 	CreatedAt    time.Time          // When the subscription was created
 	LastActivity time.Time          // Last time an event was successfully delivered (after filters and RBAC)
-	mu           sync.RWMutex       // Protects LastActivity
+	dropped      int                // Events dropped since the last call to TakeDroppedEvents()
+	mu           sync.RWMutex       // Protects LastActivity and dropped
 	// Lock ordering (outer → inner): listenerMu → listener.mu → sub.mu
 }
 
+// EventHandler receives every event from the listener, e.g. to invalidate a cache.
+// HandleEvent must not block. Reset is called when events may have been missed because the
+// connection was lost or the listener stopped. The handler must register again after a reset.
+type EventHandler interface {
+	HandleEvent(event *model.Event)
+	Reset()
+}
+
 // Listener manages the single goroutine that listens for Postgres events
 type Listener struct {
 	mu            sync.RWMutex
+	handlers      map[string]EventHandler
 	subscriptions map[string]*Subscription
 	conn          MockPgxConnIface //pgxmock.PgxConnIface
 	ctx           context.Context
@@ -63,20 +74,7 @@ func RegisterSubscription(ctx context.Context, subID string, notifyChannel chan
 	// Initialize the listener instance if not already initialized.
 	listenerMu.Lock()
 	defer listenerMu.Unlock()
-	listenerOnce.Do(func() {
-		listenCtx := context.Background()
-		listenCtx, listenCancel := context.WithCancel(listenCtx)
-		listenerInstance = &Listener{
-			subscriptions: make(map[string]*Subscription),
-			conn:          nil,
-			ctx:           listenCtx,
-			cancel:        listenCancel,
-			started:       false,
-		}
-		if err := listenerInstance.Start(); err != nil {
-			klog.Errorf("Failed to start listener: %v", err)
-		}
-	})
+	initListener()
 
 	listenerInstance.mu.Lock()
 	defer listenerInstance.mu.Unlock()
@@ -107,6 +105,45 @@ func RegisterSubscription(ctx context.Context, subID string, notifyChannel chan
 	return subCtx, nil
 }
 
+// Initializes and starts the listener instance if not already initialized. Must be called with listenerMu locked.
+func initListener() {
+	listenerOnce.Do(func() {
+		listenCtx := context.Background()
+		listenCtx, listenCancel := context.WithCancel(listenCtx)
+		listenerInstance = &Listener{
+			handlers:      make(map[string]EventHandler),
+			subscriptions: make(map[string]*Subscription),
+			conn:          nil,
+			ctx:           listenCtx,
+			cancel:        listenCancel,
+			started:       false,
+		}
+		if err := listenerInstance.Start(); err != nil {
+			klog.Errorf("Failed to start listener: %v", err)
+		}
+	})
+}
+
+// RegisterEventHandler registers a handler to receive all the events from the database.
+// Starts the listener if not already started. The listener keeps running while there are handlers.
+// Returns an error if the listener isn't connected, because the handler wouldn't receive the events.
+func RegisterEventHandler(handlerID string, handler EventHandler) error {
+	listenerMu.Lock()
+	defer listenerMu.Unlock()
+	initListener()
+
+	listenerInstance.mu.Lock()
+	defer listenerInstance.mu.Unlock()
+	if !listenerInstance.started || listenerInstance.conn == nil {
+		return fmt.Errorf("the listener isn't connected to the database")
+	}
+	if _, exists := listenerInstance.handlers[handlerID]; !exists {
+		klog.V(2).Infof("Registered event handler [%s].", handlerID)
+	}
+	listenerInstance.handlers[handlerID] = handler
+	return nil
+}
+
 func UnregisterSubscription(subID string) {
 	listenerMu.Lock()
 	listener := listenerInstance
@@ -129,7 +166,7 @@ func UnregisterSubscription(subID string) {
 	delete(listener.subscriptions, subID)
 	klog.Infof("Unregistered subscription %s. (%d active subscriptions)", subID, len(listener.subscriptions))
 
-	if len(listener.subscriptions) == 0 {
+	if len(listener.subscriptions) == 0 && len(listener.handlers) == 0 {
 		klog.Info("No more active subscriptions, shutting down listener.")
 		listener.cancel()
 	}
@@ -158,6 +195,31 @@ func UpdateSubscriptionActivity(subID string) {
 	}
 }
 
+// TakeDroppedEvents returns the number of events dropped for a subscription because its channel was full,
+// since the last call. The subscription decides how to recover, see the watch overflow policy.
+func TakeDroppedEvents(subID string) int {
+	listenerMu.Lock()
+	listener := listenerInstance
+	listenerMu.Unlock()
+
+	if listener == nil {
+		return 0
+	}
+
+	listener.mu.RLock()
+	sub, exists := listener.subscriptions[subID]
+	listener.mu.RUnlock()
+
+	if !exists {
+		return 0
+	}
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	dropped := sub.dropped
+	sub.dropped = 0
+	return dropped
+}
+
 // Start initializes and starts the listener goroutine
 func (l *Listener) Start() error {
 	l.mu.Lock()
@@ -210,6 +272,9 @@ func (l *Listener) connect() error {
 	}
 
 	l.conn = conn
+	// Events may have been missed while not listening, subscriptions can't resume from before.
+	recentEvents.reset()
+	lastKnownState.reset()
 	klog.V(2).Infof("Listening to Postgres channel: %s", channelName)
 	return nil
 }
@@ -224,10 +289,13 @@ func (l *Listener) listen() {
 				return
 			}
 		}
+		l.resetHandlers()
 		listenerMu.Lock()
 		defer listenerMu.Unlock()
-		listenerInstance = nil
-		listenerOnce = sync.Once{}
+		if listenerInstance == l {
+			listenerInstance = nil
+			listenerOnce = sync.Once{}
+		}
 		klog.Info("Subscription listener stopped.")
 	}()
 
@@ -270,6 +338,8 @@ func (l *Listener) listen() {
 // RLock during sends is required for safety: it prevents UnregisterSubscription (which needs the
 // write lock) from completing while a send is in progress, ensuring subscriber channels are not
 // closed mid-send.
+// All the subscriptions receive the same *model.Event, so they must not modify it. A subscription that changes
+// the event, e.g. to remove properties or tag the queries matched, sends a copy.
 func (l *Listener) forwardNotification(notification *pgconn.Notification) {
 	var notificationPayload model.Event
 	err := json.Unmarshal([]byte(notification.Payload), &notificationPayload)
@@ -311,10 +381,24 @@ func (l *Listener) forwardNotification(notification *pgconn.Notification) {
 			return
 		}
 	}
+	// If oldData was truncated, use the last data received for the resource.
 	if notificationPayload.OldData == nil &&
 		(notificationPayload.Operation == "UPDATE" || notificationPayload.Operation == "DELETE") {
-		klog.Warningf("Notification payload was truncated, 'oldData' is missing. This is a current limitation. UID: %s", notificationPayload.UID)
+		if oldData, found := lastKnownState.get(notificationPayload.UID); found {
+			klog.V(2).Infof("Notification payload was truncated, recovered 'oldData' from the last known state. UID: %s",
+				notificationPayload.UID)
+			notificationPayload.OldData = oldData
+			metrics.SubscriptionOldDataRecovery.WithLabelValues("recovered").Inc()
+		} else {
+			klog.Warningf("Notification payload was truncated, 'oldData' is missing and not in the last known state. UID: %s",
+				notificationPayload.UID)
+			metrics.SubscriptionOldDataRecovery.WithLabelValues("missing").Inc()
+		}
 	}
+	lastKnownState.update(&notificationPayload)
+
+	// Assign the sequence number and keep the event to replay it to subscriptions that reconnect.
+	recentEvents.add(&notificationPayload)
 
 	// Hold RLock during fan-out. Non-blocking sends ensure we never block while holding the lock.
 	// The RLock also prevents subscriber channels from being closed mid-send (UnregisterSubscription
@@ -322,6 +406,10 @@ func (l *Listener) forwardNotification(notification *pgconn.Notification) {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
 
+	for _, handler := range l.handlers {
+		handler.HandleEvent(&notificationPayload)
+	}
+
 	klog.V(3).Infof("Received postgres event, forwarding to %d subscriptions.", len(l.subscriptions))
 	for _, sub := range l.subscriptions {
 		// Check Done first (without competing with the send case) to guarantee
@@ -332,11 +420,15 @@ func (l *Listener) forwardNotification(notification *pgconn.Notification) {
 			continue
 		default:
 		}
-		// Non-blocking send: drop the event if the channel buffer is full.
+		// Non-blocking send: drop the event if the channel buffer is full. The subscription finds out
+		// with TakeDroppedEvents().
 		select {
 		case sub.Channel <- &notificationPayload:
 		default:
 			klog.Warningf("Subscription %s channel buffer is full, dropping event.", sub.ID)
+			sub.mu.Lock()
+			sub.dropped++
+			sub.mu.Unlock()
 		}
 	}
 }
@@ -353,6 +445,8 @@ func (l *Listener) handleConnectionError() {
 		l.conn = nil
 	}
 	l.mu.Unlock()
+	// Events are missed while disconnected.
+	l.resetHandlers()
 
 	// Wait before reconnecting
 	time.Sleep(time.Duration(config.Cfg.DBReconnectDelay) * time.Millisecond)
@@ -367,6 +461,18 @@ func (l *Listener) handleConnectionError() {
 	}
 }
 
+// resetHandlers removes the event handlers and notifies them that events may have been missed.
+func (l *Listener) resetHandlers() {
+	l.mu.Lock()
+	handlers := l.handlers
+	l.handlers = make(map[string]EventHandler)
+	l.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler.Reset()
+	}
+}
+
 // cleanupExpiredSubscriptions periodically checks for subscriptions that have exceeded
 // their maximum lifetime or idle timeout and closes them.
 func (l *Listener) cleanupExpiredSubscriptions() {
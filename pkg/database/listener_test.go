@@ -1304,6 +1304,33 @@ func TestForwardNotification_ChannelFull(t *testing.T) {
 	assert.Equal(t, 2, len(ch), "Channel length should be unchanged (new event dropped)")
 }
 
+// TestTakeDroppedEvents verifies that the subscription can find out about the events dropped.
+func TestTakeDroppedEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan *model.Event, 1)
+	l, _ := buildTestListener(ctx, "sub-1", ch, MockPgxConn{})
+	listenerMu.Lock()
+	listenerInstance = l
+	listenerMu.Unlock()
+	defer func() {
+		listenerMu.Lock()
+		listenerInstance = nil
+		listenerMu.Unlock()
+	}()
+
+	for i := 0; i < 3; i++ {
+		l.forwardNotification(&pgconn.Notification{
+			Payload: `{"uid":"u1","operation":"DELETE","timestamp":"2024-01-01T00:00:00Z","oldData":{}}`,
+		})
+	}
+
+	assert.Equal(t, 2, TakeDroppedEvents("sub-1"))
+	assert.Equal(t, 0, TakeDroppedEvents("sub-1"), "The count should be reset")
+	assert.Equal(t, 0, TakeDroppedEvents("unknown-sub"))
+}
+
 // TestForwardNotification_CancelledSubscription verifies that a cancelled subscription is skipped.
 func TestForwardNotification_CancelledSubscription(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -1478,3 +1505,88 @@ func TestForwardNotification_BackfillScanError(t *testing.T) {
 
 	assert.Equal(t, 0, len(ch), "Event should be dropped when scan fails")
 }
+
+// Records the events and resets received by an event handler.
+type mockEventHandler struct {
+	events []*model.Event
+	resets int
+}
+
+func (m *mockEventHandler) HandleEvent(event *model.Event) {
+	m.events = append(m.events, event)
+}
+
+func (m *mockEventHandler) Reset() {
+	m.resets++
+}
+
+// TestRegisterEventHandler_NotConnected verifies that a handler isn't registered when the listener
+// can't connect, because it wouldn't receive the events.
+func TestRegisterEventHandler_NotConnected(t *testing.T) {
+	StopPostgresListener()
+	defer StopPostgresListener()
+
+	err := RegisterEventHandler("handler-1", &mockEventHandler{})
+
+	assert.Error(t, err)
+}
+
+// TestForwardNotification_EventHandlers verifies that handlers receive the events, keep the listener
+// running after the last subscription is removed, and are reset when the listener stops.
+func TestForwardNotification_EventHandlers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan *model.Event, 10)
+	l, _ := buildTestListener(ctx, "sub-1", ch, MockPgxConn{})
+	handler := &mockEventHandler{}
+	l.handlers = map[string]EventHandler{"handler-1": handler}
+	listenerMu.Lock()
+	listenerInstance = l
+	listenerMu.Unlock()
+	defer StopPostgresListener()
+
+	l.forwardNotification(&pgconn.Notification{
+		Payload: `{"uid":"u1","operation":"DELETE","timestamp":"2024-01-01T00:00:00Z"}`,
+	})
+	assert.Equal(t, 1, len(handler.events), "Handler should receive the event")
+	assert.Equal(t, 1, len(ch), "Subscription should receive the event")
+
+	UnregisterSubscription("sub-1")
+	assert.Nil(t, l.ctx.Err(), "Listener should keep running while there are handlers")
+
+	l.resetHandlers()
+	assert.Equal(t, 1, handler.resets, "Handler should be reset")
+	assert.Equal(t, 0, len(l.handlers), "Handler must register again after a reset")
+}
+
+// TestForwardNotification_RecoverOldData verifies that truncated oldData is recovered from the last known state.
+func TestForwardNotification_RecoverOldData(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lastKnownState.reset()
+	defer lastKnownState.reset()
+
+	ch := make(chan *model.Event, 10)
+	l, _ := buildTestListener(ctx, "sub-1", ch, MockPgxConn{})
+
+	l.forwardNotification(&pgconn.Notification{
+		Payload: `{"uid":"state-1","operation":"INSERT","timestamp":"2024-01-01T00:00:00Z","newData":{"kind":"Pod","namespace":"ns1"}}`,
+	})
+	// DELETE truncated, without oldData.
+	l.forwardNotification(&pgconn.Notification{
+		Payload: `{"uid":"state-1","operation":"DELETE","timestamp":"2024-01-01T00:00:01Z"}`,
+	})
+	// Not received before.
+	l.forwardNotification(&pgconn.Notification{
+		Payload: `{"uid":"state-2","operation":"DELETE","timestamp":"2024-01-01T00:00:01Z"}`,
+	})
+
+	assert.Equal(t, 3, len(ch))
+	<-ch
+	deleted := <-ch
+	assert.Equal(t, map[string]any{"kind": "Pod", "namespace": "ns1"}, deleted.OldData)
+	assert.Nil(t, (<-ch).OldData)
+	_, found := lastKnownState.get("state-1")
+	assert.False(t, found, "Deleted resources should be removed")
+}
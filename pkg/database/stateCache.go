@@ -0,0 +1,78 @@
+// Copyright Contributors to the Open Cluster Management project
+package database
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+)
+
+var lastKnownState = newStateCache(config.Cfg.Subscription.StateCacheSize)
+
+// Keeps the last data received for each resource, to recover the oldData of the notifications truncated
+// because the payload was too large. The least recently changed resources are removed when full.
+type stateCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // Most recently changed at the front.
+	size    int
+}
+
+type stateCacheEntry struct {
+	uid  string
+	data map[string]any
+}
+
+func newStateCache(size int) *stateCache {
+	return &stateCache{entries: map[string]*list.Element{}, lru: list.New(), size: size}
+}
+
+// Returns the last data received for the resource.
+func (c *stateCache) get(uid string) (map[string]any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, found := c.entries[uid]
+	if !found {
+		return nil, false
+	}
+	return element.Value.(*stateCacheEntry).data, true
+}
+
+// Keeps the data of the event, or removes the resource if it was deleted. The data isn't modified after,
+// so it's shared with the events.
+func (c *stateCache) update(event *model.Event) {
+	if c.size <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, found := c.entries[event.UID]
+	if event.Operation == "DELETE" || event.NewData == nil {
+		if found {
+			c.lru.Remove(element)
+			delete(c.entries, event.UID)
+		}
+		return
+	}
+	if found {
+		element.Value.(*stateCacheEntry).data = event.NewData
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[event.UID] = c.lru.PushFront(&stateCacheEntry{uid: event.UID, data: event.NewData})
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*stateCacheEntry).uid)
+	}
+}
+
+// Removes all the resources. Called when events may have been missed, so the data could be outdated.
+func (c *stateCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]*list.Element{}
+	c.lru.Init()
+}
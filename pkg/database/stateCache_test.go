@@ -0,0 +1,45 @@
+// Copyright Contributors to the Open Cluster Management project
+package database
+
+import (
+	"testing"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_stateCache_RemovesLeastRecentlyChanged(t *testing.T) {
+	cache := newStateCache(2)
+
+	cache.update(&model.Event{UID: "a", Operation: "INSERT", NewData: map[string]any{"v": 1}})
+	cache.update(&model.Event{UID: "b", Operation: "INSERT", NewData: map[string]any{"v": 1}})
+	cache.update(&model.Event{UID: "a", Operation: "UPDATE", NewData: map[string]any{"v": 2}})
+	cache.update(&model.Event{UID: "c", Operation: "INSERT", NewData: map[string]any{"v": 1}})
+
+	data, found := cache.get("a")
+	assert.True(t, found)
+	assert.Equal(t, map[string]any{"v": 2}, data)
+	_, found = cache.get("b")
+	assert.False(t, found)
+	_, found = cache.get("c")
+	assert.True(t, found)
+}
+
+func Test_stateCache_Reset(t *testing.T) {
+	cache := newStateCache(2)
+	cache.update(&model.Event{UID: "a", Operation: "INSERT", NewData: map[string]any{"v": 1}})
+
+	cache.reset()
+
+	_, found := cache.get("a")
+	assert.False(t, found)
+}
+
+func Test_stateCache_Disabled(t *testing.T) {
+	cache := newStateCache(0)
+
+	cache.update(&model.Event{UID: "a", Operation: "INSERT", NewData: map[string]any{"v": 1}})
+
+	_, found := cache.get("a")
+	assert.False(t, found)
+}
@@ -24,6 +24,23 @@ var (
 		Help: "Latency (seconds) for database queries.",
 	}, []string{"query_name"})
 
+	DBQueriesCoalesced = promauto.With(PromRegistry).NewCounterVec(prometheus.CounterOpts{
+		Name: "search_api_db_queries_coalesced",
+		Help: "The number of queries that shared the result of an identical query in flight.",
+	}, []string{"query_name"})
+
+	// RBAC where clause metrics, by type of RBAC (basic, fine_grained or cluster_admin).
+	RbacClauseBuildDuration = promauto.With(PromRegistry).NewHistogramVec(prometheus.HistogramOpts{
+		Name: "search_api_rbac_clause_build_duration",
+		Help: "Time (seconds) to build the RBAC where clause for a user.",
+	}, []string{"rbac"})
+
+	RbacClauseSize = promauto.With(PromRegistry).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "search_api_rbac_clause_size",
+		Help:    "Size (bytes) of the SQL for the RBAC where clause of a user.",
+		Buckets: prometheus.ExponentialBuckets(256, 4, 8), // 256 bytes to 4 MB
+	}, []string{"rbac"})
+
 	// Subscription metrics (WebSockets)
 	SubscriptionsActive = promauto.With(PromRegistry).NewGauge(prometheus.GaugeOpts{
 		Name: "search_api_subscriptions_active",
@@ -35,6 +52,22 @@ var (
 		Help: "Duration (seconds) of subscriptions.",
 	})
 
+	// Events that a subscription's client didn't receive fast enough, by subscription. Removed when it closes.
+	SubscriptionEventsDropped = promauto.With(PromRegistry).NewCounterVec(prometheus.CounterOpts{
+		Name: "search_api_subscription_events_dropped",
+		Help: "The number of events dropped because the subscription buffer was full.",
+	}, []string{"subscription"})
+
+	SubscriptionEventsCoalesced = promauto.With(PromRegistry).NewCounterVec(prometheus.CounterOpts{
+		Name: "search_api_subscription_events_coalesced",
+		Help: "The number of events replaced by a later event for the same resource before the client received them.",
+	}, []string{"subscription"})
+
+	SubscriptionOldDataRecovery = promauto.With(PromRegistry).NewCounterVec(prometheus.CounterOpts{
+		Name: "search_api_subscription_old_data_recovery",
+		Help: "The number of truncated notifications without oldData, by result (recovered or missing).",
+	}, []string{"result"})
+
 	WebSocketConnectionsTotal = promauto.With(PromRegistry).NewCounter(prometheus.CounterOpts{
 		Name: "search_api_websocket_connections_total",
 		Help: "The total number of WebSocket connection attempts.",
@@ -44,4 +77,20 @@ var (
 		Name: "search_api_websocket_connections_failed",
 		Help: "The number of failed WebSocket connection attempts.",
 	}, []string{"reason"})
+
+	// Search results cache metrics
+	SearchCacheRequests = promauto.With(PromRegistry).NewCounterVec(prometheus.CounterOpts{
+		Name: "search_api_search_cache_requests",
+		Help: "The number of search results cache lookups, by result (hit or miss).",
+	}, []string{"result"})
+
+	SearchCacheEntries = promauto.With(PromRegistry).NewGauge(prometheus.GaugeOpts{
+		Name: "search_api_search_cache_entries",
+		Help: "The number of results in the search cache.",
+	})
+
+	SearchCacheInvalidations = promauto.With(PromRegistry).NewCounter(prometheus.CounterOpts{
+		Name: "search_api_search_cache_invalidations",
+		Help: "The number of search cache results removed because the resources changed.",
+	})
 )
@@ -0,0 +1,55 @@
+// Copyright Contributors to the Open Cluster Management project
+package rbac
+
+import (
+	"sync"
+	"time"
+
+	"github.com/doug-martin/goqu/v9/exp"
+)
+
+// Identifies a generation of the user's cached data. Changes when any of the user's caches is refreshed.
+type userDataGeneration [4]time.Time
+
+// Caches the RBAC where clause compiled from the user's data. For users with access to many namespaces the
+// clause is large, so it's compiled once for each generation of the data and shared by all queries.
+type rbacClauseCache struct {
+	lock       sync.Mutex
+	clause     exp.Expression
+	generation userDataGeneration
+}
+
+// Reference to the clause cache of the user, with the generation of the data copied by GetUserData().
+type rbacClauseRef struct {
+	cache      *rbacClauseCache
+	generation userDataGeneration
+}
+
+// Get the generation of the user's data.
+func (user *UserDataCache) generation() userDataGeneration {
+	var generation userDataGeneration
+	for i, meta := range []*cacheMetadata{&user.csrCache, &user.nsrCache, &user.clustersCache,
+		&user.userPermissionCache} {
+		meta.lock.RLock()
+		generation[i] = meta.updatedAt
+		meta.lock.RUnlock()
+	}
+	return generation
+}
+
+// RbacClause returns the RBAC where clause for the user. The clause is compiled with compile() the first time
+// and reused until the user's cached data is refreshed. Concurrent callers wait for the clause being compiled.
+// When the UserData wasn't returned by GetUserData(), the clause is compiled every time.
+func (userData UserData) RbacClause(compile func() exp.Expression) exp.Expression {
+	if userData.rbacClause == nil {
+		return compile()
+	}
+	cache := userData.rbacClause.cache
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	if cache.clause == nil || cache.generation != userData.rbacClause.generation {
+		cache.clause = compile()
+		cache.generation = userData.rbacClause.generation
+	}
+	return cache.clause
+}
@@ -0,0 +1,66 @@
+// Copyright Contributors to the Open Cluster Management project
+package rbac
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RbacClause_ReusedForSameGeneration(t *testing.T) {
+	mock_cache := mockNamespaceCache()
+	mock_cache = setupToken(mock_cache)
+	mock_cache.users["unique-user-id"] = &UserDataCache{
+		UserData: UserData{
+			CsResources: []Resource{{Apigroup: "storage.k8s.io", Kind: "nodes"}},
+			NsResources: map[string][]Resource{"ns1": {{Apigroup: "", Kind: "pods"}}},
+		},
+		clustersCache:       cacheMetadata{updatedAt: time.Now()},
+		csrCache:            cacheMetadata{updatedAt: time.Now()},
+		nsrCache:            cacheMetadata{updatedAt: time.Now()},
+		userPermissionCache: cacheMetadata{updatedAt: time.Now()},
+	}
+	ctx := context.WithValue(context.Background(), ContextAuthTokenKey, "123456")
+	compiled := 0
+	compile := func() exp.Expression {
+		compiled++
+		return goqu.L("TRUE")
+	}
+
+	userData1, err := mock_cache.GetUserData(ctx)
+	assert.NoError(t, err)
+	userData2, err := mock_cache.GetUserData(ctx)
+	assert.NoError(t, err)
+	clause1 := userData1.RbacClause(compile)
+	clause2 := userData2.RbacClause(compile)
+
+	assert.Equal(t, 1, compiled)
+	assert.Equal(t, clause1, clause2)
+
+	// Refreshing the user's data starts a new generation.
+	user := mock_cache.users["unique-user-id"]
+	user.nsrCache.updatedAt = time.Now().Add(time.Millisecond)
+	userData3, err := mock_cache.GetUserData(ctx)
+	assert.NoError(t, err)
+	userData3.RbacClause(compile)
+
+	assert.Equal(t, 2, compiled)
+}
+
+func Test_RbacClause_NotFromCache(t *testing.T) {
+	compiled := 0
+	compile := func() exp.Expression {
+		compiled++
+		return goqu.L("TRUE")
+	}
+	userData := UserData{NsResources: map[string][]Resource{"ns1": {{Apigroup: "", Kind: "pods"}}}}
+
+	userData.RbacClause(compile)
+	userData.RbacClause(compile)
+
+	assert.Equal(t, 2, compiled)
+}
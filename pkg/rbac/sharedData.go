@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	_ "github.com/doug-martin/goqu/v9/dialect/postgres"
 	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/driftprogramming/pgxpoolmock"
 	"github.com/stolostron/search-v2-api/pkg/metrics"
@@ -17,6 +18,9 @@ import (
 	"k8s.io/klog/v2"
 )
 
+// Builds queries with PostgreSQL placeholders ($1, $2...).
+var dialect = goqu.Dialect("postgres")
+
 // Cache data shared across all users.
 type SharedData struct {
 	// These are the data fields.
@@ -79,11 +83,11 @@ func (shared *SharedData) getPropertyTypes(ctx context.Context) (map[string]stri
 			"timestamp",
 		).Else(goqu.L("jsonb_typeof(value)"))
 
-	selectDs = goqu.From(schemaTable, jsb).
+	selectDs = dialect.From(schemaTable, jsb).
 		Select(goqu.L("key"), caseExpr.As("datatype")).
 		Distinct()
 
-	query, params, err := selectDs.ToSQL()
+	query, params, err := selectDs.Prepared(true).ToSQL()
 	if err != nil {
 		klog.Errorf("Error building Search query: %s", err.Error())
 		return propTypeMap, err
@@ -222,11 +226,11 @@ func (shared *SharedData) getClusterScopedResources(ctx context.Context) error {
 	// Original query: "SELECT DISTINCT data->>'apigroup', data->>'kind_plural' FROM search.resources WHERE
 	// data?'_hubClusterResource' AND data?'namespace' is FALSE"
 	schemaTable := goqu.S("search").Table("resources")
-	ds := goqu.From(schemaTable)
-	query, _, err := ds.SelectDistinct(goqu.COALESCE(goqu.L(`"data"->>'apigroup'`), "").As("apigroup"),
+	ds := dialect.From(schemaTable)
+	query, params, err := ds.SelectDistinct(goqu.COALESCE(goqu.L(`"data"->>'apigroup'`), "").As("apigroup"),
 		goqu.COALESCE(goqu.L(`"data"->>'kind_plural'`), "").As("kind")).
 		Where(goqu.L("???", goqu.C("data"), goqu.Literal("?"), "_hubClusterResource"),
-			goqu.L("???", goqu.C("data"), goqu.Literal("?"), "namespace").IsFalse()).ToSQL()
+			goqu.L("???", goqu.C("data"), goqu.Literal("?"), "namespace").IsFalse()).Prepared(true).ToSQL()
 	if err != nil {
 		klog.Errorf("Error creating query [%s]. Error: [%+v]", query, err)
 		shared.csrCache.err = err
@@ -234,7 +238,7 @@ func (shared *SharedData) getClusterScopedResources(ctx context.Context) error {
 		return shared.csrCache.err
 	}
 
-	rows, err := shared.pool.Query(ctx, query)
+	rows, err := shared.pool.Query(ctx, query, params...)
 	if err != nil {
 		klog.Errorf("Error resolving cluster scoped resources. Query [%s]. Error: [%+v]", query, err.Error())
 		shared.csrCache.err = err
@@ -403,7 +407,7 @@ func (shared *SharedData) setDisabledClusters(disabledClusters map[string]struct
 }
 
 // Build the query to find any ManagedClusters where the search addon is disabled.
-func buildSearchAddonDisabledQuery(ctx context.Context) (string, error) {
+func buildSearchAddonDisabledQuery(ctx context.Context) (string, []interface{}, error) {
 	var selectDs *goqu.SelectDataset
 
 	//FROM CLAUSE
@@ -413,7 +417,7 @@ func buildSearchAddonDisabledQuery(ctx context.Context) (string, error) {
 	// For each ManagedClusterInfo resource in the hub,
 	// we should have a matching ManagedClusterAddOn
 	// with name=search-collector in the same namespace.
-	ds := goqu.From(schemaTable1).
+	ds := dialect.From(schemaTable1).
 		LeftOuterJoin(schemaTable2,
 			goqu.On(goqu.L(`"mcInfo".data->>?`, "name").Eq(goqu.L(`"srchAddon".data->>?`, "namespace")),
 				goqu.L(`"srchAddon".data->>?`, "kind").Eq("ManagedClusterAddOn"),
@@ -433,26 +437,26 @@ func buildSearchAddonDisabledQuery(ctx context.Context) (string, error) {
 	whereDs = append(whereDs, goqu.L(`"mcInfo".data->>?`, "name").Neq("local-cluster"))
 
 	//Get the query
-	sql, params, err := selectDs.Where(whereDs...).ToSQL()
+	sql, params, err := selectDs.Where(whereDs...).Prepared(true).ToSQL()
 	if err != nil {
 		klog.Errorf("Error building Query for managed clusters with Search addon disabled: %s", err.Error())
-		return "", err
+		return "", nil, err
 	}
 	klog.V(3).Infof("Query for managed clusters with Search addon disabled: %s %s\n", sql, params)
-	return sql, nil
+	return sql, params, nil
 }
 
 func (shared *SharedData) findSrchAddonDisabledClusters(ctx context.Context) (*map[string]struct{}, error) {
 	disabledClusters := make(map[string]struct{})
 	// build the query
-	sql, queryBuildErr := buildSearchAddonDisabledQuery(ctx)
+	sql, params, queryBuildErr := buildSearchAddonDisabledQuery(ctx)
 	if queryBuildErr != nil {
 		klog.Error("Error fetching SearchAddon disabled cluster results from db ", queryBuildErr)
 		shared.setDisabledClusters(disabledClusters, queryBuildErr)
 		return &disabledClusters, queryBuildErr
 	}
 	// run the query
-	rows, err := shared.pool.Query(ctx, sql)
+	rows, err := shared.pool.Query(ctx, sql, params...)
 	if err != nil {
 		klog.Error("Error fetching SearchAddon disabled cluster results from db ", err)
 		shared.setDisabledClusters(disabledClusters, err)
@@ -62,13 +62,13 @@ func Test_getClusterScopedResources_emptyCache(t *testing.T) {
 	pgxRows1 := pgxpoolmock.NewRows(columns1).AddRow("kind", "string").AddRow("apigroup", "string").ToPgxRows()
 
 	mockpool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT COALESCE("data"->>'apigroup', '') AS "apigroup", COALESCE("data"->>'kind_plural', '') AS "kind" FROM "search"."resources" WHERE ("data"?'_hubClusterResource' AND ("data"?'namespace' IS FALSE))`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT COALESCE("data"->>'apigroup', $1) AS "apigroup", COALESCE("data"->>'kind_plural', $2) AS "kind" FROM "search"."resources" WHERE ("data"?$3 AND ("data"?$4 IS FALSE))`),
+		gomock.Eq([]interface{}{"", "", "_hubClusterResource", "namespace"}),
 	).Return(pgxRows, nil)
 
 	mockpool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN 'timestamp' ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN $1 ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
+		gomock.Eq([]interface{}{"timestamp"}),
 	).Return(pgxRows1, nil)
 
 	propTypes, _ := mock_cache.GetPropertyTypes(ctx, true) //query map
@@ -103,13 +103,13 @@ func Test_getResouces_usingCache(t *testing.T) {
 	pgxRows1 := pgxpoolmock.NewRows(columns1).AddRow("kind", "string").AddRow("apigroup", "string").ToPgxRows()
 
 	mockpool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT COALESCE("data"->>'apigroup', '') AS "apigroup", COALESCE("data"->>'kind_plural', '') AS "kind" FROM "search"."resources" WHERE ("data"?'_hubClusterResource' AND ("data"?'namespace' IS FALSE))`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT COALESCE("data"->>'apigroup', $1) AS "apigroup", COALESCE("data"->>'kind_plural', $2) AS "kind" FROM "search"."resources" WHERE ("data"?$3 AND ("data"?$4 IS FALSE))`),
+		gomock.Eq([]interface{}{"", "", "_hubClusterResource", "namespace"}),
 	).Return(pgxRows, nil)
 
 	mockpool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN 'timestamp' ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN $1 ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
+		gomock.Eq([]interface{}{"timestamp"}),
 	).Return(pgxRows1, nil)
 
 	namespaces := []string{"test-namespace"}
@@ -157,13 +157,13 @@ func Test_getResources_expiredCache(t *testing.T) {
 	pgxRows1 := pgxpoolmock.NewRows(columns1).AddRow("kind", "string").AddRow("apigroup", "string").ToPgxRows()
 
 	mockpool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT COALESCE("data"->>'apigroup', '') AS "apigroup", COALESCE("data"->>'kind_plural', '') AS "kind" FROM "search"."resources" WHERE ("data"?'_hubClusterResource' AND ("data"?'namespace' IS FALSE))`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT COALESCE("data"->>'apigroup', $1) AS "apigroup", COALESCE("data"->>'kind_plural', $2) AS "kind" FROM "search"."resources" WHERE ("data"?$3 AND ("data"?$4 IS FALSE))`),
+		gomock.Eq([]interface{}{"", "", "_hubClusterResource", "namespace"}),
 	).Return(pgxRows, nil)
 
 	mockpool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN 'timestamp' ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN $1 ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
+		gomock.Eq([]interface{}{"timestamp"}),
 	).Return(pgxRows1, nil)
 
 	namespaces := []string{"test-namespace"}
@@ -367,7 +367,8 @@ func Test_getDisabledClustersCacheInValid_RunQuery(t *testing.T) {
 
 	// Mock the database query
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "mcInfo".data->>'name' AS "srchAddonDisabledCluster" FROM "search"."resources" AS "mcInfo" LEFT OUTER JOIN "search"."resources" AS "srchAddon" ON (("mcInfo".data->>'name' = "srchAddon".data->>'namespace') AND ("srchAddon".data->>'kind' = 'ManagedClusterAddOn') AND ("srchAddon".data->>'name' = 'search-collector')) WHERE (("mcInfo".data->>'kind' = 'ManagedClusterInfo') AND ("srchAddon".uid IS NULL) AND ("mcInfo".data->>'name' != 'local-cluster'))`),
+		gomock.Eq(`SELECT DISTINCT "mcInfo".data->>$1 AS "srchAddonDisabledCluster" FROM "search"."resources" AS "mcInfo" LEFT OUTER JOIN "search"."resources" AS "srchAddon" ON (("mcInfo".data->>$2 = "srchAddon".data->>$3) AND ("srchAddon".data->>$4 = $5) AND ("srchAddon".data->>$6 = $7)) WHERE (("mcInfo".data->>$8 = $9) AND ("srchAddon".uid IS NULL) AND ("mcInfo".data->>$10 != $11))`),
+		gomock.Eq(addonDisabledQueryParams),
 	).Return(pgxRows, nil)
 
 	mock_cache.pool = mockPool
@@ -410,7 +411,8 @@ func Test_getDisabledClustersCacheInValid_RunQueryError(t *testing.T) {
 
 	// Mock the database query
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "mcInfo".data->>'name' AS "srchAddonDisabledCluster" FROM "search"."resources" AS "mcInfo" LEFT OUTER JOIN "search"."resources" AS "srchAddon" ON (("mcInfo".data->>'name' = "srchAddon".data->>'namespace') AND ("srchAddon".data->>'kind' = 'ManagedClusterAddOn') AND ("srchAddon".data->>'name' = 'search-collector')) WHERE (("mcInfo".data->>'kind' = 'ManagedClusterInfo') AND ("srchAddon".uid IS NULL) AND ("mcInfo".data->>'name' != 'local-cluster'))`),
+		gomock.Eq(`SELECT DISTINCT "mcInfo".data->>$1 AS "srchAddonDisabledCluster" FROM "search"."resources" AS "mcInfo" LEFT OUTER JOIN "search"."resources" AS "srchAddon" ON (("mcInfo".data->>$2 = "srchAddon".data->>$3) AND ("srchAddon".data->>$4 = $5) AND ("srchAddon".data->>$6 = $7)) WHERE (("mcInfo".data->>$8 = $9) AND ("srchAddon".uid IS NULL) AND ("mcInfo".data->>$10 != $11))`),
+		gomock.Eq(addonDisabledQueryParams),
 	).Return(pgxRows, fmt.Errorf("Error fetching data"))
 	mock_cache.pool = mockPool
 	mock_cache.shared.pool = mockPool
@@ -421,16 +423,20 @@ func Test_getDisabledClustersCacheInValid_RunQueryError(t *testing.T) {
 	}
 }
 
+var addonDisabledQueryParams = []interface{}{"name", "name", "namespace", "kind", "ManagedClusterAddOn", "name",
+	"search-collector", "kind", "ManagedClusterInfo", "name", "local-cluster"}
+
 func Test_Messages_Query(t *testing.T) {
 
-	sql := `SELECT DISTINCT "mcInfo".data->>'name' AS "srchAddonDisabledCluster" FROM "search"."resources" AS "mcInfo" LEFT OUTER JOIN "search"."resources" AS "srchAddon" ON (("mcInfo".data->>'name' = "srchAddon".data->>'namespace') AND ("srchAddon".data->>'kind' = 'ManagedClusterAddOn') AND ("srchAddon".data->>'name' = 'search-collector')) WHERE (("mcInfo".data->>'kind' = 'ManagedClusterInfo') AND ("srchAddon".uid IS NULL) AND ("mcInfo".data->>'name' != 'local-cluster'))`
+	sql := `SELECT DISTINCT "mcInfo".data->>$1 AS "srchAddonDisabledCluster" FROM "search"."resources" AS "mcInfo" LEFT OUTER JOIN "search"."resources" AS "srchAddon" ON (("mcInfo".data->>$2 = "srchAddon".data->>$3) AND ("srchAddon".data->>$4 = $5) AND ("srchAddon".data->>$6 = $7)) WHERE (("mcInfo".data->>$8 = $9) AND ("srchAddon".uid IS NULL) AND ("mcInfo".data->>$10 != $11))`
 	// Execute function
-	query, err := buildSearchAddonDisabledQuery(context.WithValue(context.Background(), ContextAuthTokenKey, "123456"))
+	query, params, err := buildSearchAddonDisabledQuery(context.WithValue(context.Background(), ContextAuthTokenKey, "123456"))
 
 	// Verify response
 	if query != sql {
 		t.Errorf("Expected sql query: %s but got %s", sql, query)
 	}
+	assert.Equal(t, addonDisabledQueryParams, params)
 	if err != nil {
 		t.Errorf("Expected error to be nil, but got : %s", err)
 	}
@@ -450,8 +456,8 @@ func Test_GetPropertyTypes_ConcurrentAccess(t *testing.T) {
 		ToPgxRows()
 
 	mockpool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN 'timestamp' ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN $1 ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
+		gomock.Eq([]interface{}{"timestamp"}),
 	).Return(pgxRows, nil).AnyTimes()
 
 	// Populate cache initially
@@ -510,8 +516,8 @@ func Test_GetPropertyTypes_ReturnsCopy(t *testing.T) {
 		ToPgxRows()
 
 	mockpool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN 'timestamp' ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN $1 ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
+		gomock.Eq([]interface{}{"timestamp"}),
 	).Return(pgxRows, nil)
 
 	// Populate cache
@@ -567,14 +573,14 @@ func Test_GetPropertyTypes_RWMutexLocking(t *testing.T) {
 
 	// First query for initial cache
 	mockpool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN 'timestamp' ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN $1 ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
+		gomock.Eq([]interface{}{"timestamp"}),
 	).Return(pgxRows1, nil)
 
 	// Second query for refresh
 	mockpool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN 'timestamp' ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN $1 ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
+		gomock.Eq([]interface{}{"timestamp"}),
 	).Return(pgxRows2, nil)
 
 	// Initial population
@@ -637,8 +643,8 @@ func Test_GetPropertyTypes_LockUsage(t *testing.T) {
 		ToPgxRows()
 
 	mockpool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN 'timestamp' ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT key, CASE  WHEN (jsonb_typeof(value) = 'string' AND value::text ~ '^"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}') THEN $1 ELSE jsonb_typeof(value) END AS "datatype" FROM "search"."resources", jsonb_each("data")`),
+		gomock.Eq([]interface{}{"timestamp"}),
 	).Return(pgxRows, nil)
 
 	// Test 1: Cached path - should return cached value using RLock
@@ -38,6 +38,8 @@ type UserData struct {
 
 	// Fine-grained RBAC, UserPermissions.clusterview.open-cluster-management.io
 	UserPermissions clusterviewv1alpha1.UserPermissionList
+
+	rbacClause *rbacClauseRef // Compiled RBAC where clause shared by the queries of the user.
 }
 
 // Extend UserData with caching information.
@@ -51,6 +53,8 @@ type UserDataCache struct {
 	nsrCache            cacheMetadata
 	userPermissionCache cacheMetadata // UserPermissions.clusterview.open-cluster-management.io
 
+	rbacClause rbacClauseCache // RBAC where clause compiled from the data.
+
 	// Client to external API to be replaced with a mock by unit tests.
 	authzClient v1.AuthorizationV1Interface
 
@@ -232,6 +236,10 @@ func (cache *Cache) GetUserData(ctx context.Context) (UserData, error) {
 		}
 	}
 
+	// Get the generation before copying the data. If the data is refreshed while copying, the next query
+	// gets a newer generation and compiles the RBAC clause again.
+	generation := userDataCache.generation()
+
 	// Proceed if user's rbac data exists
 	// Get a copy of the current user access if user data exists
 	userAccess := UserData{
@@ -240,6 +248,7 @@ func (cache *Cache) GetUserData(ctx context.Context) (UserData, error) {
 		NsResources:     userDataCache.GetNsResourcesCopy(),
 		ManagedClusters: userDataCache.GetManagedClustersCopy(),
 		UserPermissions: userDataCache.GetUserPermissionsCopy(),
+		rbacClause:      &rbacClauseRef{cache: &userDataCache.rbacClause, generation: generation},
 	}
 	return userAccess, nil
 }
@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/stolostron/search-v2-api/pkg/metrics"
+	"golang.org/x/sync/singleflight"
+	klog "k8s.io/klog/v2"
+)
+
+var queryGroup singleflight.Group
+
+// Runs the query once for concurrent calls with the same SQL and parameters, and returns the same result
+// to all the callers. The SQL includes the user's RBAC clause, so only users with the same permissions
+// share the results. The result must not be modified by the callers.
+// Queries in a snapshot transaction aren't coalesced, because they must see the transaction's snapshot.
+func coalesceQuery[T any](ctx context.Context, name string, pool pgxpoolmock.PgxPool, query string,
+	params []interface{}, resolve func() (T, error)) (T, error) {
+	if _, inTransaction := pool.(*snapshotPool); inTransaction {
+		return resolve()
+	}
+
+	// JSON keeps the parameters apart, e.g. ["a b","c"] and ["a","b c"] have different keys.
+	key, keyErr := json.Marshal([]interface{}{query, params})
+	if keyErr != nil {
+		klog.V(3).Infof("Can't coalesce query [%s], the parameters can't be encoded. %v", name, keyErr)
+		return resolve()
+	}
+	result, err, shared := queryGroup.Do(string(key), func() (interface{}, error) {
+		return resolve()
+	})
+	if shared {
+		metrics.DBQueriesCoalesced.WithLabelValues(name).Inc()
+		// The query was cancelled by the request that executed it, but this request is still active.
+		if err != nil && ctx != nil && ctx.Err() == nil &&
+			(errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			klog.V(3).Infof("Coalesced query [%s] was cancelled by another request, running it again.", name)
+			return resolve()
+		}
+	}
+	if result == nil {
+		var zero T
+		return zero, err
+	}
+	return result.(T), err
+}
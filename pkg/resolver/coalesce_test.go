@@ -0,0 +1,144 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_coalesceQuery_ConcurrentCallsShareResult(t *testing.T) {
+	release := make(chan struct{})
+	var executions int32
+	resolve := func() (int, error) {
+		atomic.AddInt32(&executions, 1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = coalesceQuery(context.Background(), "test", nil, "SELECT 1", []interface{}{}, resolve)
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond) // Let the callers join the query in flight.
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&executions))
+	assert.Equal(t, []int{42, 42, 42, 42, 42}, results)
+}
+
+func Test_coalesceQuery_DifferentParams(t *testing.T) {
+	var executions int32
+	resolve := func() (int, error) {
+		atomic.AddInt32(&executions, 1)
+		return 1, nil
+	}
+
+	_, _ = coalesceQuery(context.Background(), "test", nil, "SELECT $1", []interface{}{"a"}, resolve)
+	_, _ = coalesceQuery(context.Background(), "test", nil, "SELECT $1", []interface{}{"b"}, resolve)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&executions))
+}
+
+func Test_coalesceQuery_AmbiguousParams(t *testing.T) {
+	release := make(chan struct{})
+	var executions int32
+	resolve := func(result string) func() (string, error) {
+		return func() (string, error) {
+			atomic.AddInt32(&executions, 1)
+			<-release
+			return result, nil
+		}
+	}
+
+	// Both parameter lists are formatted as [a b c] with %v.
+	var wg sync.WaitGroup
+	results := make([]string, 2)
+	for i, params := range [][]interface{}{{"a b", "c"}, {"a", "b c"}} {
+		wg.Add(1)
+		go func(i int, params []interface{}) {
+			defer wg.Done()
+			results[i], _ = coalesceQuery(context.Background(), "test", nil, "SELECT $1, $2", params,
+				resolve(params[0].(string)))
+		}(i, params)
+	}
+	time.Sleep(50 * time.Millisecond) // Let both queries start.
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&executions))
+	assert.Equal(t, []string{"a b", "a"}, results)
+}
+
+func Test_coalesceQuery_SnapshotNotCoalesced(t *testing.T) {
+	release := make(chan struct{})
+	var executions int32
+	resolve := func() (int, error) {
+		atomic.AddInt32(&executions, 1)
+		<-release
+		return 1, nil
+	}
+	pool := &snapshotPool{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = coalesceQuery(context.Background(), "test", pool, "SELECT 1", []interface{}{}, resolve)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&executions))
+}
+
+func Test_coalesceQuery_RetryWhenLeaderCancelled(t *testing.T) {
+	release := make(chan struct{})
+	var executions int32
+	resolve := func() (int, error) {
+		if atomic.AddInt32(&executions, 1) == 1 {
+			<-release
+			return 0, context.Canceled // The request that executed the query was cancelled.
+		}
+		return 7, nil
+	}
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderDone := make(chan struct{})
+	go func() {
+		defer close(leaderDone)
+		_, err := coalesceQuery(leaderCtx, "test", nil, "SELECT 2", []interface{}{}, resolve)
+		assert.ErrorIs(t, err, context.Canceled)
+	}()
+	time.Sleep(20 * time.Millisecond) // The first caller executes the query.
+
+	var result int
+	var err error
+	followerDone := make(chan struct{})
+	go func() {
+		defer close(followerDone)
+		result, err = coalesceQuery(context.Background(), "test", nil, "SELECT 2", []interface{}{}, resolve)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	close(release)
+	<-leaderDone
+	<-followerDone
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, result)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&executions))
+}
@@ -0,0 +1,332 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+	db "github.com/stolostron/search-v2-api/pkg/database"
+	"github.com/stolostron/search-v2-api/pkg/metrics"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	klog "k8s.io/klog/v2"
+)
+
+var defaultCompareKeyProperties = []string{"kind", "namespace", "name"}
+
+type CompareClustersResult struct {
+	clusters          []string // The first cluster is the baseline.
+	compareProperties []string // Empty to compare all properties.
+	input             *model.SearchInput
+	keyProperties     []string
+	limit             uint // Max resources per cluster. 0 means no limit.
+	params            []interface{}
+	pool              pgxpoolmock.PgxPool
+	propTypes         map[string]string
+	query             string
+	userData          rbac.UserData
+}
+
+func CompareClusters(ctx context.Context, clusters []string, srchInput *model.SearchInput,
+	keyProperties []*string, compareProperties []*string) ([]*model.ClusterComparison, error) {
+	defer metrics.SlowLog("CompareClustersResolver", 0)()
+
+	uniqueClusters, err := validateCompareClusters(clusters)
+	if err != nil {
+		return nil, err
+	}
+
+	userData, userDataErr := rbac.GetCache().GetUserData(ctx)
+	if userDataErr != nil {
+		return nil, userDataErr
+	}
+
+	// Check that shared cache has property types:
+	propTypes, err := rbac.GetCache().GetPropertyTypes(ctx, false)
+	if err != nil {
+		klog.Warningf("Error creating datatype map with err: [%s] ", err)
+	}
+
+	compareResult := &CompareClustersResult{
+		clusters:          uniqueClusters,
+		compareProperties: nonEmptyValues(compareProperties),
+		input:             srchInput,
+		keyProperties:     nonEmptyValues(keyProperties),
+		pool:              db.GetConnPool(ctx),
+		propTypes:         propTypes,
+		userData:          userData,
+	}
+	if len(compareResult.keyProperties) == 0 {
+		compareResult.keyProperties = defaultCompareKeyProperties
+	}
+	compareResult.limit = compareResult.setLimit()
+
+	if err := compareResult.buildCompareClustersQuery(ctx); err != nil {
+		return nil, err
+	}
+	return compareResult.compareClustersResults(ctx)
+}
+
+// Validate the clusters and remove duplicates, keeping the baseline first.
+func validateCompareClusters(clusters []string) ([]string, error) {
+	uniqueClusters := make([]string, 0, len(clusters))
+	seen := map[string]struct{}{}
+	for _, cluster := range clusters {
+		if cluster == "" {
+			return nil, errors.New("invalid input. The cluster name must not be empty")
+		}
+		if _, found := seen[cluster]; !found {
+			seen[cluster] = struct{}{}
+			uniqueClusters = append(uniqueClusters, cluster)
+		}
+	}
+	if len(uniqueClusters) < 2 {
+		return nil, errors.New("invalid input. At least 2 clusters are required to compare")
+	}
+	return uniqueClusters, nil
+}
+
+// The limit applies to each cluster, so the query is limited to limit * clusters.
+func (s *CompareClustersResult) setLimit() uint {
+	if s.input != nil && s.input.Limit != nil {
+		if *s.input.Limit == -1 {
+			klog.V(2).Info("No limit set on compareClusters query. Fetching all results.")
+			return 0
+		}
+		if *s.input.Limit > 0 {
+			return uint(*s.input.Limit) // #nosec G115 - Validated positive via > 0 check
+		}
+	}
+	return config.Cfg.QueryLimit
+}
+
+// Sample query:
+// SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources"
+// WHERE (("data"->'namespace'?('foo')) AND ("cluster" IN ('cluster-a', 'cluster-b')) AND (<RBAC>))
+// ORDER BY "uid" ASC LIMIT 2001
+func (s *CompareClustersResult) buildCompareClustersQuery(ctx context.Context) error {
+	var whereDs []exp.Expression
+	var err error
+
+	schemaTable := goqu.S("search").Table("resources")
+	ds := dialect.From(schemaTable)
+
+	// WHERE CLAUSE
+	if s.input != nil && (len(s.input.Filters) > 0 || len(s.input.Keywords) > 0) {
+		if len(s.input.Keywords) > 0 {
+			jsb := goqu.L("jsonb_each_text(?)", goqu.C("data"))
+			ds = dialect.From(schemaTable, jsb)
+		}
+		whereDs, s.propTypes, err = WhereClauseFilter(ctx, s.input, s.propTypes)
+		if err != nil {
+			return err
+		}
+	}
+	whereDs = append(whereDs, goqu.C("cluster").In(s.clusters))
+
+	// get user info for logging
+	_, userInfo := rbac.GetCache().GetUserUID(ctx)
+
+	// RBAC CLAUSE
+	// if one of them is not nil, userData is not empty
+	if s.userData.CsResources != nil || s.userData.NsResources != nil || s.userData.ManagedClusters != nil {
+		whereDs = append(whereDs, rbacWhereClause(ctx, s.userData, userInfo)) // add rbac
+	} else {
+		s.query = ""
+		s.params = nil
+		return fmt.Errorf("RBAC clause is required! None found for compareClusters query %+v for user %s with uid %s",
+			s.input, userInfo.Username, userInfo.UID)
+	}
+
+	selectDs := ds.SelectDistinct("uid", "cluster", "data").
+		Where(whereDs...).
+		Order(goqu.C("uid").Asc())
+
+	// LIMIT CLAUSE
+	// Fetch one more resource than the limit to know if the limit was exceeded.
+	if s.limit != 0 {
+		selectDs = selectDs.Limit(s.limit*uint(len(s.clusters)) + 1)
+	}
+
+	sql, params, err := selectDs.Prepared(true).ToSQL()
+	if err != nil {
+		klog.Errorf("Error building CompareClusters query: %s", err.Error())
+		return err
+	}
+	s.query = sql
+	s.params = params
+	klog.V(5).Info("CompareClusters Query: ", s.query)
+	return nil
+}
+
+// Execute the query and compare the resources on each cluster with the baseline.
+func (s *CompareClustersResult) compareClustersResults(ctx context.Context) ([]*model.ClusterComparison, error) {
+	klog.V(2).Info("Resolving compareClustersResults()")
+	rows, err := s.pool.Query(ctx, s.query, s.params...)
+	if err != nil {
+		klog.Error("Error fetching compareClusters results from db ", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Resources on each cluster indexed by key.
+	resourcesByCluster := make(map[string]map[string]map[string]interface{}, len(s.clusters))
+	for _, cluster := range s.clusters {
+		resourcesByCluster[cluster] = map[string]map[string]interface{}{}
+	}
+	total := uint(0)
+	totalByCluster := map[string]uint{}
+	for rows.Next() {
+		var uid, cluster string
+		var data map[string]interface{}
+		if scanErr := rows.Scan(&uid, &cluster, &data); scanErr != nil {
+			klog.Error("Error reading compareClustersResults ", scanErr)
+			continue
+		}
+		total++
+		totalByCluster[cluster]++
+		item := formatDataMap(data)
+		item["_uid"] = uid
+		item["cluster"] = cluster
+
+		key := s.resourceKey(item)
+		if _, found := resourcesByCluster[cluster][key]; found {
+			klog.V(3).Infof("Found more than one resource with key %s on cluster %s. Using the first match.", key, cluster)
+			continue
+		}
+		resourcesByCluster[cluster][key] = item
+	}
+	// When the query returns all the resources, the limit is checked on each cluster.
+	if s.limit != 0 {
+		for _, cluster := range s.clusters {
+			if total > s.limit*uint(len(s.clusters)) || totalByCluster[cluster] > s.limit {
+				return nil, fmt.Errorf("the comparison exceeds the limit of %d resources per cluster. "+
+					"Use the input filters to narrow the resources compared", s.limit)
+			}
+		}
+	}
+
+	baseline := s.clusters[0]
+	result := make([]*model.ClusterComparison, 0, len(s.clusters)-1)
+	for _, cluster := range s.clusters[1:] {
+		result = append(result, s.compareCluster(baseline, resourcesByCluster[baseline],
+			cluster, resourcesByCluster[cluster]))
+	}
+	return result, nil
+}
+
+func (s *CompareClustersResult) compareCluster(baseline string, baselineResources map[string]map[string]interface{},
+	cluster string, clusterResources map[string]map[string]interface{}) *model.ClusterComparison {
+	comparison := &model.ClusterComparison{
+		Baseline:  baseline,
+		Cluster:   cluster,
+		Missing:   []map[string]interface{}{},
+		Extra:     []map[string]interface{}{},
+		Different: []*model.ResourceDifference{},
+	}
+	for _, key := range sortedKeys(baselineResources) {
+		baselineItem := baselineResources[key]
+		item, found := clusterResources[key]
+		if !found {
+			comparison.Missing = append(comparison.Missing, baselineItem)
+			continue
+		}
+		if properties := s.differentProperties(baselineItem, item); len(properties) > 0 {
+			comparison.Different = append(comparison.Different, &model.ResourceDifference{
+				Key:          s.keyValues(baselineItem),
+				Properties:   properties,
+				BaselineItem: baselineItem,
+				Item:         item,
+			})
+		}
+	}
+	for _, key := range sortedKeys(clusterResources) {
+		if _, found := baselineResources[key]; !found {
+			comparison.Extra = append(comparison.Extra, clusterResources[key])
+		}
+	}
+	return comparison
+}
+
+// Returns the sorted list of properties with different values in both resources.
+func (s *CompareClustersResult) differentProperties(baselineItem, item map[string]interface{}) []string {
+	properties := s.compareProperties
+	if len(properties) == 0 {
+		properties = s.defaultCompareProperties(baselineItem, item)
+	}
+	different := []string{}
+	for _, property := range properties {
+		if !reflect.DeepEqual(baselineItem[property], item[property]) {
+			different = append(different, property)
+		}
+	}
+	sort.Strings(different)
+	return different
+}
+
+// All properties in either resource, except the keys and the properties expected to differ between clusters.
+func (s *CompareClustersResult) defaultCompareProperties(baselineItem, item map[string]interface{}) []string {
+	ignored := map[string]struct{}{"cluster": {}, "created": {}}
+	for _, key := range s.keyProperties {
+		ignored[key] = struct{}{}
+	}
+	properties := []string{}
+	for _, resource := range []map[string]interface{}{baselineItem, item} {
+		for property := range resource {
+			if _, found := ignored[property]; found || strings.HasPrefix(property, "_") {
+				continue
+			}
+			ignored[property] = struct{}{} // Avoid duplicates.
+			properties = append(properties, property)
+		}
+	}
+	return properties
+}
+
+func (s *CompareClustersResult) keyValues(item map[string]interface{}) map[string]interface{} {
+	key := make(map[string]interface{}, len(s.keyProperties))
+	for _, property := range s.keyProperties {
+		key[property] = item[property]
+	}
+	return key
+}
+
+// Builds a string to match the resource across clusters. Missing properties match as empty values,
+// for example, namespace on cluster-scoped resources.
+func (s *CompareClustersResult) resourceKey(item map[string]interface{}) string {
+	values := make([]string, len(s.keyProperties))
+	for i, property := range s.keyProperties {
+		if value, found := item[property]; found {
+			values[i] = fmt.Sprintf("%v", value)
+		}
+	}
+	return fmt.Sprintf("%q", values)
+}
+
+// Like PointerToStringArray, but ignores nil and empty values.
+func nonEmptyValues(pointerArray []*string) []string {
+	values := make([]string, 0, len(pointerArray))
+	for _, val := range pointerArray {
+		if val != nil && *val != "" {
+			values = append(values, *val)
+		}
+	}
+	return values
+}
+
+func sortedKeys(resources map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(resources))
+	for key := range resources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
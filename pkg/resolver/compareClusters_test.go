@@ -0,0 +1,142 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockCompareClusters(t *testing.T, input *model.SearchInput, clusters []string,
+	ud rbac.UserData) (*CompareClustersResult, *pgxpoolmock.MockPgxPool) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	mockResolver := &CompareClustersResult{
+		clusters:      clusters,
+		input:         input,
+		keyProperties: defaultCompareKeyProperties,
+		limit:         2,
+		pool:          mockPool,
+		propTypes:     map[string]string{"kind": "string", "namespace": "string"},
+		userData:      ud,
+	}
+	return mockResolver, mockPool
+}
+
+func mockCompareRow(uid, cluster, name, image string) map[string]interface{} {
+	return map[string]interface{}{
+		"uid":     uid,
+		"cluster": cluster,
+		"data": map[string]interface{}{
+			"kind":      "Deployment",
+			"namespace": "app",
+			"name":      name,
+			"image":     image,
+			"created":   "2026-10-" + uid[len(uid)-2:] + "T00:00:00Z",
+		},
+	}
+}
+
+func Test_CompareClusters_Query(t *testing.T) {
+	val1 := "app"
+	searchInput := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "namespace", Values: []*string{&val1}}}}
+	resolver, mockPool := newMockCompareClusters(t, searchInput, []string{"cluster-a", "cluster-b"},
+		rbac.UserData{CsResources: []rbac.Resource{}})
+
+	mockRows := &MockRows{
+		mockData: []map[string]interface{}{
+			mockCompareRow("cluster-a/01", "cluster-a", "api", "api:1.0"),
+			mockCompareRow("cluster-a/02", "cluster-a", "web", "web:1.0"),
+			mockCompareRow("cluster-b/03", "cluster-b", "api", "api:1.1"),
+			mockCompareRow("cluster-b/04", "cluster-b", "db", "db:1.0"),
+		},
+		columnHeaders: []string{"uid", "cluster", "data"},
+	}
+	mockPool.EXPECT().Query(gomock.Any(),
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->$1?($2) AND ("cluster" IN ($3, $4)) AND (("cluster" = ANY ($5)) OR FALSE)) ORDER BY "uid" ASC LIMIT $6`),
+		gomock.Eq([]interface{}{"namespace", "app", "cluster-a", "cluster-b", "{}", int64(5)}),
+	).Return(mockRows, nil)
+
+	ctx := context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456")
+	err := resolver.buildCompareClustersQuery(ctx)
+	assert.Nil(t, err)
+	result, err := resolver.compareClustersResults(ctx)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, len(result))
+	assert.Equal(t, "cluster-a", result[0].Baseline)
+	assert.Equal(t, "cluster-b", result[0].Cluster)
+	assert.Equal(t, 1, len(result[0].Missing))
+	assert.Equal(t, "web", result[0].Missing[0]["name"])
+	assert.Equal(t, 1, len(result[0].Extra))
+	assert.Equal(t, "db", result[0].Extra[0]["name"])
+	// The created timestamp is ignored by default.
+	assert.Equal(t, 1, len(result[0].Different))
+	assert.Equal(t, []string{"image"}, result[0].Different[0].Properties)
+	assert.Equal(t, map[string]interface{}{"kind": "Deployment", "namespace": "app", "name": "api"},
+		result[0].Different[0].Key)
+	assert.Equal(t, "api:1.0", result[0].Different[0].BaselineItem["image"])
+	assert.Equal(t, "api:1.1", result[0].Different[0].Item["image"])
+}
+
+func Test_CompareClusters_ExceedsLimit(t *testing.T) {
+	resolver, mockPool := newMockCompareClusters(t, nil, []string{"cluster-a", "cluster-b"},
+		rbac.UserData{CsResources: []rbac.Resource{}})
+
+	// The total is within the query limit, but cluster-a exceeds the limit of 2 resources.
+	mockRows := &MockRows{
+		mockData: []map[string]interface{}{
+			mockCompareRow("cluster-a/01", "cluster-a", "api", "api:1.0"),
+			mockCompareRow("cluster-a/02", "cluster-a", "web", "web:1.0"),
+			mockCompareRow("cluster-a/03", "cluster-a", "db", "db:1.0"),
+		},
+		columnHeaders: []string{"uid", "cluster", "data"},
+	}
+	mockPool.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRows, nil)
+
+	ctx := context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456")
+	err := resolver.buildCompareClustersQuery(ctx)
+	assert.Nil(t, err)
+	_, err = resolver.compareClustersResults(ctx)
+	assert.EqualError(t, err, "the comparison exceeds the limit of 2 resources per cluster. "+
+		"Use the input filters to narrow the resources compared")
+}
+
+func Test_CompareClusters_CompareProperties(t *testing.T) {
+	resolver, _ := newMockCompareClusters(t, nil, []string{"cluster-a", "cluster-b"}, rbac.UserData{})
+	resolver.compareProperties = []string{"replicas"}
+
+	baseline := map[string]interface{}{"image": "api:1.0", "replicas": "3"}
+	item := map[string]interface{}{"image": "api:1.1", "replicas": "3"}
+	assert.Equal(t, []string{}, resolver.differentProperties(baseline, item))
+
+	item["replicas"] = "1"
+	assert.Equal(t, []string{"replicas"}, resolver.differentProperties(baseline, item))
+}
+
+func Test_CompareClusters_NoRbac(t *testing.T) {
+	resolver, _ := newMockCompareClusters(t, nil, []string{"cluster-a", "cluster-b"}, rbac.UserData{})
+
+	err := resolver.buildCompareClustersQuery(context.Background())
+	assert.NotNil(t, err)
+	assert.Equal(t, "", resolver.query)
+}
+
+func Test_validateCompareClusters(t *testing.T) {
+	clusters, err := validateCompareClusters([]string{"cluster-b", "cluster-a", "cluster-b"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"cluster-b", "cluster-a"}, clusters)
+
+	_, err = validateCompareClusters([]string{"cluster-a", "cluster-a"})
+	assert.EqualError(t, err, "invalid input. At least 2 clusters are required to compare")
+
+	_, err = validateCompareClusters([]string{"cluster-a", ""})
+	assert.EqualError(t, err, "invalid input. The cluster name must not be empty")
+}
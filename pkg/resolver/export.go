@@ -0,0 +1,234 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	db "github.com/stolostron/search-v2-api/pkg/database"
+	"github.com/stolostron/search-v2-api/pkg/metrics"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	klog "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const exportFlushRows = 100 // Flush the response after this number of rows.
+
+var defaultExportColumns = []string{"cluster", "kind", "namespace", "name", "created"}
+
+// Body of the export request.
+//
+//	{"input": {"filters": [{"property": "kind", "values": ["Pod"]}], "limit": -1}, "format": "csv",
+//	 "columns": ["cluster", "namespace", "name", "status"]}
+type ExportRequest struct {
+	Input   *model.SearchInput `json:"input"`
+	Format  string             `json:"format"`  // ndjson (default), csv or yaml
+	Columns []string           `json:"columns"` // Columns for the csv format.
+}
+
+type SearchExport struct {
+	columns []string
+	format  string
+	search  *SearchResult
+}
+
+// Writes the items in one of the export formats.
+type exportWriter interface {
+	write(item map[string]interface{}) error
+	flush() error
+}
+
+// ExportHandler streams the results of a search to the response, without loading all the items in memory.
+// Uses the same query and RBAC clause as the items of the search query.
+func ExportHandler(w http.ResponseWriter, r *http.Request) {
+	defer metrics.SlowLog("ExportHandler", 0)()
+	ctx := r.Context()
+
+	exportRequest, err := parseExportRequest(r.Body)
+	if err != nil {
+		klog.Warningf("Invalid export request. %s", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	userData, userDataErr := rbac.GetCache().GetUserData(ctx)
+	if userDataErr != nil {
+		klog.Errorf("Error getting user data for export. %s", userDataErr)
+		http.Error(w, userDataErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// check that shared cache has resource datatypes
+	propTypes, err := getPropertyType(ctx, false)
+	if err != nil {
+		klog.Warningf("Error creating datatype map. Error: [%s] ", err)
+	}
+
+	export := &SearchExport{
+		columns: exportRequest.Columns,
+		format:  exportRequest.Format,
+		search: &SearchResult{
+			context:   ctx,
+			input:     exportRequest.Input,
+			pool:      db.GetConnPool(ctx),
+			propTypes: propTypes,
+			userData:  userData,
+		},
+	}
+	export.writeResults(ctx, w)
+}
+
+// Parse and validate the request body.
+func parseExportRequest(body io.Reader) (*ExportRequest, error) {
+	exportRequest := &ExportRequest{}
+	if err := json.NewDecoder(body).Decode(exportRequest); err != nil {
+		return nil, fmt.Errorf("error decoding export request: %s", err)
+	}
+	if exportRequest.Input == nil {
+		return nil, fmt.Errorf("invalid export request. The input is required")
+	}
+	switch exportRequest.Format {
+	case "":
+		exportRequest.Format = "ndjson"
+	case "ndjson", "csv", "yaml":
+	default:
+		return nil, fmt.Errorf("invalid export format: %s. Supported formats are ndjson, csv and yaml",
+			exportRequest.Format)
+	}
+	if len(exportRequest.Columns) == 0 {
+		exportRequest.Columns = defaultExportColumns
+	}
+	return exportRequest, nil
+}
+
+// Execute the items query and write each row to the response as it's read from the database.
+// Stops when the client disconnects.
+func (e *SearchExport) writeResults(ctx context.Context, w http.ResponseWriter) {
+	if err := e.search.buildSearchQuery(ctx, false, false); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rows, err := e.search.pool.Query(ctx, e.search.query, e.search.params...)
+	if err != nil {
+		klog.Errorf("Error resolving export query [%s] with args [%+v]. Error: [%+v]",
+			e.search.query, e.search.params, err)
+		http.Error(w, "Error resolving the export query.", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	writer, err := e.newExportWriter(w)
+	if err != nil {
+		klog.Errorf("Error writing export. %s", err)
+		return
+	}
+	rc := http.NewResponseController(w)
+	count := 0
+	for rows.Next() {
+		if ctx.Err() != nil {
+			klog.V(2).Infof("Client disconnected. Stopping export after %d items.", count)
+			return
+		}
+		_, item := e.search.scanItem(rows)
+		if err := writer.write(item); err != nil {
+			klog.Warningf("Error writing export after %d items. %s", count, err)
+			return
+		}
+		count++
+		if count%exportFlushRows == 0 {
+			if err := writer.flush(); err != nil {
+				klog.Warningf("Error writing export after %d items. %s", count, err)
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				klog.V(5).Infof("Unable to flush export response. %s", err)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		klog.Errorf("Error reading export rows after %d items. %s", count, err)
+	}
+	if err := writer.flush(); err != nil {
+		klog.Warningf("Error writing export after %d items. %s", count, err)
+	}
+	klog.V(3).Infof("Exported %d items in %s format.", count, e.format)
+}
+
+// Sets the response headers and returns the writer for the format.
+func (e *SearchExport) newExportWriter(w http.ResponseWriter) (exportWriter, error) {
+	switch e.format {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="search-export.csv"`)
+		writer := &csvExportWriter{columns: e.columns, writer: csv.NewWriter(w)}
+		return writer, writer.writer.Write(e.columns) // Header
+	case "yaml":
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Header().Set("Content-Disposition", `attachment; filename="search-export.yaml"`)
+		return &yamlExportWriter{writer: w}, nil
+	default:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", `attachment; filename="search-export.ndjson"`)
+		return &ndjsonExportWriter{encoder: json.NewEncoder(w)}, nil
+	}
+}
+
+// One JSON object per line.
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonExportWriter) write(item map[string]interface{}) error {
+	return n.encoder.Encode(item)
+}
+
+func (n *ndjsonExportWriter) flush() error {
+	return nil
+}
+
+// One row per item with the selected columns. Missing properties are empty.
+type csvExportWriter struct {
+	columns []string
+	writer  *csv.Writer
+}
+
+func (c *csvExportWriter) write(item map[string]interface{}) error {
+	record := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		if value, found := item[column]; found {
+			record[i] = fmt.Sprintf("%v", value)
+		}
+	}
+	return c.writer.Write(record)
+}
+
+func (c *csvExportWriter) flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// One YAML document per item.
+type yamlExportWriter struct {
+	writer io.Writer
+}
+
+func (y *yamlExportWriter) write(item map[string]interface{}) error {
+	out, err := yaml.Marshal(item)
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(y.writer, "---\n"); err != nil {
+		return err
+	}
+	_, err = y.writer.Write(out)
+	return err
+}
+
+func (y *yamlExportWriter) flush() error {
+	return nil
+}
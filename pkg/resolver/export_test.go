@@ -0,0 +1,97 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockSearchExport(t *testing.T, format string, columns []string) (*SearchExport, *MockRows) {
+	val1 := "Template"
+	limit := -1
+	searchInput := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&val1}}},
+		Limit: &limit}
+	resolver, mockPool := newMockSearchResolver(t, searchInput, nil, rbac.UserData{CsResources: []rbac.Resource{}},
+		map[string]string{"kind": "string"})
+
+	mockRows := newMockRows("./mocks/mock.json")
+	mockRows.mockData = mockRows.mockData[:1]
+	mockPool.EXPECT().Query(gomock.Any(),
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR FALSE))`),
+		gomock.Eq([]interface{}{"kind", "Template", "{}"}),
+	).Return(mockRows, nil)
+
+	return &SearchExport{columns: columns, format: format, search: resolver}, mockRows
+}
+
+func Test_Export_NDJSON(t *testing.T) {
+	export, _ := newMockSearchExport(t, "ndjson", defaultExportColumns)
+	w := httptest.NewRecorder()
+
+	export.writeResults(export.search.context, w)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Equal(t, 1, len(lines))
+	assert.Contains(t, lines[0], `"_uid":"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd"`)
+	assert.Contains(t, lines[0], `"name":"eap-cd-starter-s2i"`)
+}
+
+func Test_Export_CSV(t *testing.T) {
+	export, _ := newMockSearchExport(t, "csv", []string{"kind", "namespace", "name", "status"})
+	w := httptest.NewRecorder()
+
+	export.writeResults(export.search.context, w)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(t, "kind,namespace,name,status\nTemplate,openshift,eap-cd-starter-s2i,\n", w.Body.String())
+}
+
+func Test_Export_YAML(t *testing.T) {
+	export, _ := newMockSearchExport(t, "yaml", defaultExportColumns)
+	w := httptest.NewRecorder()
+
+	export.writeResults(export.search.context, w)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(w.Body.String(), "---\n"))
+	assert.Contains(t, w.Body.String(), "name: eap-cd-starter-s2i\n")
+}
+
+func Test_Export_ClientDisconnected(t *testing.T) {
+	export, _ := newMockSearchExport(t, "ndjson", defaultExportColumns)
+	w := httptest.NewRecorder()
+	ctx, cancel := context.WithCancel(export.search.context)
+	cancel()
+
+	export.writeResults(ctx, w)
+
+	assert.Equal(t, "", w.Body.String(), "Rows should not be written after the client disconnects.")
+}
+
+func Test_parseExportRequest(t *testing.T) {
+	exportRequest, err := parseExportRequest(strings.NewReader(`{"input": {"keywords": ["nginx"]}}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "ndjson", exportRequest.Format)
+	assert.Equal(t, defaultExportColumns, exportRequest.Columns)
+
+	_, err = parseExportRequest(strings.NewReader(`{"input": {"keywords": ["nginx"]}, "format": "xml"}`))
+	assert.EqualError(t, err, "invalid export format: xml. Supported formats are ndjson, csv and yaml")
+
+	_, err = parseExportRequest(strings.NewReader(`{"format": "csv"}`))
+	assert.EqualError(t, err, "invalid export request. The input is required")
+
+	_, err = parseExportRequest(strings.NewReader(`not json`))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,519 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"k8s.io/klog/v2"
+)
+
+// The keywords and filters of a search input, parsed once. The filter is compiled to SQL for the queries, see
+// sql(), and evaluated in memory against the data of a resource for the subscriptions, see matches(). The
+// subscriptions parse the filters once per type of the property in the events, see eventFilter. The tests in
+// filter_test.go check both with the same cases.
+type searchFilter struct {
+	keywords   []string           // Matched case-insensitive against the value of any property.
+	conditions []*filterCondition // All must match.
+}
+
+// A filter on a property. Matches if any of the clauses matches.
+type filterCondition struct {
+	property string
+	dataType string // Type of the property, from the property types. Empty if unknown, nothing matches.
+	clauses  []*filterClause
+}
+
+// An operator from matchOperatorToProperty() with its values. For example "=" with ["Pod"], "!:*" with
+// ["kube-%"], "=:*@>" with ["app:search%"] or "@>" with [`{"app":"search"}`].
+type filterClause struct {
+	operator string
+	values   []string
+}
+
+// Dates like 2024-01-31T10:00:00Z are stored as strings, but compared as timestamps by the filters.
+var timestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}`)
+
+// Parses the keywords and filters of the input. The values of a filter are parsed according to the type of
+// the property, returned by dataTypeOf. Parsing stops at the first property of unknown type, because no
+// resource can match.
+func newSearchFilter(input *model.SearchInput,
+	dataTypeOf func(property string) (string, bool, error)) (*searchFilter, error) {
+	filter := &searchFilter{}
+	if input == nil {
+		return filter, nil
+	}
+	for _, keyword := range input.Keywords {
+		if keyword != nil {
+			filter.keywords = append(filter.keywords, *keyword)
+		}
+	}
+	for _, inputFilter := range input.Filters {
+		values := inputFilterValues(inputFilter)
+		if values == nil {
+			continue
+		}
+		dataType, known, err := dataTypeOf(inputFilter.Property)
+		if err != nil {
+			return filter, err
+		}
+		if !known {
+			filter.conditions = append(filter.conditions, &filterCondition{property: inputFilter.Property})
+			return filter, nil
+		}
+		klog.V(5).Infof("For filter prop: %s, datatype is :%s\n", inputFilter.Property, dataType)
+
+		condition, err := newFilterCondition(inputFilter.Property, values, dataType)
+		if err != nil {
+			return filter, err
+		}
+		filter.conditions = append(filter.conditions, condition)
+	}
+	return filter, nil
+}
+
+// Returns the values of the filter, or nil if the filter must be ignored.
+func inputFilterValues(inputFilter *model.SearchFilter) []string {
+	if inputFilter == nil || inputFilter.Property == "" {
+		return nil
+	}
+	values := []string{}
+	for _, value := range inputFilter.Values {
+		if value != nil {
+			values = append(values, *value)
+		}
+	}
+	if len(values) == 0 {
+		klog.Warningf("Ignoring filter [%s] because it has no values", inputFilter.Property)
+		return nil
+	}
+	return values
+}
+
+// Parses the values of a filter on a property of the given type. The values are modified.
+func newFilterCondition(property string, values []string, dataType string) (*filterCondition, error) {
+	values, err := decodePropertyTypes(values, dataType)
+	if err != nil {
+		return nil, err
+	}
+	opValueMap := matchOperatorToProperty(dataType, map[string][]string{}, values, property)
+	condition := &filterCondition{property: property, dataType: dataType}
+	// Sorted by operator, for the ease/stability of tests when there are multiple operators.
+	for _, operator := range getKeys(opValueMap) {
+		condition.clauses = append(condition.clauses, &filterClause{operator: operator, values: opValueMap[operator]})
+	}
+	return condition, nil
+}
+
+// Returns the expressions of the WHERE clause, joined with AND.
+func (f *searchFilter) sql() []exp.Expression {
+	var whereDs []exp.Expression
+	// Sample query: SELECT COUNT("uid") FROM "search"."resources", jsonb_each_text("data")
+	// WHERE (("value" LIKE '%dns%') AND ("data"->>'kind' ILIKE ANY ('{"pod","deployment"}')))
+	for _, keyword := range f.keywords {
+		whereDs = append(whereDs, goqu.L(`"value"`).ILike("%"+keyword+"%").Expression())
+	}
+	for _, condition := range f.conditions {
+		if condition.dataType == "" {
+			// search=> explain analyze select * from search.resources where 1 = 0;
+			//                                     QUERY PLAN
+			//------------------------------------------------------------------------------------
+			// Result  (cost=0.00..0.00 rows=0 width=0) (actual time=0.001..0.001 rows=0 loops=1)
+			//   One-Time Filter: false
+			// Planning Time: 0.060 ms
+			// Execution Time: 0.008 ms
+			// (4 rows)
+			whereDs = append(whereDs, goqu.L("1 = 0").Expression())
+			continue
+		}
+		var operatorWhereDs []exp.Expression // All the clauses of this filter, joined with OR.
+		for _, clause := range condition.clauses {
+			operatorWhereDs = append(operatorWhereDs, getWhereClauseExpression(condition.property,
+				clause.operator, clause.values, condition.dataType)...)
+		}
+		whereDs = append(whereDs, goqu.Or(operatorWhereDs...))
+	}
+	return whereDs
+}
+
+// Returns true if the data of the resource matches the filter, the same as the SQL of sql().
+func (f *searchFilter) matches(data map[string]any) bool {
+	if len(f.keywords) > 0 && !keywordsMatch(data, f.keywords) {
+		return false
+	}
+	for _, condition := range f.conditions {
+		if !condition.matches(data) {
+			return false
+		}
+	}
+	return true
+}
+
+// Same as `"value" ILIKE '%keyword%'` for each keyword over the rows of jsonb_each_text("data"): all the
+// keywords match the same value.
+func keywordsMatch(data map[string]any, keywords []string) bool {
+	for _, value := range data {
+		text, matched := jsonbText(value)
+		for _, keyword := range keywords {
+			matched = matched && likeMatches(strings.ToLower(text), strings.ToLower("%"+keyword+"%"))
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *filterCondition) matches(data map[string]any) bool {
+	if c.dataType == "" {
+		return false
+	}
+	for _, clause := range c.clauses {
+		if c.property == "managedHub" {
+			// Not a property of the resources, the filter selects the hubs. See matchesManagedHubFilter().
+			if processOpValueMapManagedHub(clause.operator, clause.values) {
+				return true
+			}
+		} else if c.clauseMatches(clause, data) {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluates the clause like the SQL of getWhereClauseExpression(). A missing property is NULL, so it doesn't
+// match any operator, except NOT EXISTS.
+func (c *filterCondition) clauseMatches(clause *filterClause, data map[string]any) bool {
+	value, exists := data[c.property]
+	text, isText := jsonbText(value)
+	numeric := c.dataType == "number" && c.property != "cluster" // Compared as ("data"->prop)::numeric
+
+	switch clause.operator {
+	case "*", "=:*":
+		return isText && anyValue(clause.values, func(pattern string) bool { return likeMatches(text, pattern) })
+	case "!:*", "!=:*":
+		return isText && anyValue(clause.values, func(pattern string) bool { return !likeMatches(text, pattern) })
+	case "<=", ">=", "<", ">":
+		return anyValue(clause.values, func(val string) bool {
+			comparison, ok := compareJsonb(value, text, isText, val, numeric)
+			return ok && comparisonMatches(clause.operator, comparison)
+		})
+	case "!=", "!":
+		return isText && !anyValue(clause.values, func(val string) bool {
+			comparison, ok := compareJsonb(value, text, isText, val, numeric)
+			return !ok || comparison == 0
+		})
+	case "!:*@>", "!=:*@>":
+		return !objectHasMatch(value, clause.values)
+	case ":*@>", "=:*@>":
+		return objectHasMatch(value, clause.values)
+	case "!:*[]", "!=:*[]":
+		return !arrayHasMatch(value, clause.values)
+	case ":*[]", "=:*[]":
+		return arrayHasMatch(value, clause.values)
+	case "@>", "=:@>":
+		return exists && anyValue(clause.values, func(val string) bool { return jsonbContainsText(value, val) })
+	case "!:@>", "!=:@>":
+		return exists && anyValue(clause.values, func(val string) bool { return !jsonbContainsText(value, val) })
+	case "?|":
+		return jsonbHasAnyKey(value, clause.values)
+	default:
+		if c.property == "kind" && isLower(clause.values) {
+			return isText && anyValue(clause.values, func(val string) bool {
+				return likeMatches(strings.ToLower(text), strings.ToLower(val))
+			})
+		} else if isString(clause.values) && c.property != "cluster" && c.dataType != "number" &&
+			c.dataType != "boolean" {
+			return jsonbHasAnyKey(value, clause.values)
+		}
+		return anyValue(clause.values, func(val string) bool {
+			comparison, ok := compareJsonb(value, text, isText, val, numeric)
+			return ok && comparison == 0
+		})
+	}
+}
+
+func anyValue(values []string, match func(string) bool) bool {
+	for _, value := range values {
+		if match(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func comparisonMatches(operator string, comparison int) bool {
+	switch operator {
+	case "<=":
+		return comparison <= 0
+	case ">=":
+		return comparison >= 0
+	case "<":
+		return comparison < 0
+	case ">":
+		return comparison > 0
+	}
+	return false
+}
+
+// Compares the value of the property with the value of the filter, as numbers for the properties of type
+// number or as text for the others. Returns false if the value can't be compared, like NULL in SQL.
+func compareJsonb(value any, text string, isText bool, filterValue string, numeric bool) (int, bool) {
+	if !numeric {
+		return strings.Compare(text, filterValue), isText
+	}
+	number, ok := jsonbNumber(value)
+	filterNumber, err := strconv.ParseFloat(filterValue, 64)
+	if !ok || err != nil {
+		return 0, false
+	}
+	switch {
+	case number < filterNumber:
+		return -1, true
+	case number > filterNumber:
+		return 1, true
+	}
+	return 0, true
+}
+
+// Same as ("data"->prop)::numeric, only numbers can be converted.
+func jsonbNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// Same as "data"->>prop. Returns false for NULL, when the property is missing or null.
+func jsonbText(value any) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	default:
+		return jsonbString(v), true
+	}
+}
+
+// Returns the value in the output format of jsonb: the keys of objects are sorted by length then bytes, with a
+// space after the separators.
+func jsonbString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		quoted, _ := json.Marshal(v)
+		return string(quoted)
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int, int64:
+		return fmt.Sprintf("%d", v)
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if len(keys[i]) != len(keys[j]) {
+				return len(keys[i]) < len(keys[j])
+			}
+			return keys[i] < keys[j]
+		})
+		items := make([]string, len(keys))
+		for i, key := range keys {
+			items[i] = jsonbString(key) + ": " + jsonbString(v[key])
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = jsonbString(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// Same as EXISTS(SELECT 1 FROM jsonb_each_text("data"->prop) AS kv(key, value) WHERE ...), see
+// createSubQueryForArray(). The values are key:value patterns, or a pattern matching the key or the value.
+func objectHasMatch(value any, values []string) bool {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return false
+	}
+	for key, item := range object {
+		text, isText := jsonbText(item)
+		for _, val := range values {
+			keyValue := strings.Split(val, ":")
+			if len(keyValue) == 2 {
+				if likeMatches(key, keyValue[0]) && isText && likeMatches(text, keyValue[1]) {
+					return true
+				}
+			} else if likeMatches(key, val) || (isText && likeMatches(text, val)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Same as EXISTS(SELECT 1 FROM jsonb_array_elements_text("data"->prop) AS arrayProp WHERE ...), see
+// createSubQueryForArray().
+func arrayHasMatch(value any, values []string) bool {
+	array, ok := value.([]any)
+	if !ok {
+		return false
+	}
+	for _, item := range array {
+		if text, isText := jsonbText(item); isText && anyValue(values, func(pattern string) bool {
+			return likeMatches(text, pattern)
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// Same as "data"->prop ?| values: the value is one of the strings, or an array or object containing one of
+// them as element or key.
+func jsonbHasAnyKey(value any, keys []string) bool {
+	switch v := value.(type) {
+	case string:
+		return anyValue(keys, func(key string) bool { return v == key })
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && anyValue(keys, func(key string) bool { return s == key }) {
+				return true
+			}
+		}
+	case map[string]any:
+		return anyValue(keys, func(key string) bool {
+			_, ok := v[key]
+			return ok
+		})
+	}
+	return false
+}
+
+// Same as "data"->prop @> contained, where contained is JSON text.
+func jsonbContainsText(value any, contained string) bool {
+	var decoded any
+	if err := json.Unmarshal([]byte(contained), &decoded); err != nil {
+		klog.V(4).Infof("Can't decode the filter value %s as JSON. %v", contained, err)
+		return false
+	}
+	return jsonbContains(value, decoded, true)
+}
+
+// Same as the jsonb containment operator @>. At the top level, an array contains a scalar that is one of its
+// elements.
+func jsonbContains(value, contained any, topLevel bool) bool {
+	switch c := contained.(type) {
+	case map[string]any:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, item := range c {
+			if objectItem, ok := object[key]; !ok || !jsonbContains(objectItem, item, false) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		array, ok := value.([]any)
+		if !ok {
+			return false
+		}
+		for _, item := range c {
+			found := false
+			for _, arrayItem := range array {
+				if jsonbContains(arrayItem, item, false) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	default:
+		if array, ok := value.([]any); ok && topLevel {
+			return jsonbContains(array, []any{contained}, false)
+		}
+		if number, ok := jsonbNumber(value); ok {
+			containedNumber, ok := jsonbNumber(contained)
+			return ok && number == containedNumber
+		}
+		return value == contained
+	}
+}
+
+// Same as the SQL LIKE operator: % matches any sequence of characters, _ matches one character, and \ escapes
+// the next character.
+func likeMatches(value, pattern string) bool {
+	if pattern == "" {
+		return value == ""
+	}
+	r, size := utf8.DecodeRuneInString(pattern)
+	switch r {
+	case '%':
+		rest := strings.TrimLeft(pattern, "%")
+		for i := 0; i <= len(value); i++ {
+			if (i == len(value) || utf8.RuneStart(value[i])) && likeMatches(value[i:], rest) {
+				return true
+			}
+		}
+		return false
+	case '_':
+		if value == "" {
+			return false
+		}
+		_, valueSize := utf8.DecodeRuneInString(value)
+		return likeMatches(value[valueSize:], pattern[size:])
+	case '\\':
+		if len(pattern) > size {
+			r, size = utf8.DecodeRuneInString(pattern[size:])
+			size++
+		}
+	}
+	valueRune, valueSize := utf8.DecodeRuneInString(value)
+	return value != "" && valueRune == r && likeMatches(value[valueSize:], pattern[size:])
+}
+
+// Returns the type of a value, the same as the property types from the database. See getPropertyTypes().
+func jsonbDataType(value any) string {
+	switch v := value.(type) {
+	case string:
+		if timestampPattern.MatchString(v) {
+			return "timestamp"
+		}
+		return "string"
+	case float64, int, int64:
+		return "number"
+	case bool:
+		return "boolean"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	}
+	return "null"
+}
@@ -0,0 +1,195 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// The resource filtered by filterTests, and the property types of the database.
+func newFilterTestData() map[string]any {
+	return map[string]any{"kind": "Pod", "name": "search-api-1", "cluster": "local-cluster", "version": "10",
+		"restarts": float64(3), "ready": true, "created": time.Now().Add(-2 * time.Hour).Format(time.RFC3339),
+		"label": map[string]any{"app": "search", "tier": "api"}, "container": []any{"search-api", "proxy"}}
+}
+
+var filterTestTypes = map[string]string{"kind": "string", "name": "string", "cluster": "string",
+	"version": "string", "restarts": "number", "ready": "boolean", "created": "timestamp", "label": "object",
+	"annotation": "object", "container": "array"}
+
+// Shared by the SQL and the in-memory evaluation of the filters. The match is what PostgreSQL returns for the
+// SQL with the resource of newFilterTestData(), so it's what the subscriptions must return too.
+var filterTests = []struct {
+	property string
+	values   []string
+	sql      string // The WHERE clause. Empty for relative dates, the SQL changes with the time.
+	match    bool
+}{
+	// Strings
+	{"name", []string{"search-api-1"}, `"data"->'name'?('search-api-1')`, true},
+	{"name", []string{"other", "search-api-1"}, `"data"->'name'?|'{"other","search-api-1"}'`, true},
+	{"name", []string{"!search-api-1"}, `("data"->>'name' NOT IN ('search-api-1'))`, false},
+	{"name", []string{"!=other"}, `("data"->>'name' NOT IN ('other'))`, true},
+	{"name", []string{">search"}, `("data"->>'name' > 'search')`, true},
+	{"name", []string{"<search"}, `("data"->>'name' < 'search')`, false},
+	{"name", []string{"search-*"}, `("data"->>'name' LIKE 'search-%')`, true},
+	{"name", []string{"search-api-_"}, `"data"->'name'?('search-api-_')`, false},
+	{"name", []string{"*API*"}, `("data"->>'name' LIKE '%API%')`, false},
+	{"name", []string{"!search-*"}, `NOT(("data"->>'name' LIKE 'search-%'))`, false},
+	{"name", []string{"!=kube-*"}, `NOT(("data"->>'name' LIKE 'kube-%'))`, true},
+	{"version", []string{"10"}, `("data"->>'version' IN ('10'))`, true},
+	{"version", []string{">9"}, `("data"->>'version' > '9')`, false}, // Compared as text.
+	// Kind is compared case-insensitive when a value starts with a lowercase letter.
+	{"kind", []string{"pod"}, `("data"->>'kind' ILIKE ANY ('{"pod"}'))`, true},
+	{"kind", []string{"POD"}, `"data"->'kind'?('POD')`, false},
+	{"kind", []string{"!pod"}, `("data"->>'kind' NOT IN ('pod'))`, true},
+	{"kind", []string{"po*"}, `("data"->>'kind' LIKE 'po%')`, false},
+	// Cluster is a column.
+	{"cluster", []string{"local-cluster"}, `("cluster" IN ('local-cluster'))`, true},
+	{"cluster", []string{"!local-cluster"}, `("cluster" NOT IN ('local-cluster'))`, false},
+	{"cluster", []string{"local*"}, `("cluster" LIKE 'local%')`, true},
+	// Numbers
+	{"restarts", []string{"3"}, `(("data"->'restarts')::numeric IN ('3'))`, true},
+	{"restarts", []string{"3.0"}, `(("data"->'restarts')::numeric IN ('3.0'))`, true},
+	{"restarts", []string{">2"}, `(("data"->'restarts')::numeric > '2')`, true},
+	{"restarts", []string{">=10"}, `(("data"->'restarts')::numeric >= '10')`, false},
+	{"restarts", []string{"<=2"}, `(("data"->'restarts')::numeric <= '2')`, false},
+	{"restarts", []string{"<10"}, `(("data"->'restarts')::numeric < '10')`, true}, // Not compared as text.
+	{"restarts", []string{"!3"}, `(("data"->'restarts')::numeric NOT IN ('3'))`, false},
+	{"restarts", []string{"<1", ">2"},
+		`((("data"->'restarts')::numeric < '1') OR (("data"->'restarts')::numeric > '2'))`, true},
+	// Booleans
+	{"ready", []string{"true"}, `("data"->>'ready' IN ('true'))`, true},
+	{"ready", []string{"!true"}, `("data"->>'ready' NOT IN ('true'))`, false},
+	// Relative dates, after the date by default.
+	{"created", []string{"day"}, "", true},
+	{"created", []string{"hour"}, "", false},
+	{"created", []string{"<hour"}, "", true},
+	{"created", []string{"<=week"}, "", false},
+	// Objects
+	{"label", []string{"app=search"}, `"data"->'label' @> '{"app":"search"}'`, true},
+	{"label", []string{"app=other", "tier=api"},
+		`("data"->'label' @> '{"app":"other"}' OR "data"->'label' @> '{"tier":"api"}')`, true},
+	{"label", []string{"!app=search"}, `NOT("data"->'label' @> '{"app":"search"}')`, false},
+	{"label", []string{"!=app=other"}, `NOT("data"->'label' @> '{"app":"other"}')`, true},
+	{"label", []string{"app=sea*"}, `EXISTS((SELECT 1 FROM jsonb_each_text("data"->'label') As kv(key, value) ` +
+		`WHERE ((key LIKE 'app') AND (value LIKE 'sea%'))))`, true},
+	{"label", []string{"!app=sea*"}, `NOT EXISTS((SELECT 1 FROM jsonb_each_text("data"->'label') As kv(key, ` +
+		`value) WHERE ((key LIKE 'app') AND (value LIKE 'sea%'))))`, false},
+	{"label", []string{"*api*"}, `EXISTS((SELECT 1 FROM jsonb_each_text("data"->'label') As kv(key, value) ` +
+		`WHERE ((key LIKE ('%api%')) OR (value LIKE ('%api%')))))`, true},
+	{"annotation", []string{"a=b"}, `"data"->'annotation' @> '{"a":"b"}'`, false},
+	{"annotation", []string{"!a=b*"}, `NOT EXISTS((SELECT 1 FROM jsonb_each_text("data"->'annotation') As kv(` +
+		`key, value) WHERE ((key LIKE 'a') AND (value LIKE 'b%'))))`, true}, // Missing, no key matches.
+	// Arrays
+	{"container", []string{"proxy"}, `"data"->'container' @> '["proxy"]'`, true},
+	{"container", []string{"!proxy"}, `NOT("data"->'container' @> '["proxy"]')`, false},
+	{"container", []string{"search-*"}, `EXISTS((SELECT 1 FROM jsonb_array_elements_text("data"->'container') ` +
+		`As arrayProp WHERE (arrayProp LIKE 'search-%')))`, true},
+	{"container", []string{"!*-api"}, `NOT EXISTS((SELECT 1 FROM jsonb_array_elements_text("data"->` +
+		`'container') As arrayProp WHERE (arrayProp LIKE '%-api')))`, false},
+	// Unknown property
+	{"unknown", []string{"value"}, `1 = 0`, false},
+}
+
+// Shared by the SQL and the in-memory evaluation of the keywords, with the resource of newFilterTestData().
+var keywordTests = []struct {
+	keywords []string
+	sql      string
+	match    bool
+}{
+	{[]string{"SEARCH-API"}, `("value" ILIKE '%SEARCH-API%')`, true},
+	{[]string{"local"}, `("value" ILIKE '%local%')`, true},
+	{[]string{`"tier": "api"`}, `("value" ILIKE '%"tier": "api"%')`, true}, // Objects and arrays as jsonb text.
+	{[]string{`"proxy"]`}, `("value" ILIKE '%"proxy"]%')`, true},
+	{[]string{"true"}, `("value" ILIKE '%true%')`, true},
+	{[]string{"sea%1"}, `("value" ILIKE '%sea%1%')`, true},
+	{[]string{"other"}, `("value" ILIKE '%other%')`, false},
+	// All the keywords match the same value, like the SQL over the rows of jsonb_each_text("data").
+	{[]string{"search", "api-1"}, `(("value" ILIKE '%search%') AND ("value" ILIKE '%api-1%'))`, true},
+	{[]string{"search", "local"}, `(("value" ILIKE '%search%') AND ("value" ILIKE '%local%'))`, false},
+	{[]string{"pod", "proxy"}, `(("value" ILIKE '%pod%') AND ("value" ILIKE '%proxy%'))`, false},
+}
+
+func newTestFilter(t *testing.T, input *model.SearchInput) *searchFilter {
+	filter, err := newSearchFilter(input, func(property string) (string, bool, error) {
+		dataType, ok := filterTestTypes[property]
+		return dataType, ok, nil
+	})
+	assert.NoError(t, err)
+	return filter
+}
+
+func filterTestInput(property string, values []string) *model.SearchInput {
+	filter := &model.SearchFilter{Property: property}
+	for i := range values {
+		filter.Values = append(filter.Values, &values[i])
+	}
+	return &model.SearchInput{Filters: []*model.SearchFilter{filter}}
+}
+
+func whereClause(t *testing.T, filter *searchFilter) string {
+	sql, _, err := dialect.From(goqu.S("search").Table("resources")).Select("uid").Where(filter.sql()...).ToSQL()
+	assert.NoError(t, err)
+	return strings.TrimPrefix(sql, `SELECT "uid" FROM "search"."resources" WHERE `)
+}
+
+func Test_searchFilter_Filters(t *testing.T) {
+	for _, test := range filterTests {
+		values := append([]string{}, test.values...) // Parsing modifies the values.
+		filter := newTestFilter(t, filterTestInput(test.property, values))
+
+		if test.sql != "" {
+			assert.Equal(t, test.sql, whereClause(t, filter), "SQL of %s %v", test.property, test.values)
+		}
+		assert.Equal(t, test.match, filter.matches(newFilterTestData()), "Match of %s %v", test.property,
+			test.values)
+	}
+}
+
+func Test_searchFilter_Keywords(t *testing.T) {
+	for _, test := range keywordTests {
+		input := &model.SearchInput{}
+		for i := range test.keywords {
+			input.Keywords = append(input.Keywords, &test.keywords[i])
+		}
+		filter := newTestFilter(t, input)
+
+		assert.Equal(t, test.sql, whereClause(t, filter), "SQL of keywords %v", test.keywords)
+		assert.Equal(t, test.match, filter.matches(newFilterTestData()), "Match of keywords %v", test.keywords)
+	}
+}
+
+// The events have the same results, with the types of the values in the event.
+func Test_eventMatchesAllFilters_SameAsSearch(t *testing.T) {
+	data := newFilterTestData()
+	for _, test := range filterTests {
+		if _, exists := data[test.property]; !exists {
+			continue // Parsed as a string, the type of a missing property isn't known.
+		}
+		values := append([]string{}, test.values...)
+		event := &model.Event{UID: "local-cluster/pod-1", Operation: "UPDATE", NewData: newFilterTestData()}
+
+		filter := newEventFilter(filterTestInput(test.property, values))
+		assert.Equal(t, test.match, eventMatchesAllFilters(event, filter), "Event match of %s %v", test.property,
+			test.values)
+	}
+}
+
+func Test_likeMatches_Escape(t *testing.T) {
+	assert.True(t, likeMatches("100%", `100\%`))
+	assert.False(t, likeMatches("1000", `100\%`))
+	assert.True(t, likeMatches("a_b", `a\_b`))
+	assert.True(t, likeMatches("añb", "a_b"))
+}
+
+func Test_jsonbString(t *testing.T) {
+	assert.Equal(t, `{"b": 1, "aa": [true, null, "x"]}`,
+		jsonbString(map[string]any{"aa": []any{true, nil, "x"}, "b": float64(1)}))
+	assert.Equal(t, "0.5", jsonbString(0.5))
+}
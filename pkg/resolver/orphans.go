@@ -0,0 +1,71 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/stolostron/search-v2-api/graph/model"
+	db "github.com/stolostron/search-v2-api/pkg/database"
+	"github.com/stolostron/search-v2-api/pkg/metrics"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"k8s.io/klog/v2"
+)
+
+// Orphans resolves the resources matching the input without relationships. If relatedKinds is set,
+// only relationships with those kinds are considered, for example ConfigMaps not related to any Pod.
+// Returns a SearchResult, so the input limit, offset and orderBy apply, and count can be requested.
+func Orphans(ctx context.Context, input *model.SearchInput, relatedKinds []*string) (*SearchResult, error) {
+	defer metrics.SlowLog("OrphansResolver", 0)()
+
+	if input == nil {
+		input = &model.SearchInput{}
+	}
+
+	userData, userDataErr := rbac.GetCache().GetUserData(ctx)
+	if userDataErr != nil {
+		return nil, userDataErr
+	}
+
+	// check that shared cache has resource datatypes
+	propTypes, err := getPropertyType(ctx, false)
+	if err != nil {
+		klog.Warningf("Error creating datatype map. Error: [%s] ", err)
+	}
+
+	return &SearchResult{
+		context:    ctx,
+		extraWhere: []exp.Expression{orphansWhereClause(nonEmptyValues(relatedKinds))},
+		input:      input,
+		pool:       db.GetConnPool(ctx),
+		propTypes:  propTypes,
+		userData:   userData,
+	}, nil
+}
+
+// Builds the anti-join with search.edges. Kinds are matched using a case-insensitive comparison,
+// same as the relatedKinds filter on the search query.
+//
+//	NOT EXISTS (SELECT 1 FROM "search"."edges" AS "e"
+//	  WHERE ((("e"."sourceid" = "resources"."uid") AND (lower("e"."destkind") IN ('pod')))
+//	  OR (("e"."destid" = "resources"."uid") AND (lower("e"."sourcekind") IN ('pod')))))
+func orphansWhereClause(relatedKinds []string) exp.Expression {
+	fromSource := []exp.Expression{goqu.I("e.sourceid").Eq(goqu.I("resources.uid"))}
+	fromDest := []exp.Expression{goqu.I("e.destid").Eq(goqu.I("resources.uid"))}
+
+	if len(relatedKinds) > 0 {
+		kinds := make([]string, len(relatedKinds))
+		for i, kind := range relatedKinds {
+			kinds[i] = strings.ToLower(kind)
+		}
+		fromSource = append(fromSource, goqu.Func("lower", goqu.I("e.destkind")).In(kinds))
+		fromDest = append(fromDest, goqu.Func("lower", goqu.I("e.sourcekind")).In(kinds))
+	}
+
+	edges := dialect.From(goqu.S("search").Table("edges").As("e")).
+		Select(goqu.L("1")).
+		Where(goqu.Or(goqu.And(fromSource...), goqu.And(fromDest...)))
+	return goqu.L("NOT EXISTS ?", edges)
+}
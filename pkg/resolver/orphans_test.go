@@ -0,0 +1,54 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"testing"
+
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/golang/mock/gomock"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Orphans_Count(t *testing.T) {
+	val1 := "ConfigMap"
+	searchInput := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&val1}}}}
+	resolver, mockPool := newMockSearchResolver(t, searchInput, nil, rbac.UserData{CsResources: []rbac.Resource{}},
+		map[string]string{"kind": "string"})
+	resolver.extraWhere = []exp.Expression{orphansWhereClause(nil)}
+
+	// Mock the database query
+	mockRow := &Row{MockValue: 3}
+	mockPool.EXPECT().QueryRow(gomock.Any(),
+		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ("data"->$1?($2) AND NOT EXISTS (SELECT 1 FROM "search"."edges" AS "e" WHERE (("e"."sourceid" = "resources"."uid") OR ("e"."destid" = "resources"."uid"))) AND (("cluster" = ANY ($3)) OR FALSE))`),
+		gomock.Eq([]interface{}{"kind", "ConfigMap", "{}"})).Return(mockRow)
+
+	r, err := resolver.Count()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, r)
+}
+
+func Test_Orphans_ItemsWithRelatedKinds(t *testing.T) {
+	val1 := "Secret"
+	limit := 10
+	offset := 20
+	searchInput := &model.SearchInput{
+		Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&val1}}},
+		Limit:   &limit,
+		Offset:  &offset,
+	}
+	resolver, mockPool := newMockSearchResolver(t, searchInput, nil, rbac.UserData{CsResources: []rbac.Resource{}},
+		map[string]string{"kind": "string"})
+	resolver.extraWhere = []exp.Expression{orphansWhereClause([]string{"Pod", "deployment"})}
+
+	// Mock the database query
+	mockRows := newMockRows("./mocks/mock.json")
+	mockPool.EXPECT().Query(gomock.Any(),
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->$1?($2) AND NOT EXISTS (SELECT 1 FROM "search"."edges" AS "e" WHERE ((("e"."sourceid" = "resources"."uid") AND (lower("e"."destkind") IN ($3, $4))) OR (("e"."destid" = "resources"."uid") AND (lower("e"."sourcekind") IN ($5, $6))))) AND (("cluster" = ANY ($7)) OR FALSE)) LIMIT $8 OFFSET $9`),
+		gomock.Eq([]interface{}{"kind", "Secret", "pod", "deployment", "pod", "deployment", "{}", int64(10), int64(20)})).Return(mockRows, nil)
+
+	result, err := resolver.Items()
+	assert.Nil(t, err)
+	assert.Equal(t, len(mockRows.mockData), len(result))
+}
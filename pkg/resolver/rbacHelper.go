@@ -4,16 +4,44 @@ package resolver
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/lib/pq"
 	"github.com/stolostron/search-v2-api/pkg/config"
+	"github.com/stolostron/search-v2-api/pkg/metrics"
 	"github.com/stolostron/search-v2-api/pkg/rbac"
 	v1 "k8s.io/api/authentication/v1"
 	"k8s.io/klog/v2"
 )
 
+// Get the where clause with rbac for the user. The clause is compiled once for each generation of the user's
+// cached data and reused by all resolvers.
+func rbacWhereClause(ctx context.Context, userrbac rbac.UserData, userInfo v1.UserInfo) exp.Expression {
+	return userrbac.RbacClause(func() exp.Expression {
+		rbacType := "basic"
+		if userrbac.IsClusterAdmin {
+			rbacType = "cluster_admin"
+		} else if config.Cfg.Features.FineGrainedRbac {
+			rbacType = "fine_grained"
+		}
+		start := time.Now()
+		clause := buildRbacWhereClause(ctx, userrbac, userInfo)
+		metrics.RbacClauseBuildDuration.WithLabelValues(rbacType).Observe(time.Since(start).Seconds())
+
+		sql, params, err := dialect.From("t").Where(clause).Prepared(true).ToSQL()
+		if err != nil {
+			klog.Warningf("Error building RBAC where clause for user %s. %s", userInfo.Username, err)
+			return clause
+		}
+		metrics.RbacClauseSize.WithLabelValues(rbacType).Observe(float64(len(sql)))
+		klog.V(4).Infof("Compiled %s RBAC where clause for user %s in %s. Size: %d bytes, %d parameters.",
+			rbacType, userInfo.Username, time.Since(start), len(sql), len(params))
+		return clause
+	})
+}
+
 // Build where clause with rbac by combining clusterscoped, namespace scoped and managed cluster access
 func buildRbacWhereClause(ctx context.Context, userrbac rbac.UserData, userInfo v1.UserInfo) exp.ExpressionList {
 	if userrbac.IsClusterAdmin {
@@ -124,6 +152,11 @@ func matchNamespacedResources(nsResources map[string][]rbac.Resource, userInfo v
 			userInfo.Username, userInfo.UID)
 		return goqu.Or() // return empty clause
 
+	} else if threshold := config.Cfg.RbacArrayThreshold; threshold > 0 && len(nsResources) > threshold {
+		klog.V(3).Infof("User %s with UID %s has access to %d namespaces. Using RBAC arrays.",
+			userInfo.Username, userInfo.UID, len(nsResources))
+		return goqu.Or(matchNamespacedResourcesArrays(nsResources))
+
 	} else {
 		var unMarshalErr error
 
@@ -160,6 +193,39 @@ func matchNamespacedResources(nsResources map[string][]rbac.Resource, userInfo v
 	}
 }
 
+// Match the authorized (namespace, apigroup, kind) tuples passed as arrays, instead of an OR clause for each
+// namespace group. Used for users with access to many namespaces, because the planner struggles with large
+// OR clauses. Matches the same resources as the OR clause:
+//   - An empty apigroup matches resources without apigroup, and '*' matches any apigroup.
+//   - Kind '*' matches any kind.
+//
+// Resolves to:
+//
+//	(data->'namespace' ?| '{a,b,...}' AND EXISTS (SELECT 1 FROM unnest('{a,a,b,...}'::text[], ...)
+//	 AS rbac(namespace, apigroup, kind) WHERE rbac.namespace = data->>'namespace' AND ...))
+func matchNamespacedResourcesArrays(nsResources map[string][]rbac.Resource) exp.Expression {
+	namespaces := getKeys(nsResources)
+	var tupleNamespaces, tupleApigroups, tupleKinds []string
+	for _, namespace := range namespaces {
+		for _, res := range nsResources[namespace] {
+			tupleNamespaces = append(tupleNamespaces, namespace)
+			tupleApigroups = append(tupleApigroups, res.Apigroup)
+			tupleKinds = append(tupleKinds, res.Kind)
+		}
+	}
+
+	return goqu.And(
+		goqu.L("???", goqu.L(`data->?`, "namespace"), goqu.Literal("?|"), pq.Array(namespaces)),
+		goqu.L(`EXISTS (SELECT 1 FROM unnest(?::text[], ?::text[], ?::text[]) AS rbac(namespace, apigroup, kind) `+
+			`WHERE rbac.namespace = data->>'namespace' `+
+			`AND (rbac.apigroup = '*' OR (rbac.apigroup = '' AND NOT(?)) OR `+
+			`(rbac.apigroup <> '' AND data->>'apigroup' = rbac.apigroup)) `+
+			`AND (rbac.kind = '*' OR data->>'kind_plural' = rbac.kind))`,
+			pq.Array(tupleNamespaces), pq.Array(tupleApigroups), pq.Array(tupleKinds),
+			goqu.L("???", goqu.C("data"), goqu.Literal("?"), "apigroup")),
+	)
+}
+
 // Consolidate namespace resources by resource groups as key and namespaces as values
 // Returns map with resource groups
 // array with keys of the map - to preserve order for testing
@@ -213,7 +279,7 @@ func matchHubClusterRbac(userrbac rbac.UserData, userInfo v1.UserInfo) exp.Expre
 //
 //	( cluster IN ['a', 'b', ...] )
 func matchManagedCluster(managedClusters []string) exp.BooleanExpression {
-	subQuery := goqu.From("search.resources").
+	subQuery := dialect.From("search.resources").
 		Select("cluster").
 		Where(goqu.L("(data ? '_hubClusterResource')").Eq(true)).Limit(1)
 
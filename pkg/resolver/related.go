@@ -4,11 +4,13 @@ package resolver
 import (
 	"context"
 	"fmt"
+	"maps"
 	"sort"
 	"strings"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/lib/pq"
 	"github.com/stolostron/search-v2-api/pkg/config"
 	"github.com/stolostron/search-v2-api/pkg/rbac"
 	klog "k8s.io/klog/v2"
@@ -68,9 +70,12 @@ func (s *SearchResult) buildRelationsQuery() {
 	-- select uid as uid, data->>'kind' as kind, 1 AS "level" FROM search.resources where cluster IN ('local-cluster')
 	*/
 	s.setDepth()
+	// The uids are bound as one array, so the SQL is the same for any number of uids and isn't limited by the
+	// 65535 bind parameters of PostgreSQL.
+	uids := pq.Array(PointerToStringArray(s.uids))
 	whereDs := []exp.Expression{
-		goqu.C("level").Lte(s.level), // Add filter to select up to level (default 3) relationships
-		goqu.C("uid").NotIn(s.uids)}  // Add filter to avoid selecting the search object itself
+		goqu.C("level").Lte(s.level),      // Add filter to select up to level (default 3) relationships
+		goqu.C("uid").Neq(goqu.All(uids))} // Add filter to avoid selecting the search object itself
 
 	//Non-recursive term SELECT CLAUSE
 	schema := goqu.S("search")
@@ -96,16 +101,16 @@ func (s *SearchResult) buildRelationsQuery() {
 	excludeResources := []interface{}{"Node", "Channel"}
 
 	// Non-recursive term
-	baseSource := goqu.From(schema.Table("edges").As("e")).
+	baseSource := dialect.From(schema.Table("edges").As("e")).
 		Select(selectBase...).
-		Where(goqu.Ex{"sourceid": s.uids})
-	baseDest := goqu.From(schema.Table("edges").As("e")).
+		Where(goqu.C("sourceid").Eq(goqu.Any(uids)))
+	baseDest := dialect.From(schema.Table("edges").As("e")).
 		Select(selectBase...).
-		Where(goqu.Ex{"destid": s.uids})
+		Where(goqu.C("destid").Eq(goqu.Any(uids)))
 	baseTerm := baseSource.UnionAll(baseDest)
 
 	// Recursive term
-	recursiveTerm := goqu.From(schema.Table("edges").As("e")).
+	recursiveTerm := dialect.From(schema.Table("edges").As("e")).
 		InnerJoin(goqu.T("search_graph").As("sg"),
 			goqu.On(goqu.ExOr{"sg.destid": srcDestIds, "sg.sourceid": srcDestIds})).
 		Select(selectNext...).
@@ -119,7 +124,7 @@ func (s *SearchResult) buildRelationsQuery() {
 	if s.level > 1 {
 		klog.V(5).Infof("Search term includes applications or level set by user. Level: %d", s.level)
 		// Recursive query. Refer: https://www.postgresqltutorial.com/postgresql-tutorial/postgresql-recursive-query/
-		searchGraphQ = goqu.From("search_graph").
+		searchGraphQ = dialect.From("search_graph").
 			WithRecursive("search_graph(level, sourceid, destid,  sourcekind, destkind, cluster, path)",
 				baseTerm.
 					Union(recursiveTerm)).
@@ -127,10 +132,10 @@ func (s *SearchResult) buildRelationsQuery() {
 	} else {
 		searchGraphQ = baseTerm // Query without recursion since it is only level 1
 	}
-	combineIds := goqu.From(searchGraphQ.As("search_graph")).Select(selectCombineIds...)
+	combineIds := dialect.From(searchGraphQ.As("search_graph")).Select(selectCombineIds...)
 	var relQuery *goqu.SelectDataset
 
-	relQuery = goqu.From(combineIds.As("combineIds")).
+	relQuery = dialect.From(combineIds.As("combineIds")).
 		Select(selectFinal...).
 		Where(whereDs...).
 		GroupBy(groupBy...)
@@ -142,7 +147,7 @@ func (s *SearchResult) buildRelationsQuery() {
 	if clusterSelectTerm != nil {
 		relQuery = relQuery.Union(clusterSelectTerm).As("related")
 	}
-	relQuery = goqu.From(relQuery.As("related")).Select("related.uid", "related.kind",
+	relQuery = dialect.From(relQuery.As("related")).Select("related.uid", "related.kind",
 		"related.level", "related.path")
 	relQueryInnerJoin := relQuery.InnerJoin(goqu.S("search").Table("resources"),
 		goqu.On(goqu.Ex{"related.uid": goqu.L(`"resources".uid`)}))
@@ -157,13 +162,13 @@ func (s *SearchResult) buildRelationsQuery() {
 	// if one of them is not nil, userData is not empty
 	if s.userData.CsResources != nil || s.userData.NsResources != nil || s.userData.ManagedClusters != nil {
 		// add rbac
-		relQueryWithRbac = relQueryInnerJoin.Where(buildRbacWhereClause(s.context, s.userData, userInfo))
+		relQueryWithRbac = relQueryInnerJoin.Where(rbacWhereClause(s.context, s.userData, userInfo))
 	} else {
 		s.checkErrorBuildingQuery(fmt.Errorf("RBAC clause is required! None found for relations query %+v for user %s with uid %s ",
 			s.input, userInfo.Username, userInfo.UID), "Error building search relations query")
 		return
 	}
-	sql, params, err := relQueryWithRbac.ToSQL()
+	sql, params, err := relQueryWithRbac.Prepared(true).ToSQL()
 
 	if err != nil {
 		klog.Error("Error creating relation query", err)
@@ -188,7 +193,7 @@ func (s *SearchResult) selectIfClusterUIDPresent() *goqu.SelectDataset {
 		// where cluster IN ('local-cluster', 'sv-remote-1')
 		//define schema table:
 		schemaTable := goqu.S("search").Table("resources")
-		ds := goqu.From(schemaTable)
+		ds := dialect.From(schemaTable)
 
 		//SELECT CLAUSE
 		selectDs := ds.Select(goqu.C("uid").As("uid"), goqu.L("data->>'kind'").As("kind"), goqu.L("1").As("level"),
@@ -199,7 +204,7 @@ func (s *SearchResult) selectIfClusterUIDPresent() *goqu.SelectDataset {
 		//LIMIT CLAUSE - Do we need limit here?
 		// limit := config.Cfg.QueryLimit
 
-		return selectDs.Where(goqu.C("cluster").In(clusterNames))
+		return selectDs.Where(goqu.C("cluster").Eq(goqu.Any(pq.Array(clusterNames))))
 	} else {
 		return nil
 	}
@@ -213,22 +218,22 @@ func (s *SearchResult) buildQueryToGetItemsFromUIDs() {
 	var err error
 	// define schema table
 	schemaTable := goqu.S("search").Table("resources")
-	ds := goqu.From(schemaTable)
+	ds := dialect.From(schemaTable)
 
 	// SELECT CLAUSE
 	selectDs := ds.Select("uid", "cluster", "data")
 
 	// WHERE CLAUSE
-	whereDs := []exp.Expression{goqu.C("uid").In(s.uids)} // Add filter to avoid selecting the search object itself
+	whereDs := []exp.Expression{goqu.C("uid").Eq(goqu.Any(pq.Array(PointerToStringArray(s.uids))))}
 
 	// LIMIT CLAUSE
 	limit := s.setLimit()
 
 	// Get the query
 	if limit != 0 {
-		sql, params, err = selectDs.Where(whereDs...).Limit(uint(limit)).ToSQL()
+		sql, params, err = selectDs.Where(whereDs...).Limit(uint(limit)).Prepared(true).ToSQL()
 	} else {
-		sql, params, err = selectDs.Where(whereDs...).ToSQL()
+		sql, params, err = selectDs.Where(whereDs...).Prepared(true).ToSQL()
 	}
 	if err != nil {
 		klog.Errorf("Error building SearchRelatedKinds query: %s", err.Error())
@@ -239,6 +244,41 @@ func (s *SearchResult) buildQueryToGetItemsFromUIDs() {
 	s.params = params
 }
 
+// A row of the relations query.
+type relationRow struct {
+	kind  string
+	level int
+	path  []string
+	uid   string
+}
+
+// Executes the relations query and reads the rows.
+func (s *SearchResult) resolveRelations() ([]relationRow, error) {
+	query, params := s.query, s.params
+	return coalesceQuery(s.context, "resolveRelationsFunc", s.pool, query, params, func() ([]relationRow, error) {
+		relations, err := s.pool.Query(s.context, query, params...) // how to deal with defaults.
+		if err != nil {
+			return nil, err
+		}
+		rows := []relationRow{}
+		if relations == nil {
+			return rows, nil
+		}
+		defer relations.Close()
+		// iterating through resulting rows and scaning data, destid  and destkind
+		for relations.Next() {
+			var row relationRow
+			relatedResultError := relations.Scan(&row.uid, &row.kind, &row.level, &row.path)
+			if relatedResultError != nil {
+				klog.Errorf("Error %s retrieving rows for relationships:%s", relatedResultError.Error(), relations)
+				continue
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	})
+}
+
 func (s *SearchResult) getRelationResolvers(ctx context.Context) []SearchRelatedResult {
 	klog.V(3).Infof("Resolving relationships for [%d] uids.\n", len(s.uids))
 	relatedSearch := []SearchRelatedResult{}
@@ -256,35 +296,22 @@ func (s *SearchResult) getRelationResolvers(ctx context.Context) []SearchRelated
 	}
 	// Build the relations query
 	s.buildRelationsQuery()
-	relations, relQueryError := s.pool.Query(s.context, s.query, s.params...) // how to deal with defaults.
+	relations, relQueryError := s.resolveRelations()
 	if relQueryError != nil {
 		klog.Errorf("Error while executing getRelations query. Error :%s", relQueryError.Error())
 		return relatedSearch
 	}
 
-	if relations != nil {
-		defer relations.Close()
-		processedUIDs := map[string]struct{}{}
-		// iterating through resulting rows and scaning data, destid  and destkind
-		for relations.Next() {
-			var kind, uid string
-			var path []string
-			var level int
-			relatedResultError := relations.Scan(&uid, &kind, &level, &path)
-
-			if relatedResultError != nil {
-				klog.Errorf("Error %s retrieving rows for relationships:%s", relatedResultError.Error(), relations)
-				continue
-			}
-			// Getting path can bring duplicate uids - Avoid duplicates by discarding already processed uids
-			if _, present := processedUIDs[uid]; !present {
-				processedUIDs[uid] = struct{}{}
-				// Store result in a map
-				s.updateKindMap(uid, kind, relatedMap)
-			}
-			// Store result->currentSearchUID relation
-			s.updResultToCurrSearchUidsMap(uid, currSearchUidsMap, resultToCurrSearchUidsMap, path)
+	processedUIDs := map[string]struct{}{}
+	for _, relation := range relations {
+		// Getting path can bring duplicate uids - Avoid duplicates by discarding already processed uids
+		if _, present := processedUIDs[relation.uid]; !present {
+			processedUIDs[relation.uid] = struct{}{}
+			// Store result in a map
+			s.updateKindMap(relation.uid, relation.kind, relatedMap)
 		}
+		// Store result->currentSearchUID relation
+		s.updResultToCurrSearchUidsMap(relation.uid, currSearchUidsMap, resultToCurrSearchUidsMap, relation.path)
 	}
 	// get uids for related items that match the relatedKind filter.
 	s.filterRelatedUIDs(relatedMap)
@@ -349,10 +376,12 @@ func (s *SearchResult) searchRelatedResultKindItems(items []map[string]interface
 	resultToCurrSearchMap map[string][]string) []SearchRelatedResult {
 	// Organize the related items by kind.
 	relatedItemsByKind := map[string][]map[string]interface{}{}
-	for _, currItem := range items {
-		kind := currItem["kind"].(string)
-		relatedUids := resultToCurrSearchMap[currItem["_uid"].(string)]
-		// Add the related ids to the currently processing item
+	for _, item := range items {
+		kind := item["kind"].(string)
+		relatedUids := resultToCurrSearchMap[item["_uid"].(string)]
+		// Add the related ids to a copy of the item. The items are shared with the coalesced queries and the
+		// search cache, and each request has its own related ids.
+		currItem := maps.Clone(item)
 		currItem["_relatedUids"] = relatedUids
 		kindItemList := relatedItemsByKind[kind]
 		relatedItemsByKind[kind] = append(kindItemList, currItem)
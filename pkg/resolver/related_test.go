@@ -3,10 +3,15 @@ package resolver
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
 	"github.com/stolostron/search-v2-api/graph/model"
 	"github.com/stolostron/search-v2-api/pkg/config"
 	"github.com/stolostron/search-v2-api/pkg/rbac"
@@ -24,7 +29,7 @@ func Test_SearchResolver_Relationships(t *testing.T) {
 	resolver, mockPool := newMockSearchResolver(t, searchInput, resultList, rbac.UserData{CsResources: []rbac.Resource{}}, nil)
 
 	// Mock FIRST database request.
-	query := strings.TrimSpace(`SELECT "related"."uid", "related"."kind", "related"."level", "related"."path" FROM (SELECT "uid", "kind", MIN("level") AS "level", "path" FROM (SELECT "level", unnest(array[sourceid, destid, concat('cluster__',cluster)]) AS "uid", unnest(array[sourcekind, destkind, 'Cluster']) AS "kind", "path" FROM (WITH RECURSIVE search_graph(level, sourceid, destid,  sourcekind, destkind, cluster, path) AS (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("sourceid" IN ('local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd', 'local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b')) UNION ALL (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("destid" IN ('local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd', 'local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b'))) UNION (SELECT level+1 AS "level", "e"."sourceid", "e"."destid", "e"."sourcekind", "e"."destkind", "e"."cluster", "path" FROM "search"."edges" AS "e" INNER JOIN "search_graph" AS "sg" ON (("sg"."destid" IN ("e"."sourceid", "e"."destid")) OR ("sg"."sourceid" IN ("e"."sourceid", "e"."destid"))) WHERE (("e"."destkind" NOT IN ('Node', 'Channel')) AND ("e"."sourcekind" NOT IN ('Node', 'Channel')) AND ("sg"."level" <= 3)))) SELECT DISTINCT "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", "path" FROM "search_graph") AS "search_graph") AS "combineIds" WHERE (("level" <= 3) AND ("uid" NOT IN ('local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd', 'local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b'))) GROUP BY "uid", "kind", "path") AS "related" INNER JOIN "search"."resources" ON ("related"."uid" = "resources".uid) WHERE (("cluster" = ANY ('{}')) OR FALSE)`)
+	query := strings.TrimSpace(`SELECT "related"."uid", "related"."kind", "related"."level", "related"."path" FROM (SELECT "uid", "kind", MIN("level") AS "level", "path" FROM (SELECT "level", unnest(array[sourceid, destid, concat('cluster__',cluster)]) AS "uid", unnest(array[sourcekind, destkind, 'Cluster']) AS "kind", "path" FROM (WITH RECURSIVE search_graph(level, sourceid, destid,  sourcekind, destkind, cluster, path) AS (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("sourceid" = ANY ($1)) UNION ALL (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("destid" = ANY ($2))) UNION (SELECT level+1 AS "level", "e"."sourceid", "e"."destid", "e"."sourcekind", "e"."destkind", "e"."cluster", "path" FROM "search"."edges" AS "e" INNER JOIN "search_graph" AS "sg" ON (("sg"."destid" IN ("e"."sourceid", "e"."destid")) OR ("sg"."sourceid" IN ("e"."sourceid", "e"."destid"))) WHERE (("e"."destkind" NOT IN ($3, $4)) AND ("e"."sourcekind" NOT IN ($5, $6)) AND ("sg"."level" <= $7)))) SELECT DISTINCT "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", "path" FROM "search_graph") AS "search_graph") AS "combineIds" WHERE (("level" <= $8) AND ("uid" != ALL ($9))) GROUP BY "uid", "kind", "path") AS "related" INNER JOIN "search"."resources" ON ("related"."uid" = "resources".uid) WHERE (("cluster" = ANY ($10)) OR FALSE)`)
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock-rel-1.json", searchInput, "", 0)
 	mockPool.EXPECT().Query(gomock.Any(),
 		gomock.Eq(query),
@@ -32,7 +37,7 @@ func Test_SearchResolver_Relationships(t *testing.T) {
 	).Return(mockRows, nil)
 
 	// Mock SECOND database request. UIDs are sorted alphabetically for stable ordering.
-	query2 := `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("uid" IN ('local-cluster/30c35f12-320a-417f-98d1-fbee28a4b2a6', 'local-cluster/411e30e4-f773-41a6-b745-24c93c173f45')) LIMIT 1000`
+	query2 := `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("uid" = ANY ($1)) LIMIT $2`
 	mockRows2 := newMockRows("./mocks/mock-related-test.json")
 	mockPool.EXPECT().Query(gomock.Any(),
 		gomock.Eq(query2),
@@ -63,6 +68,76 @@ func Test_SearchResolver_Relationships(t *testing.T) {
 	}
 }
 
+// Two requests resolving the relationships of the same items share the related items query. Run with -race.
+func Test_SearchResolver_Relationships_Coalesced(t *testing.T) {
+	relationLevel := config.Cfg.RelationLevel
+	t.Cleanup(func() { config.Cfg.RelationLevel = relationLevel })
+	config.Cfg.RelationLevel = 3
+	uid1 := "local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd"
+	uid2 := "local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b"
+	resultList := []*string{&uid1, &uid2}
+	searchInput := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "uid", Values: resultList}}}
+
+	newResolver := func() *SearchResult {
+		resolver, mockPool := newMockSearchResolver(t, searchInput, resultList,
+			rbac.UserData{CsResources: []rbac.Resource{}}, nil)
+		mockPool.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+				time.Sleep(50 * time.Millisecond) // Let the other request join the query in flight.
+				if strings.HasPrefix(query, `SELECT "related"`) {
+					return newMockRowsWithoutRBAC("./mocks/mock-rel-1.json", searchInput, "", 0), nil
+				}
+				return newMockRows("./mocks/mock-related-test.json"), nil
+			}).AnyTimes()
+		return resolver
+	}
+	resolvers := []*SearchResult{newResolver(), newResolver()}
+	results := make([][]SearchRelatedResult, len(resolvers))
+
+	var wg sync.WaitGroup
+	for i, resolver := range resolvers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = resolver.Related(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		assert.NotEmpty(t, result)
+		for _, related := range result {
+			for _, item := range related.Items {
+				assert.Contains(t, item, "_relatedUids", "Expected the related uids in the items of request %d.", i)
+			}
+		}
+	}
+	assert.NotEqual(t, reflect.ValueOf(results[0][0].Items[0]).Pointer(),
+		reflect.ValueOf(results[1][0].Items[0]).Pointer(), "Each request should annotate its own copy of the items.")
+}
+
+// The uids are bound as arrays, so the SQL doesn't change with the number of uids and it isn't limited by
+// the 65535 bind parameters of PostgreSQL.
+func Test_buildRelationsQuery_UidsAsArrays(t *testing.T) {
+	relationsQuery := func(count int) (string, []interface{}) {
+		uids := make([]*string, count)
+		for i := range uids {
+			uid := fmt.Sprintf("local-cluster/uid-%d", i)
+			uids[i] = &uid
+		}
+		resolver, _ := newMockSearchResolver(t, &model.SearchInput{}, uids,
+			rbac.UserData{CsResources: []rbac.Resource{}}, nil)
+		resolver.buildRelationsQuery()
+		return resolver.query, resolver.params
+	}
+
+	query, params := relationsQuery(2)
+	largeQuery, largeParams := relationsQuery(30000)
+
+	assert.Equal(t, query, largeQuery)
+	assert.Equal(t, len(params), len(largeParams))
+}
+
 func Test_SearchResolver_RelationshipsWithCluster(t *testing.T) {
 	config.Cfg.RelationLevel = 3
 
@@ -75,15 +150,15 @@ func Test_SearchResolver_RelationshipsWithCluster(t *testing.T) {
 	resolver, mockPool := newMockSearchResolver(t, searchInput, resultList, ud, nil)
 
 	// Mock FIRST database request.
-	query1 := strings.TrimSpace(`SELECT "related"."uid", "related"."kind", "related"."level", "related"."path" FROM (SELECT "uid", "kind", MIN("level") AS "level", "path" FROM (SELECT "level", unnest(array[sourceid, destid, concat('cluster__',cluster)]) AS "uid", unnest(array[sourcekind, destkind, 'Cluster']) AS "kind", "path" FROM (WITH RECURSIVE search_graph(level, sourceid, destid,  sourcekind, destkind, cluster, path) AS (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("sourceid" IN ('cluster__local-cluster')) UNION ALL (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("destid" IN ('cluster__local-cluster'))) UNION (SELECT level+1 AS "level", "e"."sourceid", "e"."destid", "e"."sourcekind", "e"."destkind", "e"."cluster", "path" FROM "search"."edges" AS "e" INNER JOIN "search_graph" AS "sg" ON (("sg"."destid" IN ("e"."sourceid", "e"."destid")) OR ("sg"."sourceid" IN ("e"."sourceid", "e"."destid"))) WHERE (("e"."destkind" NOT IN ('Node', 'Channel')) AND ("e"."sourcekind" NOT IN ('Node', 'Channel')) AND ("sg"."level" <= 3)))) SELECT DISTINCT "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", "path" FROM "search_graph") AS "search_graph") AS "combineIds" WHERE (("level" <= 3) AND ("uid" NOT IN ('cluster__local-cluster'))) GROUP BY "uid", "kind", "path" UNION (SELECT "uid" AS "uid", data->>'kind' AS "kind", 1 AS "level", array[]::text[] AS "path" FROM "search"."resources" WHERE ("cluster" IN ('local-cluster')))) AS "related" INNER JOIN "search"."resources" ON ("related"."uid" = "resources".uid) WHERE (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))`)
+	query1 := strings.TrimSpace(`SELECT "related"."uid", "related"."kind", "related"."level", "related"."path" FROM (SELECT "uid", "kind", MIN("level") AS "level", "path" FROM (SELECT "level", unnest(array[sourceid, destid, concat('cluster__',cluster)]) AS "uid", unnest(array[sourcekind, destkind, 'Cluster']) AS "kind", "path" FROM (WITH RECURSIVE search_graph(level, sourceid, destid,  sourcekind, destkind, cluster, path) AS (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("sourceid" = ANY ($1)) UNION ALL (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("destid" = ANY ($2))) UNION (SELECT level+1 AS "level", "e"."sourceid", "e"."destid", "e"."sourcekind", "e"."destkind", "e"."cluster", "path" FROM "search"."edges" AS "e" INNER JOIN "search_graph" AS "sg" ON (("sg"."destid" IN ("e"."sourceid", "e"."destid")) OR ("sg"."sourceid" IN ("e"."sourceid", "e"."destid"))) WHERE (("e"."destkind" NOT IN ($3, $4)) AND ("e"."sourcekind" NOT IN ($5, $6)) AND ("sg"."level" <= $7)))) SELECT DISTINCT "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", "path" FROM "search_graph") AS "search_graph") AS "combineIds" WHERE (("level" <= $8) AND ("uid" != ALL ($9))) GROUP BY "uid", "kind", "path" UNION (SELECT "uid" AS "uid", data->>'kind' AS "kind", 1 AS "level", array[]::text[] AS "path" FROM "search"."resources" WHERE ("cluster" = ANY ($10)))) AS "related" INNER JOIN "search"."resources" ON ("related"."uid" = "resources".uid) WHERE (("cluster" = ANY ($11)) OR ("data"?$12 AND ((NOT("data"?$13) AND ((NOT("data"?$14) AND data->$15?$16) OR (data->$17?$18 AND data->$19?$20))) OR ((data->$21?|$22 AND ((NOT("data"?$23) AND data->$24?$25) OR (data->$26?$27 AND data->$28?$29))) OR (data->$30?|$31 AND ((data->$32?$33 AND data->$34?$35) OR (data->$36?$37 AND data->$38?$39)))))))`)
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock-rel-1.json", searchInput, "", 0)
 	mockPool.EXPECT().Query(gomock.Any(),
 		gomock.Eq(query1),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq([]interface{}{"{\"cluster__local-cluster\"}", "{\"cluster__local-cluster\"}", "Node", "Channel", "Node", "Channel", int64(3), int64(3), "{\"cluster__local-cluster\"}", "{\"local-cluster\"}", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments"}),
 	).Return(mockRows, nil)
 
 	// Mock the SECOND database request. UIDs are sorted alphabetically for stable ordering.
-	query2 := `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("uid" IN ('local-cluster/30c35f12-320a-417f-98d1-fbee28a4b2a6', 'local-cluster/411e30e4-f773-41a6-b745-24c93c173f45')) LIMIT 1000`
+	query2 := `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("uid" = ANY ($1)) LIMIT $2`
 	mockRows2 := newMockRows("./mocks/mock-related-test.json")
 	mockPool.EXPECT().Query(gomock.Any(),
 		gomock.Eq(query2),
@@ -128,19 +203,19 @@ func Test_SearchResolver_RelatedKindsRelationships(t *testing.T) {
 	resolver, mockPool := newMockSearchResolver(t, searchInput, resultList, ud, nil)
 
 	// Mock the FIRST database request.
-	query1 := strings.TrimSpace(`SELECT "related"."uid", "related"."kind", "related"."level", "related"."path" FROM (SELECT "uid", "kind", MIN("level") AS "level", "path" FROM (SELECT "level", unnest(array[sourceid, destid, concat('cluster__',cluster)]) AS "uid", unnest(array[sourcekind, destkind, 'Cluster']) AS "kind", "path" FROM (WITH RECURSIVE search_graph(level, sourceid, destid,  sourcekind, destkind, cluster, path) AS (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("sourceid" IN ('local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd', 'local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b')) UNION ALL (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("destid" IN ('local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd', 'local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b'))) UNION (SELECT level+1 AS "level", "e"."sourceid", "e"."destid", "e"."sourcekind", "e"."destkind", "e"."cluster", "path" FROM "search"."edges" AS "e" INNER JOIN "search_graph" AS "sg" ON (("sg"."destid" IN ("e"."sourceid", "e"."destid")) OR ("sg"."sourceid" IN ("e"."sourceid", "e"."destid"))) WHERE (("e"."destkind" NOT IN ('Node', 'Channel')) AND ("e"."sourcekind" NOT IN ('Node', 'Channel')) AND ("sg"."level" <= 3)))) SELECT DISTINCT "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", "path" FROM "search_graph") AS "search_graph") AS "combineIds" WHERE (("level" <= 3) AND ("uid" NOT IN ('local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd', 'local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b'))) GROUP BY "uid", "kind", "path") AS "related" INNER JOIN "search"."resources" ON ("related"."uid" = "resources".uid) WHERE (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))`)
+	query1 := strings.TrimSpace(`SELECT "related"."uid", "related"."kind", "related"."level", "related"."path" FROM (SELECT "uid", "kind", MIN("level") AS "level", "path" FROM (SELECT "level", unnest(array[sourceid, destid, concat('cluster__',cluster)]) AS "uid", unnest(array[sourcekind, destkind, 'Cluster']) AS "kind", "path" FROM (WITH RECURSIVE search_graph(level, sourceid, destid,  sourcekind, destkind, cluster, path) AS (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("sourceid" = ANY ($1)) UNION ALL (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("destid" = ANY ($2))) UNION (SELECT level+1 AS "level", "e"."sourceid", "e"."destid", "e"."sourcekind", "e"."destkind", "e"."cluster", "path" FROM "search"."edges" AS "e" INNER JOIN "search_graph" AS "sg" ON (("sg"."destid" IN ("e"."sourceid", "e"."destid")) OR ("sg"."sourceid" IN ("e"."sourceid", "e"."destid"))) WHERE (("e"."destkind" NOT IN ($3, $4)) AND ("e"."sourcekind" NOT IN ($5, $6)) AND ("sg"."level" <= $7)))) SELECT DISTINCT "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", "path" FROM "search_graph") AS "search_graph") AS "combineIds" WHERE (("level" <= $8) AND ("uid" != ALL ($9))) GROUP BY "uid", "kind", "path") AS "related" INNER JOIN "search"."resources" ON ("related"."uid" = "resources".uid) WHERE (("cluster" = ANY ($10)) OR ("data"?$11 AND ((NOT("data"?$12) AND ((NOT("data"?$13) AND data->$14?$15) OR (data->$16?$17 AND data->$18?$19))) OR ((data->$20?|$21 AND ((NOT("data"?$22) AND data->$23?$24) OR (data->$25?$26 AND data->$27?$28))) OR (data->$29?|$30 AND ((data->$31?$32 AND data->$33?$34) OR (data->$35?$36 AND data->$37?$38)))))))`)
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock-rel-1.json", searchInput, "", 0)
 	mockPool.EXPECT().Query(gomock.Any(),
 		gomock.Eq(query1),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq([]interface{}{"{\"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd\",\"local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b\"}", "{\"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd\",\"local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b\"}", "Node", "Channel", "Node", "Channel", int64(3), int64(3), "{\"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd\",\"local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b\"}", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments"}),
 	).Return(mockRows, nil)
 
 	// Mock the SECOND database request.
-	query2 := `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("uid" IN ('local-cluster/30c35f12-320a-417f-98d1-fbee28a4b2a6')) LIMIT 1000`
+	query2 := `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("uid" = ANY ($1)) LIMIT $2`
 	mockRows2 := newMockRowsWithoutRBAC("./mocks/mock.json", searchInput, "", 0)
 	mockPool.EXPECT().Query(gomock.Any(),
 		gomock.Eq(query2),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq([]interface{}{"{\"local-cluster/30c35f12-320a-417f-98d1-fbee28a4b2a6\"}", int64(1000)}),
 	).Return(mockRows2, nil)
 
 	// Execute the function - should return a relatedResults object
@@ -169,19 +244,19 @@ func Test_SearchResolver_RelatedKindsRelationships_NegativeLimit(t *testing.T) {
 	resolver, mockPool := newMockSearchResolver(t, searchInput, resultList, rbac.UserData{CsResources: []rbac.Resource{}}, nil)
 
 	// Mock the FIRST database request.
-	query := strings.TrimSpace(`SELECT "related"."uid", "related"."kind", "related"."level", "related"."path" FROM (SELECT "uid", "kind", MIN("level") AS "level", "path" FROM (SELECT "level", unnest(array[sourceid, destid, concat('cluster__',cluster)]) AS "uid", unnest(array[sourcekind, destkind, 'Cluster']) AS "kind", "path" FROM (WITH RECURSIVE search_graph(level, sourceid, destid,  sourcekind, destkind, cluster, path) AS (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("sourceid" IN ('local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd', 'local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b')) UNION ALL (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("destid" IN ('local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd', 'local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b'))) UNION (SELECT level+1 AS "level", "e"."sourceid", "e"."destid", "e"."sourcekind", "e"."destkind", "e"."cluster", "path" FROM "search"."edges" AS "e" INNER JOIN "search_graph" AS "sg" ON (("sg"."destid" IN ("e"."sourceid", "e"."destid")) OR ("sg"."sourceid" IN ("e"."sourceid", "e"."destid"))) WHERE (("e"."destkind" NOT IN ('Node', 'Channel')) AND ("e"."sourcekind" NOT IN ('Node', 'Channel')) AND ("sg"."level" <= 3)))) SELECT DISTINCT "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", "path" FROM "search_graph") AS "search_graph") AS "combineIds" WHERE (("level" <= 3) AND ("uid" NOT IN ('local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd', 'local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b'))) GROUP BY "uid", "kind", "path") AS "related" INNER JOIN "search"."resources" ON ("related"."uid" = "resources".uid) WHERE (("cluster" = ANY ('{}')) OR FALSE)`)
+	query := strings.TrimSpace(`SELECT "related"."uid", "related"."kind", "related"."level", "related"."path" FROM (SELECT "uid", "kind", MIN("level") AS "level", "path" FROM (SELECT "level", unnest(array[sourceid, destid, concat('cluster__',cluster)]) AS "uid", unnest(array[sourcekind, destkind, 'Cluster']) AS "kind", "path" FROM (WITH RECURSIVE search_graph(level, sourceid, destid,  sourcekind, destkind, cluster, path) AS (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("sourceid" = ANY ($1)) UNION ALL (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("destid" = ANY ($2))) UNION (SELECT level+1 AS "level", "e"."sourceid", "e"."destid", "e"."sourcekind", "e"."destkind", "e"."cluster", "path" FROM "search"."edges" AS "e" INNER JOIN "search_graph" AS "sg" ON (("sg"."destid" IN ("e"."sourceid", "e"."destid")) OR ("sg"."sourceid" IN ("e"."sourceid", "e"."destid"))) WHERE (("e"."destkind" NOT IN ($3, $4)) AND ("e"."sourcekind" NOT IN ($5, $6)) AND ("sg"."level" <= $7)))) SELECT DISTINCT "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", "path" FROM "search_graph") AS "search_graph") AS "combineIds" WHERE (("level" <= $8) AND ("uid" != ALL ($9))) GROUP BY "uid", "kind", "path") AS "related" INNER JOIN "search"."resources" ON ("related"."uid" = "resources".uid) WHERE (("cluster" = ANY ($10)) OR FALSE)`)
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock-rel-1.json", searchInput, "", 0)
 	mockPool.EXPECT().Query(gomock.Any(),
 		gomock.Eq(query),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq([]interface{}{"{\"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd\",\"local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b\"}", "{\"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd\",\"local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b\"}", "Node", "Channel", "Node", "Channel", int64(3), int64(3), "{\"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd\",\"local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b\"}", "{}"}),
 	).Return(mockRows, nil)
 
 	// Mock the SECOND database request.
-	query2 := `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("uid" IN ('local-cluster/30c35f12-320a-417f-98d1-fbee28a4b2a6'))`
+	query2 := `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("uid" = ANY ($1))`
 	mockRows2 := newMockRowsWithoutRBAC("./mocks/mock.json", searchInput, "", 0)
 	mockPool.EXPECT().Query(gomock.Any(),
 		gomock.Eq(query2),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq([]interface{}{"{\"local-cluster/30c35f12-320a-417f-98d1-fbee28a4b2a6\"}"}),
 	).Return(mockRows2, nil)
 
 	// Execute the function - should return a relatedResults object
@@ -212,19 +287,19 @@ func Test_SearchResolver_Level1Related(t *testing.T) {
 	resolver, mockPool := newMockSearchResolver(t, searchInput, resultList, ud, nil)
 
 	// Mock the FIRST database request.
-	query := strings.TrimSpace(`SELECT "related"."uid", "related"."kind", "related"."level", "related"."path" FROM (SELECT "uid", "kind", MIN("level") AS "level", "path" FROM (SELECT "level", unnest(array[sourceid, destid, concat('cluster__',cluster)]) AS "uid", unnest(array[sourcekind, destkind, 'Cluster']) AS "kind", "path" FROM (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("sourceid" IN ('local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd', 'local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b')) UNION ALL (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("destid" IN ('local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd', 'local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b')))) AS "search_graph") AS "combineIds" WHERE (("level" <= 1) AND ("uid" NOT IN ('local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd', 'local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b'))) GROUP BY "uid", "kind", "path") AS "related" INNER JOIN "search"."resources" ON ("related"."uid" = "resources".uid) WHERE (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))`)
+	query := strings.TrimSpace(`SELECT "related"."uid", "related"."kind", "related"."level", "related"."path" FROM (SELECT "uid", "kind", MIN("level") AS "level", "path" FROM (SELECT "level", unnest(array[sourceid, destid, concat('cluster__',cluster)]) AS "uid", unnest(array[sourcekind, destkind, 'Cluster']) AS "kind", "path" FROM (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("sourceid" = ANY ($1)) UNION ALL (SELECT 1 AS "level", "sourceid", "destid", "sourcekind", "destkind", "cluster", array[sourceid, destid] AS "path" FROM "search"."edges" AS "e" WHERE ("destid" = ANY ($2)))) AS "search_graph") AS "combineIds" WHERE (("level" <= $3) AND ("uid" != ALL ($4))) GROUP BY "uid", "kind", "path") AS "related" INNER JOIN "search"."resources" ON ("related"."uid" = "resources".uid) WHERE (("cluster" = ANY ($5)) OR ("data"?$6 AND ((NOT("data"?$7) AND ((NOT("data"?$8) AND data->$9?$10) OR (data->$11?$12 AND data->$13?$14))) OR ((data->$15?|$16 AND ((NOT("data"?$17) AND data->$18?$19) OR (data->$20?$21 AND data->$22?$23))) OR (data->$24?|$25 AND ((data->$26?$27 AND data->$28?$29) OR (data->$30?$31 AND data->$32?$33)))))))`)
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock-rel-1.json", searchInput, "", 0)
 	mockPool.EXPECT().Query(gomock.Any(),
 		gomock.Eq(query),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq([]interface{}{"{\"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd\",\"local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b\"}", "{\"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd\",\"local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b\"}", int64(1), "{\"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd\",\"local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b\"}", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments"}),
 	).Return(mockRows, nil)
 
 	// Mock the SECOND database request.
-	query2 := `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("uid" IN ('local-cluster/30c35f12-320a-417f-98d1-fbee28a4b2a6'))`
+	query2 := `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("uid" = ANY ($1))`
 	mockRows2 := newMockRowsWithoutRBAC("./mocks/mock.json", searchInput, "", 0)
 	mockPool.EXPECT().Query(gomock.Any(),
 		gomock.Eq(query2),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq([]interface{}{"{\"local-cluster/30c35f12-320a-417f-98d1-fbee28a4b2a6\"}"}),
 	).Return(mockRows2, nil)
 
 	// Execute the function - should return a relatedResults object
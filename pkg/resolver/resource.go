@@ -0,0 +1,82 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/lib/pq"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+	db "github.com/stolostron/search-v2-api/pkg/database"
+	"github.com/stolostron/search-v2-api/pkg/metrics"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"k8s.io/klog/v2"
+)
+
+// Resource resolves a single resource using its UID.
+func Resource(ctx context.Context, uid string) (*SearchResult, error) {
+	return Resources(ctx, []string{uid})
+}
+
+// Resources resolves the resources matching the UIDs. Uses the primary key on search.resources
+// instead of filtering on the data, so it's cheaper than the equivalent search.
+// The RBAC clause is added by buildSearchQuery, so resources the user isn't authorized to list
+// are excluded from the result.
+func Resources(ctx context.Context, uids []string) (*SearchResult, error) {
+	defer metrics.SlowLog("ResourcesResolver", 0)()
+
+	where, limit, err := uidLookupWhereClause(uids)
+	if err != nil {
+		return nil, err
+	}
+
+	userData, userDataErr := rbac.GetCache().GetUserData(ctx)
+	if userDataErr != nil {
+		return nil, userDataErr
+	}
+
+	// check that shared cache has resource datatypes
+	propTypes, err := getPropertyType(ctx, false)
+	if err != nil {
+		klog.Warningf("Error creating datatype map. Error: [%s] ", err)
+	}
+
+	return &SearchResult{
+		context:    ctx,
+		extraWhere: []exp.Expression{where},
+		input:      &model.SearchInput{Limit: &limit},
+		pool:       db.GetConnPool(ctx),
+		propTypes:  propTypes,
+		userData:   userData,
+	}, nil
+}
+
+// Builds the WHERE clause to select resources by uid. Duplicate uids are ignored.
+// Returns the clause and the limit needed to return all the resources. The uids are bound as one array.
+//
+//	("uid" = ANY ('{"local-cluster/abc","cluster-a/xyz"}'))
+func uidLookupWhereClause(uids []string) (exp.Expression, int, error) {
+	uniqueUids := make([]string, 0, len(uids))
+	seen := map[string]struct{}{}
+	for _, uid := range uids {
+		if uid == "" {
+			return nil, 0, errors.New("invalid input. The uid must not be empty")
+		}
+		if _, found := seen[uid]; !found {
+			seen[uid] = struct{}{}
+			uniqueUids = append(uniqueUids, uid)
+		}
+	}
+	if len(uniqueUids) == 0 {
+		return nil, 0, errors.New("invalid input. At least one uid is required")
+	}
+	if uint(len(uniqueUids)) > config.Cfg.QueryLimit {
+		return nil, 0, fmt.Errorf("invalid input. Requested %d uids, the maximum is %d",
+			len(uniqueUids), config.Cfg.QueryLimit)
+	}
+	return goqu.C("uid").Eq(goqu.Any(pq.Array(uniqueUids))), len(uniqueUids), nil
+}
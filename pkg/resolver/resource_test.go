@@ -0,0 +1,77 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"testing"
+
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/golang/mock/gomock"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_uidLookupWhereClause(t *testing.T) {
+	where, limit, err := uidLookupWhereClause([]string{"local-cluster/uid-1", "managed1/uid-2", "local-cluster/uid-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, limit, "Duplicate uids should be ignored.")
+	assert.NotNil(t, where)
+}
+
+func Test_uidLookupWhereClause_InvalidInput(t *testing.T) {
+	_, _, err := uidLookupWhereClause([]string{})
+	assert.EqualError(t, err, "invalid input. At least one uid is required")
+
+	_, _, err = uidLookupWhereClause([]string{"local-cluster/uid-1", ""})
+	assert.EqualError(t, err, "invalid input. The uid must not be empty")
+
+	queryLimit := config.Cfg.QueryLimit
+	defer func() { config.Cfg.QueryLimit = queryLimit }()
+	config.Cfg.QueryLimit = 1
+	_, _, err = uidLookupWhereClause([]string{"local-cluster/uid-1", "local-cluster/uid-2"})
+	assert.EqualError(t, err, "invalid input. Requested 2 uids, the maximum is 1")
+}
+
+func Test_SearchResolver_ItemsByUid(t *testing.T) {
+	where, limit, err := uidLookupWhereClause([]string{"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd"})
+	assert.Nil(t, err)
+	csRes, nsRes, managedClusters := newUserData()
+	ud := rbac.UserData{CsResources: csRes, NsResources: nsRes, ManagedClusters: managedClusters}
+	resolver, mockPool := newMockSearchResolver(t, &model.SearchInput{Limit: &limit}, nil, ud, nil)
+	resolver.extraWhere = []exp.Expression{where}
+
+	// Mock the database query. Must include the RBAC clause.
+	mockRows := newMockRows("./mocks/mock.json")
+	mockRows.mockData = mockRows.mockData[:1]
+	mockPool.EXPECT().Query(gomock.Any(),
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("uid" = ANY ($1)) AND (("cluster" = ANY ($2)) OR ("data"?$3 AND ((NOT("data"?$4) AND ((NOT("data"?$5) AND data->$6?$7) OR (data->$8?$9 AND data->$10?$11))) OR ((data->$12?|$13 AND ((NOT("data"?$14) AND data->$15?$16) OR (data->$17?$18 AND data->$19?$20))) OR (data->$21?|$22 AND ((data->$23?$24 AND data->$25?$26) OR (data->$27?$28 AND data->$29?$30)))))))) LIMIT $31`),
+		gomock.Eq([]interface{}{"{\"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd\"}", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(1)}),
+	).Return(mockRows, nil)
+
+	result, err := resolver.Items()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(result))
+	assert.Equal(t, "local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd", result[0]["_uid"])
+	assert.Equal(t, "Template", result[0]["kind"])
+}
+
+func Test_SearchResolver_UidsByUid(t *testing.T) {
+	where, limit, err := uidLookupWhereClause([]string{"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd",
+		"local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b"})
+	assert.Nil(t, err)
+	resolver, mockPool := newMockSearchResolver(t, &model.SearchInput{Limit: &limit}, nil,
+		rbac.UserData{CsResources: []rbac.Resource{}}, nil)
+	resolver.extraWhere = []exp.Expression{where}
+
+	// Related() uses this query to get the uids used to find relationships.
+	mockRows := newMockRows("./mocks/mock.json")
+	mockPool.EXPECT().Query(gomock.Any(),
+		gomock.Eq(`SELECT "uid" FROM "search"."resources" WHERE (("uid" = ANY ($1)) AND (("cluster" = ANY ($2)) OR FALSE)) LIMIT $3`),
+		gomock.Eq([]interface{}{"{\"local-cluster/e12c2ddd-4ac5-499d-b0e0-20242f508afd\",\"local-cluster/13250bc4-865c-41db-a8f2-05bec0bd042b\"}", "{}", int64(2)}),
+	).Return(mockRows, nil)
+
+	err = resolver.Uids()
+	assert.Nil(t, err)
+	assert.Equal(t, len(mockRows.mockData), len(resolver.uids))
+}
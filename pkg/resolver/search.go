@@ -8,9 +8,12 @@ import (
 	"sync"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/jackc/pgx/v4"
+	"github.com/lib/pq"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stolostron/search-v2-api/graph/model"
 	"github.com/stolostron/search-v2-api/pkg/config"
@@ -20,20 +23,33 @@ import (
 	"k8s.io/klog/v2"
 )
 
-// Constants for jsonb data extraction
-const jsonbExtractOperator = "data->>?"
+// Returns the expression data->>'property' with the property inlined. The expression is repeated in the
+// SELECT DISTINCT and ORDER BY clauses, which PostgreSQL only matches when they're identical, so the property
+// can't be a bind parameter.
+func jsonbExtract(property string) exp.LiteralExpression {
+	return goqu.L("data->>" + pq.QuoteLiteral(property))
+}
 
 type SearchResult struct {
-	context   context.Context
-	input     *model.SearchInput
-	level     int // The number of levels/hops for finding relationships for a particular resource
-	params    []interface{}
-	pool      pgxpoolmock.PgxPool // Used to mock database pool in tests
-	propTypes map[string]string
-	query     string
-	uids      []*string // List of uids from search result to be used to get relatioinships.
-	userData  rbac.UserData
-	wg        sync.WaitGroup // Used to serialize search query and relatioinships query.
+	cacheKey       string // Normalized input and permissions hash, see searchCacheKey().
+	context        context.Context
+	count          int                      // Total count resolved with the items when countWithItems is set.
+	countWithItems bool                     // Resolve the count with the items query (COUNT(*) OVER()) when both are selected.
+	extraWhere     []exp.Expression         // Conditions not derived from the input, e.g. lookup resources by uid.
+	items          []map[string]interface{} // Items resolved with the count when countWithItems is set.
+	input          *model.SearchInput
+	level          int        // The number of levels/hops for finding relationships for a particular resource
+	mutex          sync.Mutex // Serializes the queries. Fields are resolved concurrently when the client uses @defer.
+	params         []interface{}
+	pool           pgxpoolmock.PgxPool // Used to mock database pool in tests
+	propTypes      map[string]string
+	query          string
+	snapshot       bool      // Run the queries in a single read-only transaction, see beginSnapshot().
+	snapshotEnd    func()    // Ends the snapshot transaction, set while it's open.
+	snapshotFields int       // Fields left to resolve before the snapshot ends, see snapshotFieldResolved().
+	uids           []*string // List of uids from search result to be used to get relatioinships.
+	userData       rbac.UserData
+	wg             sync.WaitGroup // Used to serialize search query and relatioinships query.
 }
 
 const ErrorMsg string = "Error building Search query:"
@@ -53,15 +69,31 @@ func Search(ctx context.Context, input []*model.SearchInput) ([]*SearchResult, e
 		klog.Warningf("Error creating datatype map. Error: [%s] ", err)
 	}
 
+	fields := selectedFields(ctx)
+	countWithItems := fields["items"] && (fields["count"] || fields["hasMore"])
+	// Use a consistent snapshot when more than one query is needed. The items, count and hasMore are resolved
+	// with one query when selected together. Queries in a snapshot can't be coalesced with other requests.
+	queries := len(fields)
+	if countWithItems {
+		queries = 1
+		if fields["related"] {
+			queries = 2
+		}
+	}
+	snapshot := config.Cfg.Features.ConsistentSnapshot && queries > 1
+
 	// Proceed if user's rbac data exists
 	if len(input) > 0 {
 		for index, in := range input {
 			srchResult[index] = &SearchResult{
-				input:     in,
-				pool:      db.GetConnPool(ctx),
-				userData:  userData,
-				context:   ctx,
-				propTypes: propTypes,
+				countWithItems: countWithItems,
+				input:          in,
+				pool:           db.GetConnPool(ctx),
+				snapshot:       snapshot,
+				snapshotFields: len(fields),
+				userData:       userData,
+				context:        ctx,
+				propTypes:      propTypes,
 			}
 		}
 	}
@@ -69,6 +101,22 @@ func Search(ctx context.Context, input []*model.SearchInput) ([]*SearchResult, e
 
 }
 
+// Returns the fields of the search result that query the database (items, count, hasMore and related)
+// selected in the GraphQL request.
+func selectedFields(ctx context.Context) map[string]bool {
+	fields := map[string]bool{}
+	if !graphql.HasOperationContext(ctx) || graphql.GetFieldContext(ctx) == nil {
+		return fields
+	}
+	for _, field := range graphql.CollectAllFields(ctx) {
+		switch field {
+		case "items", "count", "hasMore", "related":
+			fields[field] = true
+		}
+	}
+	return fields
+}
+
 // Stop search if managedHub is a filter and current hub name is not in values.
 // Otherwise, proceed with the search.
 func (s *SearchResult) matchesManagedHubFilter() bool {
@@ -100,20 +148,79 @@ func (s *SearchResult) Count() (int, error) {
 		return 0, nil
 	}
 	klog.V(2).Info("Resolving SearchResult:Count()")
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	defer s.snapshotFieldResolved()
+	s.beginSnapshot()
+	if s.countWithItems {
+		_, count, err := s.resolveItemsWithCount()
+		return count, err
+	}
+	if cached := s.cacheGet("count"); cached != nil {
+		return cached.count, nil
+	}
+	cacheSet := s.cacheBegin("count")
 	err := s.buildSearchQuery(s.context, true, false)
 	if err != nil {
 		return 0, err
 	}
-	return s.resolveCount()
+	count, err := s.resolveCount()
+	if err == nil {
+		cacheSet(count, nil)
+	}
+	return count, err
+}
+
+// HasMore returns true if there are more resources matching the query after the items returned.
+func (s *SearchResult) HasMore() (bool, error) {
+	if !s.matchesManagedHubFilter() { // if current hub is not part of managedHub filter, stop search
+		return false, nil
+	}
+	klog.V(2).Info("Resolving SearchResult:HasMore()")
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	defer s.snapshotFieldResolved()
+	s.beginSnapshot()
+	offset := 0
+	if s.input != nil && s.input.Offset != nil && *s.input.Offset > 0 {
+		offset = *s.input.Offset
+	}
+	if s.countWithItems {
+		items, count, err := s.resolveItemsWithCount()
+		return offset+len(items) < count, err
+	}
+
+	limit := s.setLimit()
+	if limit == 0 { // No limit, all items are returned.
+		return false, nil
+	}
+	if err := s.buildSearchQuery(s.context, true, false); err != nil {
+		return false, err
+	}
+	count, err := s.resolveCount()
+	return uint(offset)+limit < uint(count), err // #nosec G115 - offset and count are non-negative.
 }
 
 func (s *SearchResult) Items() ([]map[string]interface{}, error) {
+	s.mutex.Lock() // Lock before wg.Add() to avoid a deadlock with Related().
+	defer s.mutex.Unlock()
 	s.wg.Add(1)
 	defer s.wg.Done()
 	if !s.matchesManagedHubFilter() { // if current hub is not part of managedHub filter, stop search
 		return []map[string]interface{}{}, nil
 	}
 	klog.V(2).Info("Resolving SearchResult:Items()")
+	defer s.snapshotFieldResolved()
+	s.beginSnapshot()
+	if s.countWithItems {
+		items, _, err := s.resolveItemsWithCount()
+		return items, err
+	}
+	if cached := s.cacheGet("items"); cached != nil {
+		s.uids = append([]*string{}, cached.uids...)
+		return cached.items, nil
+	}
+	cacheSet := s.cacheBegin("items")
 	err := s.buildSearchQuery(s.context, false, false)
 	if err != nil {
 		return nil, err
@@ -121,6 +228,8 @@ func (s *SearchResult) Items() ([]map[string]interface{}, error) {
 	r, e := s.resolveItems()
 	if e != nil {
 		s.checkErrorBuildingQuery(e, "Error resolving items.")
+	} else {
+		cacheSet(0, r)
 	}
 	return r, e
 }
@@ -130,9 +239,13 @@ func (s *SearchResult) Related(ctx context.Context) ([]SearchRelatedResult, erro
 	if !s.matchesManagedHubFilter() { // if current hub is not part of managedHub filter, stop search
 		return r, nil
 	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	if s.context == nil {
 		s.context = ctx
 	}
+	defer s.snapshotFieldResolved()
+	s.beginSnapshot()
 	if s.uids == nil {
 		err := s.Uids()
 		if err != nil {
@@ -154,6 +267,40 @@ func (s *SearchResult) Related(ctx context.Context) ([]SearchRelatedResult, erro
 	return r, nil
 }
 
+// Resolves the items and the total count with one query. COUNT(*) OVER() counts all the rows matching
+// the WHERE and RBAC clauses before the LIMIT and OFFSET are applied. The results are kept, so the query
+// runs once for both fields. Must be called with the mutex locked.
+func (s *SearchResult) resolveItemsWithCount() ([]map[string]interface{}, int, error) {
+	if s.items != nil {
+		return s.items, s.count, nil
+	}
+	if cached := s.cacheGet("itemsWithCount"); cached != nil {
+		s.items, s.count, s.uids = cached.items, cached.count, append([]*string{}, cached.uids...)
+		return s.items, s.count, nil
+	}
+	cacheSet := s.cacheBegin("itemsWithCount")
+	if err := s.buildSearchQuery(s.context, false, false); err != nil {
+		return nil, 0, err
+	}
+	items, err := s.resolveItems()
+	if err != nil {
+		s.checkErrorBuildingQuery(err, "Error resolving items.")
+		return items, 0, err
+	}
+	// There are no rows to get the count from when the offset is past the last item.
+	if len(items) == 0 && s.input.Offset != nil && *s.input.Offset > 0 {
+		if err = s.buildSearchQuery(s.context, true, false); err != nil {
+			return items, 0, err
+		}
+		if s.count, err = s.resolveCount(); err != nil {
+			return items, 0, err
+		}
+	}
+	s.items = items
+	cacheSet(s.count, items)
+	return items, s.count, nil
+}
+
 func (s *SearchResult) Uids() error {
 	klog.V(2).Info("Resolving SearchResult:Uids()")
 	err := s.buildSearchQuery(s.context, false, true)
@@ -175,20 +322,21 @@ func (s *SearchResult) buildSearchQuery(ctx context.Context, count bool, uid boo
 
 	// define schema table:
 	schemaTable := goqu.S("search").Table("resources")
-	ds := goqu.From(schemaTable)
+	ds := dialect.From(schemaTable)
 
 	if len(s.input.Keywords) > 0 {
 		jsb := goqu.L("jsonb_each_text(?)", goqu.C("data"))
-		ds = goqu.From(schemaTable, jsb)
+		ds = dialect.From(schemaTable, jsb)
 	}
 
-	if s.input != nil && (len(s.input.Filters) > 0 || len(s.input.Keywords) > 0) {
+	if s.input != nil && (len(s.input.Filters) > 0 || len(s.input.Keywords) > 0 || len(s.extraWhere) > 0) {
 		// WHERE CLAUSE
 		whereDs, s.propTypes, err = WhereClauseFilter(s.context, s.input, s.propTypes)
 		if err != nil {
 			s.checkErrorBuildingQuery(err, ErrorMsg)
 			return err
 		}
+		whereDs = append(whereDs, s.extraWhere...)
 
 		// SELECT CLAUSE
 		selectDs = s.buildSelectClause(ds, count, uid)
@@ -202,7 +350,7 @@ func (s *SearchResult) buildSearchQuery(ctx context.Context, count bool, uid boo
 		// if one of them is not nil, userData is not empty
 		if s.userData.CsResources != nil || s.userData.NsResources != nil || s.userData.ManagedClusters != nil {
 			whereDs = append(whereDs,
-				buildRbacWhereClause(ctx, s.userData, userInfo)) // add rbac
+				rbacWhereClause(ctx, s.userData, userInfo)) // add rbac
 			if len(whereDs) == 0 {
 				s.checkErrorBuildingQuery(fmt.Errorf("search query must contain a whereClause"),
 					ErrorMsg)
@@ -259,7 +407,7 @@ func (s *SearchResult) buildSearchQuery(ctx context.Context, count bool, uid boo
 	}
 
 	// Get the query
-	sql, params, err = queryDs.ToSQL()
+	sql, params, err = queryDs.Prepared(true).ToSQL()
 	if err != nil {
 		s.checkErrorBuildingQuery(err, ErrorMsg)
 	}
@@ -281,20 +429,22 @@ func (s *SearchResult) buildSelectClause(ds *goqu.SelectDataset, count bool, uid
 		return ds.Select("uid")
 	}
 
+	columns := []interface{}{"uid", "cluster", "data"}
 	// Items query with possible ORDER BY
 	if s.input.OrderBy != nil && *s.input.OrderBy != "" {
 		orderProperty := s.extractOrderByProperty()
-		if orderProperty != "" {
-			// 'cluster' and 'uid' are already in SELECT, no need to add them again
-			if orderProperty == "cluster" || orderProperty == "uid" {
-				return ds.SelectDistinct("uid", "cluster", "data")
-			}
+		// 'cluster' and 'uid' are already in SELECT, no need to add them again
+		if orderProperty != "" && orderProperty != "cluster" && orderProperty != "uid" {
 			// Include the order field in the SELECT to make it compatible with DISTINCT
-			return ds.SelectDistinct("uid", "cluster", "data", goqu.L(jsonbExtractOperator, orderProperty))
+			columns = append(columns, jsonbExtract(orderProperty))
 		}
 	}
+	// Total count of rows matching the query, before the LIMIT is applied.
+	if s.countWithItems {
+		columns = append(columns, goqu.L("COUNT(*) OVER()").As("total"))
+	}
 
-	return ds.SelectDistinct("uid", "cluster", "data")
+	return ds.SelectDistinct(columns...)
 }
 
 // extractOrderByProperty extracts just the property name from the orderBy string.
@@ -370,9 +520,9 @@ func (s *SearchResult) applyOrderBy(queryDs *goqu.SelectDataset) (*goqu.SelectDa
 	} else {
 		// JSON field: use data->>'property'
 		if direction == "desc" {
-			orderExp = goqu.L(jsonbExtractOperator, property).Desc()
+			orderExp = jsonbExtract(property).Desc()
 		} else {
-			orderExp = goqu.L(jsonbExtractOperator, property).Asc()
+			orderExp = jsonbExtract(property).Asc()
 		}
 	}
 
@@ -387,154 +537,145 @@ func (s *SearchResult) checkErrorBuildingQuery(err error, logMessage string) {
 }
 
 func (s *SearchResult) resolveCount() (int, error) {
-	rows := s.pool.QueryRow(context.TODO(), s.query, s.params...)
+	query, params := s.query, s.params
+	return coalesceQuery(s.context, "resolveCountFunc", s.pool, query, params, func() (int, error) {
+		rows := s.pool.QueryRow(context.TODO(), query, params...)
 
-	var count int
-	err := rows.Scan(&count)
-	if err != nil {
-		klog.Errorf("Error resolving count. Error: %s  Query: %s", err.Error(), s.query)
-	}
-	return count, err
+		var count int
+		err := rows.Scan(&count)
+		if err != nil {
+			klog.Errorf("Error resolving count. Error: %s  Query: %s", err.Error(), query)
+		}
+		return count, err
+	})
 }
 
 func (s *SearchResult) resolveUids() error {
-	rows, err := s.pool.Query(s.context, s.query, s.params...)
-	if err != nil {
-		klog.Errorf("Error resolving UIDs. Query [%s] with args [%+v]. Error: [%+v]", s.query, s.params, err)
-		return err
-	}
-	defer rows.Close()
-	for rows.Next() {
-		var uid string
-		err = rows.Scan(&uid)
+	query, params := s.query, s.params
+	uids, err := coalesceQuery(s.context, "resolveUidsFunc", s.pool, query, params, func() ([]*string, error) {
+		rows, err := s.pool.Query(s.context, query, params...)
 		if err != nil {
-			klog.Errorf("Error %s retrieving rows for query:%s", err.Error(), s.query)
+			klog.Errorf("Error resolving UIDs. Query [%s] with args [%+v]. Error: [%+v]", query, params, err)
+			return nil, err
 		}
-		s.uids = append(s.uids, &uid)
-	}
-	return nil
+		defer rows.Close()
+		uids := []*string{}
+		for rows.Next() {
+			var uid string
+			err = rows.Scan(&uid)
+			if err != nil {
+				klog.Errorf("Error %s retrieving rows for query:%s", err.Error(), query)
+			}
+			uids = append(uids, &uid)
+		}
+		return uids, nil
+	})
+	s.uids = append(s.uids, uids...)
+	return err
 }
-func (s *SearchResult) resolveItems() ([]map[string]interface{}, error) {
-	items := []map[string]interface{}{}
-	timer := prometheus.NewTimer(metrics.DBQueryDuration.WithLabelValues("resolveItemsFunc"))
-	klog.V(5).Infof("Query issued by resolver [%s] ", s.query)
-	rows, err := s.pool.Query(s.context, s.query, s.params...)
-
-	defer timer.ObserveDuration()
-	if err != nil {
-		klog.Errorf("Error resolving query [%s] with args [%+v]. Error: [%+v]", s.query, s.params, err)
-		return items, err
-	}
-	defer rows.Close()
 
-	s.uids = make([]*string, len(items))
-
-	for rows.Next() {
-		var uid string
-		var cluster string
-		var data map[string]interface{}
+// Items, uids and total count (if selected with COUNT(*) OVER()) resolved by the items query.
+type itemsQueryResult struct {
+	count int
+	items []map[string]interface{}
+	uids  []*string
+}
 
-		// Check if the query actually contains the order field in SELECT by looking at the query string
-		// The order field is only added for SELECT DISTINCT queries (main items),
-		// NOT for related items queries which use regular SELECT
-		hasOrderFieldInQuery := s.input.OrderBy != nil && *s.input.OrderBy != "" &&
-			strings.Contains(s.query, "data->>'")
+func (s *SearchResult) resolveItems() ([]map[string]interface{}, error) {
+	query, params := s.query, s.params
+	result, err := coalesceQuery(s.context, "resolveItemsFunc", s.pool, query, params,
+		func() (itemsQueryResult, error) {
+			result := itemsQueryResult{items: []map[string]interface{}{}, uids: []*string{}}
+			timer := prometheus.NewTimer(metrics.DBQueryDuration.WithLabelValues("resolveItemsFunc"))
+			klog.V(5).Infof("Query issued by resolver [%s] ", query)
+			rows, err := s.pool.Query(s.context, query, params...)
+
+			defer timer.ObserveDuration()
+			if err != nil {
+				klog.Errorf("Error resolving query [%s] with args [%+v]. Error: [%+v]", query, params, err)
+				return result, err
+			}
+			defer rows.Close()
+
+			// The items query for the relationships doesn't have the total count.
+			withCount := strings.Contains(query, "COUNT(*) OVER()")
+			for rows.Next() {
+				var uid string
+				var currItem map[string]interface{}
+				if withCount {
+					uid, currItem = s.scanItem(rows, &result.count)
+				} else {
+					uid, currItem = s.scanItem(rows)
+				}
+				result.items = append(result.items, currItem)
+				result.uids = append(result.uids, &uid)
+			}
+			return result, nil
+		})
 
-		if hasOrderFieldInQuery {
-			var orderValue interface{} // Scan the order column but don't use it
-			err = rows.Scan(&uid, &cluster, &data, &orderValue)
-		} else {
-			err = rows.Scan(&uid, &cluster, &data)
-		}
+	// Copy the uids, the relationships query modifies them.
+	s.uids = append([]*string{}, result.uids...)
+	s.count = result.count
+	if result.items == nil {
+		result.items = []map[string]interface{}{}
+	}
+	return result.items, err
+}
 
-		if err != nil {
-			klog.Errorf("Error %s retrieving rows for query:%s", err.Error(), s.query)
-		}
-		currItem := formatDataMap(data)
-		currItem["_uid"] = uid
-		currItem["cluster"] = cluster
+// Scans a row from the items query and formats the data. The extra destinations are for the
+// columns after the data, e.g. the total count.
+func (s *SearchResult) scanItem(rows pgx.Rows, extra ...interface{}) (string, map[string]interface{}) {
+	var uid string
+	var cluster string
+	var data map[string]interface{}
+	var err error
 
-		items = append(items, currItem)
-		s.uids = append(s.uids, &uid)
+	// Check if the query actually contains the order field in SELECT by looking at the query string
+	// The order field is only added for SELECT DISTINCT queries (main items),
+	// NOT for related items queries which use regular SELECT
+	hasOrderFieldInQuery := s.input.OrderBy != nil && *s.input.OrderBy != "" &&
+		strings.Contains(s.query, "data->>'")
 
+	dest := []interface{}{&uid, &cluster, &data}
+	if hasOrderFieldInQuery {
+		var orderValue interface{} // Scan the order column but don't use it
+		dest = append(dest, &orderValue)
 	}
+	err = rows.Scan(append(dest, extra...)...)
 
-	return items, nil
+	if err != nil {
+		klog.Errorf("Error %s retrieving rows for query:%s", err.Error(), s.query)
+	}
+	currItem := formatDataMap(data)
+	currItem["_uid"] = uid
+	currItem["cluster"] = cluster
+	return uid, currItem
 }
 
+// WhereClauseFilter returns the WHERE clause for the keywords and filters of the input. The property type cache
+// is refreshed when a filter uses a property not in it. See searchFilter.
 func WhereClauseFilter(ctx context.Context, input *model.SearchInput,
 	propTypeMap map[string]string) ([]exp.Expression, map[string]string, error) {
-
-	var whereDs []exp.Expression
-	var err error
-
-	if len(input.Keywords) > 0 {
-		// Sample query: SELECT COUNT("uid") FROM "search"."resources", jsonb_each_text("data")
-		// WHERE (("value" LIKE '%dns%') AND ("data"->>'kind' ILIKE ANY ('{"pod","deployment"}')))
-		keywords := PointerToStringArray(input.Keywords)
-		for _, key := range keywords {
-			key = "%" + key + "%"
-			whereDs = append(whereDs, goqu.L(`"value"`).ILike(key).Expression())
-		}
-	}
-
-	if input.Filters != nil {
-		for _, filter := range input.Filters {
-			opValueMap := map[string][]string{}
-			if len(filter.Values) == 0 {
-				klog.Warningf("Ignoring filter [%s] because it has no values", filter.Property)
-				continue
-			}
-			values := PointerToStringArray(filter.Values)
-
-			dataType, dataTypeInMap := propTypeMap[filter.Property]
-			if len(propTypeMap) == 0 || !dataTypeInMap {
-				klog.V(3).Infof("Property type for [%s] doesn't exist in cache. Refreshing property type cache",
-					filter.Property)
-				propTypeMapNew, err := getPropertyType(ctx, true) // Refresh the property type cache.
-				propTypeMap = propTypeMapNew
-				dataType, dataTypeInMap = propTypeMap[filter.Property]
-				klog.V(3).Infof("For filter prop: %s, datatype is :%s dataTypeInMap: %t\n", filter.Property,
-					dataType, dataTypeInMap)
-				if err != nil {
-					klog.Errorf("Error creating property type map with err: [%s]", err)
-					return whereDs, propTypeMap, fmt.Errorf("error [%s] fetching data type for property: [%s]",
-						err, filter.Property)
-				}
-				if !dataTypeInMap {
-					klog.V(1).Infof("Input property type [%s] doesn't exist, setting false condition to return 0 results", filter.Property)
-					// search=> explain analyze select * from search.resources where 1 = 0;
-					//                                     QUERY PLAN
-					//------------------------------------------------------------------------------------
-					// Result  (cost=0.00..0.00 rows=0 width=0) (actual time=0.001..0.001 rows=0 loops=1)
-					//   One-Time Filter: false
-					// Planning Time: 0.060 ms
-					// Execution Time: 0.008 ms
-					// (4 rows)
-					whereDs = append(whereDs, goqu.L("1 = 0").Expression())
-					return whereDs, propTypeMap, err
-				}
-			}
-
-			klog.V(5).Infof("For filter prop: %s, datatype is :%s\n", filter.Property, dataType)
-
-			// if property matches then call decode function:
-			values, err = decodePropertyTypes(values, dataType)
+	filter, err := newSearchFilter(input, func(property string) (string, bool, error) {
+		dataType, dataTypeInMap := propTypeMap[property]
+		if len(propTypeMap) == 0 || !dataTypeInMap {
+			klog.V(3).Infof("Property type for [%s] doesn't exist in cache. Refreshing property type cache",
+				property)
+			propTypeMapNew, err := getPropertyType(ctx, true) // Refresh the property type cache.
+			propTypeMap = propTypeMapNew
+			dataType, dataTypeInMap = propTypeMap[property]
+			klog.V(3).Infof("For filter prop: %s, datatype is :%s dataTypeInMap: %t\n", property,
+				dataType, dataTypeInMap)
 			if err != nil {
-				return whereDs, propTypeMap, err
+				klog.Errorf("Error creating property type map with err: [%s]", err)
+				return "", false, fmt.Errorf("error [%s] fetching data type for property: [%s]", err, property)
 			}
-			opValueMap = matchOperatorToProperty(dataType, opValueMap, values, filter.Property)
-
-			//Sort map according to keys - This is for the ease/stability of tests when there are multiple operators
-			keys := getKeys(opValueMap)
-			var operatorWhereDs []exp.Expression //store all the clauses for this filter together
-			for _, operator := range keys {
-				operatorWhereDs = append(operatorWhereDs,
-					getWhereClauseExpression(filter.Property, operator, opValueMap[operator], propTypeMap[filter.Property])...)
+			if !dataTypeInMap {
+				klog.V(1).Infof("Input property type [%s] doesn't exist, setting false condition to return 0 results",
+					property)
 			}
-			whereDs = append(whereDs, goqu.Or(operatorWhereDs...)) //Join all the clauses with OR
 		}
-	}
-
-	return whereDs, propTypeMap, err
+		return dataType, dataTypeInMap, nil
+	})
+	return filter.sql(), propTypeMap, err
 }
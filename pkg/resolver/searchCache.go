@@ -0,0 +1,318 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+	"github.com/stolostron/search-v2-api/pkg/database"
+	"github.com/stolostron/search-v2-api/pkg/metrics"
+	klog "k8s.io/klog/v2"
+)
+
+const searchCacheHandlerID = "search-cache"
+
+// Caches the items and count of search results. Results are keyed by the normalized input and a hash of
+// the user's RBAC data, so users with the same permissions share the results.
+// Results are removed when the database listener receives a change for the kinds and clusters in the
+// input filters, and when the listener loses the connection, because the events could be missed.
+type searchCache struct {
+	mutex     sync.Mutex
+	entries   map[string]*list.Element
+	lru       *list.List // Most recently used at the front.
+	listening bool       // The cache is registered with the database listener.
+	// Sequence of the last event for each kind and cluster. Used to discard results queried before an event.
+	clusterSeq     map[string]uint64
+	kindSeq        map[string]uint64
+	resetSeq       uint64 // Sequence of the last reset.
+	seq            uint64 // Incremented with each event and reset.
+	unknownKindSeq uint64 // Sequence of the last event without the kind.
+}
+
+type searchCacheEntry struct {
+	clusters map[string]struct{} // Clusters in the input filters. Empty matches any cluster.
+	count    int
+	expires  time.Time
+	items    []map[string]interface{}
+	key      string
+	kinds    map[string]struct{} // Kinds (lowercase) in the input filters. Empty matches any kind.
+	uids     []*string
+}
+
+var resultCache = newSearchCache()
+
+func newSearchCache() *searchCache {
+	return &searchCache{
+		entries:    map[string]*list.Element{},
+		lru:        list.New(),
+		clusterSeq: map[string]uint64{},
+		kindSeq:    map[string]uint64{},
+	}
+}
+
+// Returns the cached result, or nil if not found or expired.
+func (c *searchCache) get(key string) *searchCacheEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, found := c.entries[key]
+	if !found || time.Now().After(element.Value.(*searchCacheEntry).expires) {
+		metrics.SearchCacheRequests.WithLabelValues("miss").Inc()
+		return nil
+	}
+	metrics.SearchCacheRequests.WithLabelValues("hit").Inc()
+	c.lru.MoveToFront(element)
+	return element.Value.(*searchCacheEntry)
+}
+
+// Registers the cache with the database listener if needed, and returns the sequence to pass to set()
+// after the query completes. Returns false if results can't be cached because the listener isn't running.
+func (c *searchCache) begin() (uint64, bool) {
+	c.mutex.Lock()
+	listening, seq := c.listening, c.seq
+	c.mutex.Unlock()
+	if listening {
+		return seq, true
+	}
+
+	// Don't hold the cache mutex while registering, the listener calls Reset() with its own mutex locked.
+	if err := database.RegisterEventHandler(searchCacheHandlerID, c); err != nil {
+		klog.V(3).Infof("Search results won't be cached. %s", err)
+		return 0, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.seq != seq { // Reset while registering.
+		return 0, false
+	}
+	c.listening = true
+	return c.seq, true
+}
+
+// Adds the result, unless an event for its kinds and clusters was received after seq.
+func (c *searchCache) set(entry *searchCacheEntry, seq uint64) {
+	if len(entry.items) > config.Cfg.SearchCache.MaxItems {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.listening || c.changedSince(entry, seq) {
+		return
+	}
+
+	entry.expires = time.Now().Add(time.Duration(config.Cfg.SearchCache.TTL) * time.Millisecond)
+	if element, found := c.entries[entry.key]; found {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+	} else {
+		c.entries[entry.key] = c.lru.PushFront(entry)
+	}
+	for c.lru.Len() > config.Cfg.SearchCache.MaxEntries {
+		c.remove(c.lru.Back())
+	}
+	metrics.SearchCacheEntries.Set(float64(c.lru.Len()))
+}
+
+// Returns true if an event received after seq could change the entry. It may return true when the events
+// were for a different kind and cluster combination, in which case the result isn't cached.
+func (c *searchCache) changedSince(entry *searchCacheEntry, seq uint64) bool {
+	if c.seq == seq {
+		return false
+	}
+	if seq < c.resetSeq { // Events may have been missed.
+		return true
+	}
+	kindChanged := len(entry.kinds) == 0 || c.unknownKindSeq > seq
+	for kind := range entry.kinds {
+		kindChanged = kindChanged || c.kindSeq[kind] > seq
+	}
+	clusterChanged := len(entry.clusters) == 0
+	for cluster := range entry.clusters {
+		clusterChanged = clusterChanged || c.clusterSeq[cluster] > seq
+	}
+	return kindChanged && clusterChanged
+}
+
+// Must be called with the mutex locked.
+func (c *searchCache) remove(element *list.Element) {
+	c.lru.Remove(element)
+	delete(c.entries, element.Value.(*searchCacheEntry).key)
+}
+
+// HandleEvent removes the results that could include the changed resource.
+func (c *searchCache) HandleEvent(event *model.Event) {
+	cluster, _, _ := strings.Cut(event.UID, "/") // The uid has the format cluster/uid
+	cluster = strings.ToLower(cluster)
+	kinds := map[string]struct{}{}
+	for _, data := range []map[string]any{event.NewData, event.OldData} {
+		if kind, ok := data["kind"].(string); ok {
+			kinds[strings.ToLower(kind)] = struct{}{}
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.seq++
+	c.clusterSeq[cluster] = c.seq
+	if len(kinds) == 0 {
+		c.unknownKindSeq = c.seq
+	}
+	for kind := range kinds {
+		c.kindSeq[kind] = c.seq
+	}
+
+	removed := 0
+	for element := c.lru.Front(); element != nil; {
+		next := element.Next()
+		entry := element.Value.(*searchCacheEntry)
+		if entry.matches(cluster, kinds) {
+			c.remove(element)
+			removed++
+		}
+		element = next
+	}
+	if removed > 0 {
+		klog.V(5).Infof("Removed %d search cache results for change to %s.", removed, event.UID)
+		metrics.SearchCacheInvalidations.Add(float64(removed))
+		metrics.SearchCacheEntries.Set(float64(c.lru.Len()))
+	}
+}
+
+// Reset removes all results. Called by the listener when events may have been missed.
+func (c *searchCache) Reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	klog.V(3).Info("Resetting the search results cache.")
+	c.entries = map[string]*list.Element{}
+	c.lru.Init()
+	c.listening = false
+	c.clusterSeq = map[string]uint64{}
+	c.kindSeq = map[string]uint64{}
+	c.seq++
+	c.resetSeq = c.seq
+	metrics.SearchCacheEntries.Set(0)
+}
+
+// Returns true if a change to a resource of the kinds (or unknown kind) in the cluster could change the entry.
+func (e *searchCacheEntry) matches(cluster string, kinds map[string]struct{}) bool {
+	if _, found := e.clusters[cluster]; len(e.clusters) > 0 && !found {
+		return false
+	}
+	if len(e.kinds) == 0 || len(kinds) == 0 {
+		return true
+	}
+	for kind := range kinds {
+		if _, found := e.kinds[kind]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the cached result for the field (items, count or itemsWithCount) of this search.
+func (s *SearchResult) cacheGet(field string) *searchCacheEntry {
+	key := s.searchCacheKey(field)
+	if key == "" {
+		return nil
+	}
+	return resultCache.get(key)
+}
+
+// Starts caching the result for the field. Call before running the query, and call the returned function
+// with the result when the query completes.
+func (s *SearchResult) cacheBegin(field string) func(count int, items []map[string]interface{}) {
+	key := s.searchCacheKey(field)
+	if key == "" {
+		return func(int, []map[string]interface{}) {}
+	}
+	seq, ok := resultCache.begin()
+	if !ok {
+		return func(int, []map[string]interface{}) {}
+	}
+	return func(count int, items []map[string]interface{}) {
+		entry := &searchCacheEntry{count: count, items: items, key: key, uids: append([]*string{}, s.uids...)}
+		entry.kinds, entry.clusters = filterValues(s.input, "kind"), filterValues(s.input, "cluster")
+		resultCache.set(entry, seq)
+	}
+}
+
+// Returns the key for the field, or an empty string if the result can't be cached.
+// The key is the normalized input and a hash of the user's RBAC data.
+func (s *SearchResult) searchCacheKey(field string) string {
+	if !config.Cfg.Features.SearchCache || s.input == nil || len(s.extraWhere) > 0 {
+		return ""
+	}
+	if s.cacheKey == "" {
+		s.cacheKey = newSearchCacheKey(s)
+	}
+	if s.cacheKey == "" {
+		return ""
+	}
+	return field + ":" + s.cacheKey
+}
+
+func newSearchCacheKey(s *SearchResult) string {
+	userData, err := json.Marshal(s.userData)
+	if err != nil {
+		klog.Warningf("Unable to build search cache key. %s", err)
+		return ""
+	}
+	permissions := sha256.Sum256(userData)
+
+	type filter struct {
+		Property string   `json:"property"`
+		Values   []string `json:"values"`
+	}
+	filters := make([]filter, 0, len(s.input.Filters))
+	for _, f := range s.input.Filters {
+		if f == nil {
+			continue
+		}
+		values := nonEmptyValues(f.Values)
+		sort.Strings(values)
+		filters = append(filters, filter{Property: f.Property, Values: values})
+	}
+	sort.Slice(filters, func(i, j int) bool { return filters[i].Property < filters[j].Property })
+	keywords := nonEmptyValues(s.input.Keywords)
+	sort.Strings(keywords)
+
+	key, err := json.Marshal(struct {
+		Filters     []filter `json:"filters"`
+		Keywords    []string `json:"keywords"`
+		Limit       *int     `json:"limit"`
+		Offset      *int     `json:"offset"`
+		OrderBy     *string  `json:"orderBy"`
+		Permissions string   `json:"permissions"`
+	}{filters, keywords, s.input.Limit, s.input.Offset, s.input.OrderBy, hex.EncodeToString(permissions[:])})
+	if err != nil {
+		klog.Warningf("Unable to build search cache key. %s", err)
+		return ""
+	}
+	return string(key)
+}
+
+// Returns the values (lowercase) of the property filter, or nil if the filter can match any value
+// because it uses an operator or wildcard.
+func filterValues(input *model.SearchInput, property string) map[string]struct{} {
+	for _, f := range input.Filters {
+		if f == nil || f.Property != property {
+			continue
+		}
+		values := map[string]struct{}{}
+		for _, value := range nonEmptyValues(f.Values) {
+			if strings.ContainsAny(value, "!=<>*") {
+				return nil
+			}
+			values[strings.ToLower(value)] = struct{}{}
+		}
+		return values
+	}
+	return nil
+}
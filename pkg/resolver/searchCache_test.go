@@ -0,0 +1,172 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/golang/mock/gomock"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+// Enables the cache feature with an empty cache registered with the listener.
+func setupTestSearchCache(t *testing.T) *searchCache {
+	feature, cache := config.Cfg.Features.SearchCache, resultCache
+	t.Cleanup(func() {
+		config.Cfg.Features.SearchCache = feature
+		resultCache = cache
+	})
+	config.Cfg.Features.SearchCache = true
+	resultCache = newSearchCache()
+	resultCache.listening = true
+	return resultCache
+}
+
+func newTestCacheEntry(key string, kinds []string, clusters []string) *searchCacheEntry {
+	entry := &searchCacheEntry{key: key, kinds: map[string]struct{}{}, clusters: map[string]struct{}{}}
+	for _, kind := range kinds {
+		entry.kinds[kind] = struct{}{}
+	}
+	for _, cluster := range clusters {
+		entry.clusters[cluster] = struct{}{}
+	}
+	return entry
+}
+
+func Test_searchCacheKey_Normalized(t *testing.T) {
+	setupTestSearchCache(t)
+	pod, deployment, cluster := "Pod", "Deployment", "local-cluster"
+	s1 := &SearchResult{input: &model.SearchInput{Filters: []*model.SearchFilter{
+		{Property: "kind", Values: []*string{&pod, &deployment}}, {Property: "cluster", Values: []*string{&cluster}}}}}
+	s2 := &SearchResult{input: &model.SearchInput{Filters: []*model.SearchFilter{
+		{Property: "cluster", Values: []*string{&cluster}}, {Property: "kind", Values: []*string{&deployment, &pod}}}}}
+
+	assert.NotEqual(t, "", s1.searchCacheKey("items"))
+	assert.Equal(t, s1.searchCacheKey("items"), s2.searchCacheKey("items"),
+		"Expected the same key when the filters and values are in a different order.")
+	assert.NotEqual(t, s1.searchCacheKey("items"), s1.searchCacheKey("count"))
+
+	s3 := &SearchResult{input: s1.input, userData: rbac.UserData{IsClusterAdmin: true}}
+	assert.NotEqual(t, s1.searchCacheKey("items"), s3.searchCacheKey("items"),
+		"Expected a different key for users with different permissions.")
+
+	s4 := &SearchResult{input: s1.input, extraWhere: []exp.Expression{goqu.C("uid").Eq("1")}}
+	assert.Equal(t, "", s4.searchCacheKey("items"), "Results with extra conditions can't be cached.")
+}
+
+func Test_searchCache_SetGet(t *testing.T) {
+	cache := setupTestSearchCache(t)
+	maxEntries := config.Cfg.SearchCache.MaxEntries
+	defer func() { config.Cfg.SearchCache.MaxEntries = maxEntries }()
+	config.Cfg.SearchCache.MaxEntries = 2
+
+	cache.set(newTestCacheEntry("a", nil, nil), cache.seq)
+	cache.set(newTestCacheEntry("b", nil, nil), cache.seq)
+	assert.NotNil(t, cache.get("a")) // "a" is now the most recently used.
+	cache.set(newTestCacheEntry("c", nil, nil), cache.seq)
+
+	assert.NotNil(t, cache.get("a"))
+	assert.Nil(t, cache.get("b"), "Expected the least recently used entry to be removed.")
+	assert.NotNil(t, cache.get("c"))
+
+	cache.entries["a"].Value.(*searchCacheEntry).expires = time.Now().Add(-time.Second)
+	assert.Nil(t, cache.get("a"), "Expected expired entries to be ignored.")
+}
+
+func Test_searchCache_MaxItems(t *testing.T) {
+	cache := setupTestSearchCache(t)
+	maxItems := config.Cfg.SearchCache.MaxItems
+	defer func() { config.Cfg.SearchCache.MaxItems = maxItems }()
+	config.Cfg.SearchCache.MaxItems = 1
+
+	entry := newTestCacheEntry("a", nil, nil)
+	entry.items = []map[string]interface{}{{"name": "a"}, {"name": "b"}}
+	cache.set(entry, cache.seq)
+
+	assert.Nil(t, cache.get("a"), "Expected results with more than the max items to not be cached.")
+}
+
+func Test_searchCache_HandleEvent(t *testing.T) {
+	cache := setupTestSearchCache(t)
+	cache.set(newTestCacheEntry("pods", []string{"pod"}, nil), cache.seq)
+	cache.set(newTestCacheEntry("managed-pods", []string{"pod"}, []string{"managed1"}), cache.seq)
+	cache.set(newTestCacheEntry("all", nil, nil), cache.seq)
+
+	cache.HandleEvent(&model.Event{UID: "local-cluster/123", Operation: "UPDATE",
+		NewData: map[string]any{"kind": "Deployment"}})
+	assert.NotNil(t, cache.get("pods"), "A deployment change shouldn't remove the pods.")
+	assert.NotNil(t, cache.get("managed-pods"))
+	assert.Nil(t, cache.get("all"), "Results for any kind should be removed.")
+
+	cache.HandleEvent(&model.Event{UID: "local-cluster/456", Operation: "INSERT", NewData: map[string]any{"kind": "Pod"}})
+	assert.Nil(t, cache.get("pods"))
+	assert.NotNil(t, cache.get("managed-pods"), "A change on another cluster shouldn't remove the results.")
+
+	cache.HandleEvent(&model.Event{UID: "managed1/789", Operation: "DELETE"}) // Unknown kind.
+	assert.Nil(t, cache.get("managed-pods"))
+}
+
+func Test_searchCache_ChangedWhileQuerying(t *testing.T) {
+	cache := setupTestSearchCache(t)
+	seq := cache.seq
+	cache.HandleEvent(&model.Event{UID: "local-cluster/123", NewData: map[string]any{"kind": "Pod"}})
+
+	cache.set(newTestCacheEntry("pods", []string{"pod"}, nil), seq)
+	cache.set(newTestCacheEntry("deployments", []string{"deployment"}, nil), seq)
+
+	assert.Nil(t, cache.get("pods"), "Expected results queried before a change to not be cached.")
+	assert.NotNil(t, cache.get("deployments"))
+}
+
+func Test_searchCache_Reset(t *testing.T) {
+	cache := setupTestSearchCache(t)
+	seq := cache.seq
+	cache.set(newTestCacheEntry("pods", []string{"pod"}, nil), seq)
+
+	cache.Reset()
+
+	assert.Nil(t, cache.get("pods"))
+	assert.False(t, cache.listening)
+	cache.listening = true // Registered again.
+	cache.set(newTestCacheEntry("pods", []string{"pod"}, nil), seq)
+	assert.Nil(t, cache.get("pods"), "Expected results queried before the reset to not be cached.")
+}
+
+func Test_SearchResolver_CountCached(t *testing.T) {
+	setupTestSearchCache(t)
+	val1 := "Pod"
+	searchInput := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&val1}}}}
+	resolver, mockPool := newMockSearchResolver(t, searchInput, nil, rbac.UserData{CsResources: []rbac.Resource{}},
+		map[string]string{"kind": "string"})
+	mockPool.EXPECT().QueryRow(gomock.Any(),
+		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR FALSE))`),
+		gomock.Eq([]interface{}{"kind", "Pod", "{}"})).Return(&Row{MockValue: 10}).Times(1)
+
+	count, err := resolver.Count()
+	assert.Nil(t, err)
+	assert.Equal(t, 10, count)
+
+	// Another request with the same input and permissions uses the cached result.
+	cached, _ := newMockSearchResolver(t, searchInput, nil, rbac.UserData{CsResources: []rbac.Resource{}},
+		map[string]string{"kind": "string"})
+	count, err = cached.Count()
+	assert.Nil(t, err)
+	assert.Equal(t, 10, count)
+}
+
+func Test_filterValues(t *testing.T) {
+	pod, notPod, wildcard := "Pod", "!Pod", "Deploy*"
+	input := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&pod}}}}
+	assert.Equal(t, map[string]struct{}{"pod": {}}, filterValues(input, "kind"))
+	assert.Nil(t, filterValues(input, "cluster"), "Expected nil when there isn't a filter for the property.")
+
+	input.Filters[0].Values = []*string{&notPod}
+	assert.Nil(t, filterValues(input, "kind"), "Expected nil when the filter uses an operator.")
+	input.Filters[0].Values = []*string{&wildcard}
+	assert.Nil(t, filterValues(input, "kind"), "Expected nil when the filter uses a wildcard.")
+}
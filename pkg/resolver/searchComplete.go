@@ -11,6 +11,7 @@ import (
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/lib/pq"
 	"github.com/stolostron/search-v2-api/graph/model"
 	"github.com/stolostron/search-v2-api/pkg/config"
 	db "github.com/stolostron/search-v2-api/pkg/database"
@@ -82,14 +83,14 @@ func (s *SearchCompleteResult) searchCompleteQuery(ctx context.Context) {
 	var selectDs *goqu.SelectDataset
 
 	schemaTable := goqu.S("search").Table("resources")
-	ds := goqu.From(schemaTable)
+	ds := dialect.From(schemaTable)
 	if s.property != "" {
 
 		// WHERE CLAUSE
 		if s.input != nil && len(s.input.Filters) > 0 {
 			if len(s.input.Keywords) > 0 {
 				jsb := goqu.L("jsonb_each_text(?)", goqu.C("data"))
-				ds = goqu.From(schemaTable, jsb)
+				ds = dialect.From(schemaTable, jsb)
 			}
 			whereDs, s.propTypes, _ = WhereClauseFilter(ctx, s.input, s.propTypes)
 		}
@@ -104,9 +105,11 @@ func (s *SearchCompleteResult) searchCompleteQuery(ctx context.Context) {
 		default:
 			// "->" - get data as json object
 			// "->>" - get data as string
-			selectDs = ds.SelectDistinct(goqu.L(`"data"->?`, s.property)).Order(goqu.L(`"data"->?`, s.property).Asc())
+			// The property is inlined because SELECT DISTINCT requires the same expression in the ORDER BY.
+			propExp := goqu.L(`"data"->` + pq.QuoteLiteral(s.property))
+			selectDs = ds.SelectDistinct(propExp).Order(propExp.Asc())
 			//Adding notNull clause to filter out NULL values and ORDER by sort results
-			whereDs = append(whereDs, goqu.L(`"data"->?`, s.property).IsNotNull())
+			whereDs = append(whereDs, propExp.IsNotNull())
 		}
 
 		// get user info for logging
@@ -116,7 +119,7 @@ func (s *SearchCompleteResult) searchCompleteQuery(ctx context.Context) {
 		// if one of them is not nil, userData is not empty
 		if s.userData.CsResources != nil || s.userData.NsResources != nil || s.userData.ManagedClusters != nil {
 			whereDs = append(whereDs,
-				buildRbacWhereClause(ctx, s.userData, userInfo)) // add rbac
+				rbacWhereClause(ctx, s.userData, userInfo)) // add rbac
 		} else {
 			klog.Errorf("Error building searchComplete query: RBAC clause is required!"+
 				" None found for searchComplete query %+v for user %s with uid %s ",
@@ -142,9 +145,9 @@ func (s *SearchCompleteResult) searchCompleteQuery(ctx context.Context) {
 
 		// Get the query
 		if limit > 0 {
-			sql, params, err = selectDs.Where(whereDs...).Limit(limit).ToSQL()
+			sql, params, err = selectDs.Where(whereDs...).Limit(limit).Prepared(true).ToSQL()
 		} else {
-			sql, params, err = selectDs.Where(whereDs...).ToSQL()
+			sql, params, err = selectDs.Where(whereDs...).Prepared(true).ToSQL()
 		}
 
 		if err != nil {
@@ -27,8 +27,8 @@ func Test_SearchComplete_Query(t *testing.T) {
 	// Mock the database query
 	// SELECT DISTINCT "prop" FROM (SELECT "data"->>'kind' AS "prop" FROM "search"."resources" WHERE ("data"->>'kind' IS NOT NULL) LIMIT 100000) AS "searchComplete" ORDER BY prop ASC LIMIT 1000
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "data"->'kind' FROM "search"."resources" WHERE (("data"->'kind' IS NOT NULL) AND (("cluster" = ANY ('{}')) OR FALSE)) ORDER BY "data"->'kind' ASC LIMIT 1000`),
-		gomock.Eq([]interface{}{})).Return(mockRows, nil)
+		gomock.Eq(`SELECT DISTINCT "data"->'kind' FROM "search"."resources" WHERE (("data"->'kind' IS NOT NULL) AND (("cluster" = ANY ($1)) OR FALSE)) ORDER BY "data"->'kind' ASC LIMIT $2`),
+		gomock.Eq([]interface{}{"{}", int64(1000)})).Return(mockRows, nil)
 
 	// Execute function
 	result, err := resolver.autoComplete(context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456"))
@@ -73,8 +73,8 @@ func Test_SearchComplete_Query_WithLimit(t *testing.T) {
 	mockRows := newMockRowsWithoutRBAC("../resolver/mocks/mock.json", searchInput, prop1, limit)
 	// Mock the database query
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "data"->'kind' FROM "search"."resources" WHERE (("data"->'kind' IS NOT NULL) AND (("cluster" = ANY ('{}')) OR FALSE)) ORDER BY "data"->'kind' ASC LIMIT 2`),
-		gomock.Eq([]interface{}{})).Return(mockRows, nil)
+		gomock.Eq(`SELECT DISTINCT "data"->'kind' FROM "search"."resources" WHERE (("data"->'kind' IS NOT NULL) AND (("cluster" = ANY ($1)) OR FALSE)) ORDER BY "data"->'kind' ASC LIMIT $2`),
+		gomock.Eq([]interface{}{"{}", int64(2)})).Return(mockRows, nil)
 
 	// Execute function
 	result, err := resolver.autoComplete(context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456"))
@@ -101,8 +101,8 @@ func Test_SearchComplete_Query_WithNegativeLimit(t *testing.T) {
 	mockRows := newMockRowsWithoutRBAC("../resolver/mocks/mock.json", searchInput, prop1, limit)
 	// Mock the database query
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "data"->'kind' FROM "search"."resources" WHERE (("data"->'kind' IS NOT NULL) AND (("cluster" = ANY ('{}')) OR FALSE)) ORDER BY "data"->'kind' ASC`),
-		gomock.Eq([]interface{}{})).Return(mockRows, nil)
+		gomock.Eq(`SELECT DISTINCT "data"->'kind' FROM "search"."resources" WHERE (("data"->'kind' IS NOT NULL) AND (("cluster" = ANY ($1)) OR FALSE)) ORDER BY "data"->'kind' ASC`),
+		gomock.Eq([]interface{}{"{}"})).Return(mockRows, nil)
 
 	// Execute function
 	result, err := resolver.autoComplete(context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456"))
@@ -158,8 +158,8 @@ func Test_SearchCompleteWithFilter_Query(t *testing.T) {
 	// Mock the database query
 	// check if cluster
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "data"->'kind' FROM "search"."resources" WHERE ("data"->'namespace'?|'{"openshift","openshift-monitoring"}' AND ("cluster" IN ('local-cluster')) AND ("data"->'kind' IS NOT NULL) AND (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))) ORDER BY "data"->'kind' ASC LIMIT 10`),
-		gomock.Eq([]interface{}{})).Return(mockRows, nil)
+		gomock.Eq(`SELECT DISTINCT "data"->'kind' FROM "search"."resources" WHERE ("data"->$1?|$2 AND ("cluster" IN ($3)) AND ("data"->'kind' IS NOT NULL) AND (("cluster" = ANY ($4)) OR ("data"?$5 AND ((NOT("data"?$6) AND ((NOT("data"?$7) AND data->$8?$9) OR (data->$10?$11 AND data->$12?$13))) OR ((data->$14?|$15 AND ((NOT("data"?$16) AND data->$17?$18) OR (data->$19?$20 AND data->$21?$22))) OR (data->$23?|$24 AND ((data->$25?$26 AND data->$27?$28) OR (data->$29?$30 AND data->$31?$32)))))))) ORDER BY "data"->'kind' ASC LIMIT $33`),
+		gomock.Eq([]interface{}{"namespace", "{\"openshift\",\"openshift-monitoring\"}", "local-cluster", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(10)})).Return(mockRows, nil)
 
 	// Execute function
 	result, _ := resolver.autoComplete(context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456"))
@@ -185,8 +185,8 @@ func Test_SearchCompleteWithCluster(t *testing.T) {
 	// Mock the database query
 	// SELECT DISTINCT "prop" FROM (SELECT DISTINCT "cluster" AS "prop" FROM "search"."resources" WHERE (("cluster" IS NOT NULL) AND ("cluster" != '')) LIMIT 100000) AS "searchComplete" ORDER BY prop ASC LIMIT 10
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "cluster" FROM "search"."resources" WHERE (("cluster" IS NOT NULL) AND ("cluster" != '') AND (("cluster" = ANY ('{}')) OR FALSE)) ORDER BY "cluster" ASC LIMIT 10`),
-		gomock.Eq([]interface{}{})).Return(mockRows, nil)
+		gomock.Eq(`SELECT DISTINCT "cluster" FROM "search"."resources" WHERE (("cluster" IS NOT NULL) AND ("cluster" != $1) AND (("cluster" = ANY ($2)) OR FALSE)) ORDER BY "cluster" ASC LIMIT $3`),
+		gomock.Eq([]interface{}{"", "{}", int64(10)})).Return(mockRows, nil)
 
 	// Execute function
 	result, _ := resolver.autoComplete(context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456"))
@@ -207,8 +207,8 @@ func Test_SearchCompleteQuery_PropDate(t *testing.T) {
 	mockRows := newMockRowsWithoutRBAC("../resolver/mocks/mock.json", searchInput, prop1, 0)
 	// Mock the database query
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "data"->'created' FROM "search"."resources" WHERE (("data"->'created' IS NOT NULL) AND (("cluster" = ANY ('{}')) OR FALSE)) ORDER BY "data"->'created' ASC LIMIT 1000`),
-		gomock.Eq([]interface{}{})).Return(mockRows, nil)
+		gomock.Eq(`SELECT DISTINCT "data"->'created' FROM "search"."resources" WHERE (("data"->'created' IS NOT NULL) AND (("cluster" = ANY ($1)) OR FALSE)) ORDER BY "data"->'created' ASC LIMIT $2`),
+		gomock.Eq([]interface{}{"{}", int64(1000)})).Return(mockRows, nil)
 
 	// Execute function
 	result, err := resolver.autoComplete(context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456"))
@@ -236,8 +236,8 @@ func Test_SearchCompleteQuery_PropNum(t *testing.T) {
 	// SELECT DISTINCT "prop" FROM (SELECT "data"->>'current' AS "prop" FROM "search"."resources" WHERE ("data"->>'current' IS NOT NULL) LIMIT 100000) AS "searchComplete" ORDER BY prop ASC LIMIT 1000
 
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "data"->'current' FROM "search"."resources" WHERE (("data"->'current' IS NOT NULL) AND (("cluster" = ANY ('{"managed1","managed2"}')) OR FALSE)) ORDER BY "data"->'current' ASC LIMIT 1000`),
-		gomock.Eq([]interface{}{})).Return(mockRows, nil)
+		gomock.Eq(`SELECT DISTINCT "data"->'current' FROM "search"."resources" WHERE (("data"->'current' IS NOT NULL) AND (("cluster" = ANY ($1)) OR FALSE)) ORDER BY "data"->'current' ASC LIMIT $2`),
+		gomock.Eq([]interface{}{"{\"managed1\",\"managed2\"}", int64(1000)})).Return(mockRows, nil)
 
 	// Execute function
 	result, err := resolver.autoComplete(context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456"))
@@ -276,8 +276,8 @@ func Test_SearchCompleteWithObject_Query(t *testing.T) {
 
 	// Mock the database query
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "data"->'label' FROM "search"."resources" WHERE ("data"->'namespace'?|'{"openshift","openshift-monitoring"}' AND ("cluster" IN ('local-cluster')) AND ("data"->'label' IS NOT NULL) AND (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))) ORDER BY "data"->'label' ASC LIMIT 1000`),
-		gomock.Eq([]interface{}{})).Return(mockRows, nil)
+		gomock.Eq(`SELECT DISTINCT "data"->'label' FROM "search"."resources" WHERE ("data"->$1?|$2 AND ("cluster" IN ($3)) AND ("data"->'label' IS NOT NULL) AND (("cluster" = ANY ($4)) OR ("data"?$5 AND ((NOT("data"?$6) AND ((NOT("data"?$7) AND data->$8?$9) OR (data->$10?$11 AND data->$12?$13))) OR ((data->$14?|$15 AND ((NOT("data"?$16) AND data->$17?$18) OR (data->$19?$20 AND data->$21?$22))) OR (data->$23?|$24 AND ((data->$25?$26 AND data->$27?$28) OR (data->$29?$30 AND data->$31?$32)))))))) ORDER BY "data"->'label' ASC LIMIT $33`),
+		gomock.Eq([]interface{}{"namespace", "{\"openshift\",\"openshift-monitoring\"}", "local-cluster", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(1000)})).Return(mockRows, nil)
 	// Execute function
 	result, err := resolver.autoComplete(context.TODO())
 	if err != nil {
@@ -315,8 +315,8 @@ func Test_SearchCompleteWithContainer_Query(t *testing.T) {
 	expectedProps := []*string{&val1, &val2, &val3}
 	// Mock the database query
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "data"->'container' FROM "search"."resources" WHERE ("data"->'namespace'?|'{"openshift","openshift-monitoring"}' AND ("cluster" IN ('local-cluster')) AND ("data"->'container' IS NOT NULL) AND (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))) ORDER BY "data"->'container' ASC LIMIT 1000`),
-		gomock.Eq([]interface{}{})).Return(mockRows, nil)
+		gomock.Eq(`SELECT DISTINCT "data"->'container' FROM "search"."resources" WHERE ("data"->$1?|$2 AND ("cluster" IN ($3)) AND ("data"->'container' IS NOT NULL) AND (("cluster" = ANY ($4)) OR ("data"?$5 AND ((NOT("data"?$6) AND ((NOT("data"?$7) AND data->$8?$9) OR (data->$10?$11 AND data->$12?$13))) OR ((data->$14?|$15 AND ((NOT("data"?$16) AND data->$17?$18) OR (data->$19?$20 AND data->$21?$22))) OR (data->$23?|$24 AND ((data->$25?$26 AND data->$27?$28) OR (data->$29?$30 AND data->$31?$32)))))))) ORDER BY "data"->'container' ASC LIMIT $33`),
+		gomock.Eq([]interface{}{"namespace", "{\"openshift\",\"openshift-monitoring\"}", "local-cluster", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(1000)})).Return(mockRows, nil)
 
 	// Execute function
 	result, err := resolver.autoComplete(context.TODO())
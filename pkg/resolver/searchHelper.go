@@ -15,6 +15,7 @@ import (
 	"k8s.io/utils/strings/slices"
 
 	"github.com/doug-martin/goqu/v9"
+	_ "github.com/doug-martin/goqu/v9/dialect/postgres"
 	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/lib/pq"
 	"github.com/stolostron/search-v2-api/pkg/config"
@@ -22,6 +23,10 @@ import (
 	"k8s.io/klog/v2"
 )
 
+// Builds queries with PostgreSQL placeholders ($1, $2...). Queries are built with Prepared(true), so the values
+// are passed as bind parameters and the server can reuse the plans of queries with the same shape.
+var dialect = goqu.Dialect("postgres")
+
 func getPropertyType(ctx context.Context, refresh bool) (map[string]string, error) {
 	propTypesCache, err := rbac.GetCache().GetPropertyTypes(ctx, refresh)
 	return propTypesCache, err
@@ -162,14 +167,15 @@ func getWhereClauseExpression(prop, operator string, values []string, dataType s
 			exps = append(exps, goqu.L("NOT(?)", goqu.L(`"data"->? @> ?`, prop, val)))
 		}
 	case "?|":
-		exps = append(exps, goqu.L(`"data"->? ? ?`, prop, "?|", values))
+		exps = append(exps, goqu.L(`"data"->? ? ?`, prop, goqu.Literal("?|"), pq.Array(values)))
 	default:
 		if prop == "kind" && isLower(values) {
 			//ILIKE to enable case-insensitive comparison for kind. Needed for V1 compatibility.
 			exps = append(exps, goqu.L(`"data"->>?`, prop).ILike(goqu.Any(pq.Array(values))))
 			klog.Warning("Using ILIKE for lower case KIND string comparison.",
 				"- This behavior is needed for V1 compatibility and will be deprecated with Search V2.")
-		} else if isString(values) && prop != "cluster" {
+		} else if isString(values) && prop != "cluster" && dataType != "number" && dataType != "boolean" {
+			// The jsonb "?" operators only match strings, so numbers and booleans are compared as text below.
 			if len(values) == 1 { // for single value, use "?" operator
 				// Refer to https://www.postgresql.org/docs/9.5/functions-json.html#FUNCTIONS-JSONB-OP-TABLE
 				lhsExp = goqu.L(`"data"->?`, prop)
@@ -193,7 +199,7 @@ func createSubQueryForArray(dataType, prop string, values []string) *goqu.Select
 		for _, val := range values {
 			subexps = append(subexps, goqu.L(`arrayProp`).Like(val))
 		}
-		return goqu.From(goqu.L(`jsonb_array_elements_text("data"->?) As arrayProp`, prop)).
+		return dialect.From(goqu.L(`jsonb_array_elements_text("data"->?) As arrayProp`, prop)).
 			Select(goqu.L("1")).
 			Where(goqu.Or(subexps...))
 	} else {
@@ -210,7 +216,7 @@ func createSubQueryForArray(dataType, prop string, values []string) *goqu.Select
 			}
 			subexpList = goqu.Or(subexpInnerList...)
 		}
-		return goqu.From(goqu.L(`jsonb_each_text("data"->?) As kv(key, value)`, prop)).
+		return dialect.From(goqu.L(`jsonb_each_text("data"->?) As kv(key, value)`, prop)).
 			Select(goqu.L("1")).
 			Where(goqu.Or(subexpList))
 	}
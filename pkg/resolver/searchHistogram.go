@@ -0,0 +1,248 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/stolostron/search-v2-api/graph/model"
+	db "github.com/stolostron/search-v2-api/pkg/database"
+	"github.com/stolostron/search-v2-api/pkg/metrics"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	histogramDateFormat   = "2006-01-02T15:04:05Z"
+	histogramDefaultRange = 7 * 24 * time.Hour // Last 7 days
+	histogramMaxBuckets   = 1000
+)
+
+type SearchHistogramResult struct {
+	from      time.Time
+	input     *model.SearchInput
+	interval  model.HistogramInterval
+	params    []interface{}
+	pool      pgxpoolmock.PgxPool
+	property  string
+	propTypes map[string]string
+	query     string
+	to        time.Time
+	userData  rbac.UserData
+}
+
+func SearchHistogram(ctx context.Context, srchInput *model.SearchInput, property *string,
+	interval model.HistogramInterval, from *string, to *string) ([]*model.HistogramBucket, error) {
+	defer metrics.SlowLog("SearchHistogramResolver", 0)()
+
+	prop := "created"
+	if property != nil && *property != "" {
+		prop = *property
+	}
+	fromTime, toTime, err := parseHistogramRange(interval, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	userData, userDataErr := rbac.GetCache().GetUserData(ctx)
+	if userDataErr != nil {
+		return nil, userDataErr
+	}
+
+	// Check that shared cache has property types:
+	propTypes, err := rbac.GetCache().GetPropertyTypes(ctx, false)
+	if err != nil {
+		klog.Warningf("Error creating datatype map with err: [%s] ", err)
+	}
+
+	histogramResult := &SearchHistogramResult{
+		from:      fromTime,
+		input:     srchInput,
+		interval:  interval,
+		pool:      db.GetConnPool(ctx),
+		property:  prop,
+		propTypes: propTypes,
+		to:        toTime,
+		userData:  userData,
+	}
+	if err := histogramResult.buildSearchHistogramQuery(ctx); err != nil {
+		return nil, err
+	}
+	return histogramResult.searchHistogramResults(ctx)
+}
+
+// Parse and validate the time range. Defaults to the last 7 days.
+func parseHistogramRange(interval model.HistogramInterval, from *string, to *string) (time.Time, time.Time, error) {
+	if !interval.IsValid() {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid interval: %s", interval)
+	}
+	toTime := time.Now().UTC()
+	if to != nil && *to != "" {
+		t, err := time.Parse(time.RFC3339, *to)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid value for 'to': %s. Expected RFC3339 format", *to)
+		}
+		toTime = t.UTC()
+	}
+	fromTime := toTime.Add(-histogramDefaultRange)
+	if from != nil && *from != "" {
+		t, err := time.Parse(time.RFC3339, *from)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid value for 'from': %s. Expected RFC3339 format", *from)
+		}
+		fromTime = t.UTC()
+	}
+	if !fromTime.Before(toTime) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid range. 'from' (%s) must be before 'to' (%s)",
+			fromTime.Format(histogramDateFormat), toTime.Format(histogramDateFormat))
+	}
+	if buckets := len(histogramBuckets(interval, fromTime, toTime)); buckets > histogramMaxBuckets {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid range. The range has %d buckets, the maximum is %d",
+			buckets, histogramMaxBuckets)
+	}
+	return fromTime, toTime, nil
+}
+
+// Truncates the time to the start of the bucket. Matches date_trunc() in Postgres using UTC.
+func truncateToInterval(t time.Time, interval model.HistogramInterval) time.Time {
+	t = t.UTC()
+	switch interval {
+	case model.HistogramIntervalHour:
+		return t.Truncate(time.Hour)
+	case model.HistogramIntervalDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	default: // Weeks start on Monday (ISO 8601).
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+}
+
+// Returns the start time of every bucket in the range [from, to).
+func histogramBuckets(interval model.HistogramInterval, from, to time.Time) []time.Time {
+	buckets := []time.Time{}
+	for bucket := truncateToInterval(from, interval); bucket.Before(to); {
+		buckets = append(buckets, bucket)
+		if len(buckets) > histogramMaxBuckets { // No need to keep counting.
+			break
+		}
+		switch interval {
+		case model.HistogramIntervalHour:
+			bucket = bucket.Add(time.Hour)
+		case model.HistogramIntervalDay:
+			bucket = bucket.AddDate(0, 0, 1)
+		default:
+			bucket = bucket.AddDate(0, 0, 7)
+		}
+	}
+	return buckets
+}
+
+// Sample query:
+// SELECT to_char(date_trunc('hour', ("data"->>'created')::timestamptz AT TIME ZONE 'UTC'),
+// 'YYYY-MM-DD"T"HH24:MI:SS"Z"') AS "bucket", COUNT(DISTINCT("uid")) AS "count" FROM "search"."resources"
+// WHERE (("data"->'kind'?('Pod')) AND ("data"->>'created' >= '2026-10-11T00:00:00Z')
+// AND ("data"->>'created' < '2026-10-18T00:00:00Z') AND (<RBAC>))
+// GROUP BY "bucket" ORDER BY "bucket" ASC
+func (s *SearchHistogramResult) buildSearchHistogramQuery(ctx context.Context) error {
+	var whereDs []exp.Expression
+	var err error
+
+	// Validate the property is a timestamp.
+	if _, found := s.propTypes[s.property]; !found {
+		klog.V(3).Infof("Property type for [%s] doesn't exist in cache. Refreshing property type cache", s.property)
+		s.propTypes, err = getPropertyType(ctx, true) // Refresh the property type cache.
+		if err != nil {
+			return fmt.Errorf("error [%s] fetching data type for property: [%s]", err, s.property)
+		}
+	}
+	if s.propTypes[s.property] != "timestamp" {
+		return fmt.Errorf("invalid property [%s]. The histogram requires a timestamp property", s.property)
+	}
+
+	schemaTable := goqu.S("search").Table("resources")
+	ds := dialect.From(schemaTable)
+
+	// WHERE CLAUSE
+	if s.input != nil && (len(s.input.Filters) > 0 || len(s.input.Keywords) > 0) {
+		if len(s.input.Keywords) > 0 {
+			jsb := goqu.L("jsonb_each_text(?)", goqu.C("data"))
+			ds = dialect.From(schemaTable, jsb)
+		}
+		whereDs, s.propTypes, err = WhereClauseFilter(ctx, s.input, s.propTypes)
+		if err != nil {
+			return err
+		}
+	}
+	// Timestamps use the same format, so the range can be compared as text.
+	propExp := goqu.L(`"data"->>?`, s.property)
+	whereDs = append(whereDs,
+		propExp.Gte(s.from.Format(histogramDateFormat)),
+		propExp.Lt(s.to.Format(histogramDateFormat)))
+
+	// get user info for logging
+	_, userInfo := rbac.GetCache().GetUserUID(ctx)
+
+	// RBAC CLAUSE
+	// if one of them is not nil, userData is not empty
+	if s.userData.CsResources != nil || s.userData.NsResources != nil || s.userData.ManagedClusters != nil {
+		whereDs = append(whereDs, rbacWhereClause(ctx, s.userData, userInfo)) // add rbac
+	} else {
+		s.query = ""
+		s.params = nil
+		return fmt.Errorf("RBAC clause is required! None found for searchHistogram query %+v for user %s with uid %s",
+			s.input, userInfo.Username, userInfo.UID)
+	}
+
+	// SELECT CLAUSE
+	bucketExp := goqu.L(`to_char(date_trunc(?, (?)::timestamptz AT TIME ZONE 'UTC'), ?)`,
+		strings.ToLower(string(s.interval)), propExp, `YYYY-MM-DD"T"HH24:MI:SS"Z"`)
+	selectDs := ds.Select(bucketExp.As("bucket"), goqu.COUNT(goqu.DISTINCT("uid")).As("count")).
+		Where(whereDs...).
+		GroupBy(goqu.C("bucket")).
+		Order(goqu.C("bucket").Asc())
+
+	sql, params, err := selectDs.Prepared(true).ToSQL()
+	if err != nil {
+		klog.Errorf("Error building SearchHistogram query: %s", err.Error())
+		return err
+	}
+	s.query = sql
+	s.params = params
+	klog.V(5).Info("SearchHistogram Query: ", s.query)
+	return nil
+}
+
+// Execute the query and fill the buckets without resources with zero.
+func (s *SearchHistogramResult) searchHistogramResults(ctx context.Context) ([]*model.HistogramBucket, error) {
+	klog.V(2).Info("Resolving searchHistogramResults()")
+	rows, err := s.pool.Query(ctx, s.query, s.params...)
+	if err != nil {
+		klog.Error("Error fetching search histogram results from db ", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var bucket string
+		var count int
+		if scanErr := rows.Scan(&bucket, &count); scanErr != nil {
+			klog.Error("Error reading searchHistogramResults ", scanErr)
+			continue
+		}
+		counts[bucket] = count
+	}
+
+	buckets := histogramBuckets(s.interval, s.from, s.to)
+	result := make([]*model.HistogramBucket, len(buckets))
+	for i, bucket := range buckets {
+		timestamp := bucket.Format(histogramDateFormat)
+		result[i] = &model.HistogramBucket{Timestamp: timestamp, Count: counts[timestamp]}
+	}
+	return result, nil
+}
@@ -0,0 +1,122 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockSearchHistogram(t *testing.T, input *model.SearchInput, interval model.HistogramInterval,
+	from, to string, ud rbac.UserData) (*SearchHistogramResult, *pgxpoolmock.MockPgxPool) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	fromTime, toTime, err := parseHistogramRange(interval, &from, &to)
+	assert.Nil(t, err)
+	mockResolver := &SearchHistogramResult{
+		from:      fromTime,
+		input:     input,
+		interval:  interval,
+		pool:      mockPool,
+		property:  "created",
+		propTypes: map[string]string{"kind": "string", "created": "timestamp"},
+		to:        toTime,
+		userData:  ud,
+	}
+	return mockResolver, mockPool
+}
+
+func Test_SearchHistogram_Query(t *testing.T) {
+	val1 := "Pod"
+	searchInput := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&val1}}}}
+	resolver, mockPool := newMockSearchHistogram(t, searchInput, model.HistogramIntervalHour,
+		"2026-10-18T10:30:00Z", "2026-10-18T14:00:00Z", rbac.UserData{CsResources: []rbac.Resource{}})
+
+	mockRows := &MockRows{
+		mockData: []map[string]interface{}{
+			{"bucket": "2026-10-18T10:00:00Z", "count": float64(2)},
+			{"bucket": "2026-10-18T12:00:00Z", "count": float64(5)},
+		},
+		columnHeaders: []string{"bucket", "count"},
+	}
+	mockPool.EXPECT().Query(gomock.Any(),
+		gomock.Eq(`SELECT to_char(date_trunc($1, ("data"->>$2)::timestamptz AT TIME ZONE 'UTC'), $3) AS "bucket", COUNT(DISTINCT("uid")) AS "count" FROM "search"."resources" WHERE ("data"->$4?($5) AND ("data"->>$6 >= $7) AND ("data"->>$8 < $9) AND (("cluster" = ANY ($10)) OR FALSE)) GROUP BY "bucket" ORDER BY "bucket" ASC`),
+		gomock.Eq([]interface{}{"hour", "created", "YYYY-MM-DD\"T\"HH24:MI:SS\"Z\"", "kind", "Pod", "created", "2026-10-18T10:30:00Z", "created", "2026-10-18T14:00:00Z", "{}"}),
+	).Return(mockRows, nil)
+
+	ctx := context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456")
+	err := resolver.buildSearchHistogramQuery(ctx)
+	assert.Nil(t, err)
+	result, err := resolver.searchHistogramResults(ctx)
+	assert.Nil(t, err)
+
+	// Buckets without resources are filled with 0.
+	expected := []*model.HistogramBucket{
+		{Timestamp: "2026-10-18T10:00:00Z", Count: 2},
+		{Timestamp: "2026-10-18T11:00:00Z", Count: 0},
+		{Timestamp: "2026-10-18T12:00:00Z", Count: 5},
+		{Timestamp: "2026-10-18T13:00:00Z", Count: 0},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func Test_SearchHistogram_InvalidProperty(t *testing.T) {
+	resolver, _ := newMockSearchHistogram(t, nil, model.HistogramIntervalDay,
+		"2026-10-01T00:00:00Z", "2026-10-18T00:00:00Z", rbac.UserData{CsResources: []rbac.Resource{}})
+	resolver.property = "kind"
+
+	err := resolver.buildSearchHistogramQuery(context.Background())
+	assert.NotNil(t, err)
+	assert.Equal(t, "", resolver.query)
+}
+
+func Test_SearchHistogram_NoRbac(t *testing.T) {
+	resolver, _ := newMockSearchHistogram(t, nil, model.HistogramIntervalDay,
+		"2026-10-01T00:00:00Z", "2026-10-18T00:00:00Z", rbac.UserData{})
+
+	err := resolver.buildSearchHistogramQuery(context.Background())
+	assert.NotNil(t, err)
+	assert.Equal(t, "", resolver.query)
+}
+
+func Test_parseHistogramRange(t *testing.T) {
+	from := "2026-10-18T00:00:00Z"
+	to := "2026-10-17T00:00:00Z"
+	_, _, err := parseHistogramRange(model.HistogramIntervalDay, &from, &to)
+	assert.EqualError(t, err, "invalid range. 'from' (2026-10-18T00:00:00Z) must be before 'to' (2026-10-17T00:00:00Z)")
+
+	invalid := "yesterday"
+	_, _, err = parseHistogramRange(model.HistogramIntervalDay, &invalid, nil)
+	assert.EqualError(t, err, "invalid value for 'from': yesterday. Expected RFC3339 format")
+
+	_, _, err = parseHistogramRange(model.HistogramInterval("MONTH"), nil, nil)
+	assert.EqualError(t, err, "invalid interval: MONTH")
+
+	from = "2025-01-01T00:00:00Z"
+	to = "2026-01-01T00:00:00Z"
+	_, _, err = parseHistogramRange(model.HistogramIntervalHour, &from, &to)
+	assert.EqualError(t, err, "invalid range. The range has 1001 buckets, the maximum is 1000")
+
+	// Defaults to the last 7 days.
+	fromTime, toTime, err := parseHistogramRange(model.HistogramIntervalDay, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 7*24*time.Hour, toTime.Sub(fromTime))
+}
+
+func Test_truncateToInterval(t *testing.T) {
+	ts := time.Date(2026, 10, 15, 17, 45, 12, 0, time.UTC) // Thursday
+	assert.Equal(t, time.Date(2026, 10, 15, 17, 0, 0, 0, time.UTC), truncateToInterval(ts, model.HistogramIntervalHour))
+	assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), truncateToInterval(ts, model.HistogramIntervalDay))
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), truncateToInterval(ts, model.HistogramIntervalWeek))
+
+	sunday := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), truncateToInterval(sunday, model.HistogramIntervalWeek))
+}
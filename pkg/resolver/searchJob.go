@@ -0,0 +1,371 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+	db "github.com/stolostron/search-v2-api/pkg/database"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	klog "k8s.io/klog/v2"
+)
+
+// SearchJob is a search running in the background, for queries that take longer than the request timeout.
+// Results are kept in memory until the job expires.
+type SearchJob struct {
+	id             string
+	includeRelated bool
+	userUID        string // The user who submitted the job. Only this user can get the job.
+
+	mutex     sync.RWMutex
+	cancel    context.CancelFunc
+	completed *time.Time
+	count     int // Total resources matching the query, may be more than the items kept.
+	items     []map[string]interface{}
+	message   string
+	progress  int
+	related   []SearchRelatedResult
+	status    model.SearchJobStatus
+	submitted time.Time
+}
+
+// Keeps the search jobs in memory.
+type searchJobStore struct {
+	mutex sync.Mutex
+	jobs  map[string]*SearchJob
+}
+
+var jobStore = &searchJobStore{jobs: map[string]*SearchJob{}}
+
+// SubmitSearchJob starts a search in the background and returns the job without waiting for the results.
+// The job uses a snapshot of the user's RBAC data at the time it's submitted.
+func SubmitSearchJob(ctx context.Context, input *model.SearchInput, includeRelated *bool) (*SearchJob, error) {
+	userUID, userInfo := rbac.GetCache().GetUserUID(ctx)
+	userData, userDataErr := rbac.GetCache().GetUserData(ctx)
+	if userDataErr != nil {
+		return nil, userDataErr
+	}
+
+	// check that shared cache has resource datatypes
+	propTypes, err := getPropertyType(ctx, false)
+	if err != nil {
+		klog.Warningf("Error creating datatype map. Error: [%s] ", err)
+	}
+
+	job, err := jobStore.add(userUID, includeRelated != nil && *includeRelated)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(2).Infof("Submitted search job %s for user %s", job.id, userInfo.Username)
+
+	// The job must continue after the request completes.
+	jobCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx),
+		time.Duration(config.Cfg.SearchJob.Timeout)*time.Millisecond)
+	job.cancel = cancel
+	search := &SearchResult{
+		context:        jobCtx,
+		countWithItems: true,
+		input:          searchJobInput(input),
+		pool:           db.GetConnPool(jobCtx),
+		propTypes:      propTypes,
+		userData:       userData,
+	}
+	go job.run(jobCtx, search)
+
+	return job, nil
+}
+
+// Returns the input with the limit of the job instead of the QUERY_LIMIT of search. The job keeps up to
+// SEARCH_JOB_MAX_RESULTS items, or fewer if the input has a lower limit.
+func searchJobInput(input *model.SearchInput) *model.SearchInput {
+	jobInput := &model.SearchInput{}
+	if input != nil {
+		*jobInput = *input
+	}
+	limit := config.Cfg.SearchJob.MaxResults
+	if jobInput.Limit != nil && *jobInput.Limit > 0 && *jobInput.Limit < limit {
+		limit = *jobInput.Limit
+	}
+	jobInput.Limit = &limit
+	return jobInput
+}
+
+// GetSearchJob returns the job if it was submitted by the same user and hasn't expired.
+func GetSearchJob(ctx context.Context, id string) (*SearchJob, error) {
+	userUID, _ := rbac.GetCache().GetUserUID(ctx)
+	return jobStore.get(userUID, id), nil
+}
+
+// Adds a new job if the user doesn't exceed the limit of pending or running jobs. The oldest completed jobs
+// are removed to keep the new job within the limits of jobs kept.
+func (s *searchJobStore) add(userUID string, includeRelated bool) (*SearchJob, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removeExpired()
+	s.removeOldest(userUID, 1)
+
+	active, userJobs := 0, 0
+	for _, job := range s.jobs {
+		if job.userUID == userUID {
+			userJobs++
+			if !job.isDone() {
+				active++
+			}
+		}
+	}
+	if active >= config.Cfg.SearchJob.MaxPerUser {
+		return nil, fmt.Errorf("the user has %d search jobs pending or running, the maximum is %d. "+
+			"Wait for a job to complete and try again", active, config.Cfg.SearchJob.MaxPerUser)
+	}
+	if userJobs >= config.Cfg.SearchJob.MaxStoredPerUser {
+		return nil, fmt.Errorf("the user has %d search jobs, the maximum is %d. "+
+			"Wait for a job to complete and try again", userJobs, config.Cfg.SearchJob.MaxStoredPerUser)
+	}
+	if len(s.jobs) >= config.Cfg.SearchJob.MaxStored {
+		return nil, fmt.Errorf("there are %d search jobs pending or running, the maximum is %d. "+
+			"Try again later", len(s.jobs), config.Cfg.SearchJob.MaxStored)
+	}
+
+	id, err := newSearchJobID()
+	if err != nil {
+		return nil, err
+	}
+	job := &SearchJob{
+		id:             id,
+		includeRelated: includeRelated,
+		userUID:        userUID,
+		status:         model.SearchJobStatusPending,
+		submitted:      time.Now(),
+	}
+	s.jobs[id] = job
+	return job, nil
+}
+
+func (s *searchJobStore) get(userUID string, id string) *SearchJob {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removeExpired()
+
+	job, found := s.jobs[id]
+	if !found || job.userUID != userUID {
+		return nil
+	}
+	return job
+}
+
+// Removes the jobs completed more than TTL ago. Must be called with the store mutex locked.
+func (s *searchJobStore) removeExpired() {
+	ttl := time.Duration(config.Cfg.SearchJob.TTL) * time.Millisecond
+	for id, job := range s.jobs {
+		job.mutex.RLock()
+		expired := job.completed != nil && time.Since(*job.completed) > ttl
+		job.mutex.RUnlock()
+		if expired {
+			klog.V(3).Infof("Removing expired search job %s", id)
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// Removes the oldest completed jobs until the jobs and items kept, for the user and for all the users, are
+// within the limits with the new jobs. Must be called with the store mutex locked.
+func (s *searchJobStore) removeOldest(userUID string, newJobs int) {
+	var completed []*SearchJob
+	userJobs, userItems, items := newJobs, 0, 0
+	for _, job := range s.jobs {
+		job.mutex.RLock()
+		if job.userUID == userUID {
+			userJobs++
+			userItems += len(job.items)
+		}
+		items += len(job.items)
+		if job.completed != nil {
+			completed = append(completed, job)
+		}
+		job.mutex.RUnlock()
+	}
+	jobs := len(s.jobs) + newJobs
+	slices.SortFunc(completed, func(a, b *SearchJob) int { return a.completed.Compare(*b.completed) })
+
+	limits := config.Cfg.SearchJob
+	for _, job := range completed {
+		overUser := job.userUID == userUID &&
+			(userJobs > limits.MaxStoredPerUser || userItems > limits.MaxStoredItemsPerUser)
+		if !overUser && jobs <= limits.MaxStored && items <= limits.MaxStoredItems {
+			continue
+		}
+		klog.V(3).Infof("Removing search job %s to keep the search jobs within the limits", job.id)
+		delete(s.jobs, job.id)
+		job.mutex.RLock()
+		if job.userUID == userUID {
+			userJobs--
+			userItems -= len(job.items)
+		}
+		jobs--
+		items -= len(job.items)
+		job.mutex.RUnlock()
+	}
+}
+
+// Removes the oldest completed jobs when a job completes, to keep its items within the limits.
+func (s *searchJobStore) completed(job *SearchJob) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removeOldest(job.userUID, 0)
+}
+
+func newSearchJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating search job id: %s", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Resolves the items and, if requested, the relationships.
+func (j *SearchJob) run(ctx context.Context, search *SearchResult) {
+	defer j.cancel()
+	j.update(model.SearchJobStatusRunning, 0, "")
+
+	items, err := search.Items()
+	if err == nil {
+		err = ctx.Err() // Timed out.
+	}
+	if err != nil {
+		klog.Warningf("Search job %s failed. %s", j.id, err)
+		j.update(model.SearchJobStatusFailed, 0, err.Error())
+		return
+	}
+
+	var related []SearchRelatedResult
+	if j.includeRelated {
+		j.update(model.SearchJobStatusRunning, 50, "")
+		related, err = search.Related(ctx)
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err != nil {
+			klog.Warningf("Search job %s failed resolving relationships. %s", j.id, err)
+			j.update(model.SearchJobStatusFailed, 50, err.Error())
+			return
+		}
+	}
+
+	j.mutex.Lock()
+	j.count = max(search.count, len(items))
+	j.items = items
+	j.related = related
+	j.mutex.Unlock()
+	j.update(model.SearchJobStatusCompleted, 100, "")
+	jobStore.completed(j)
+	klog.V(2).Infof("Search job %s completed with %d of %d items.", j.id, len(items), j.count)
+}
+
+func (j *SearchJob) update(status model.SearchJobStatus, progress int, message string) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.status = status
+	j.progress = progress
+	j.message = message
+	if status == model.SearchJobStatusCompleted || status == model.SearchJobStatusFailed {
+		now := time.Now()
+		j.completed = &now
+	}
+}
+
+func (j *SearchJob) isDone() bool {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.status == model.SearchJobStatusCompleted || j.status == model.SearchJobStatusFailed
+}
+
+func (j *SearchJob) ID() string {
+	return j.id
+}
+
+func (j *SearchJob) Status() model.SearchJobStatus {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.status
+}
+
+func (j *SearchJob) Progress() int {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.progress
+}
+
+func (j *SearchJob) Submitted() string {
+	return j.submitted.UTC().Format(time.RFC3339)
+}
+
+func (j *SearchJob) Completed() *string {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	if j.completed == nil {
+		return nil
+	}
+	completed := j.completed.UTC().Format(time.RFC3339)
+	return &completed
+}
+
+func (j *SearchJob) Message() *string {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	if j.message == "" {
+		return nil
+	}
+	message := j.message
+	return &message
+}
+
+// Count returns the total resources matching the query, from COUNT(*) OVER() of the items query. It's more
+// than the items when the job reached its limit.
+func (j *SearchJob) Count() *int {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	if j.status != model.SearchJobStatusCompleted {
+		return nil
+	}
+	count := j.count
+	return &count
+}
+
+// Items returns a page of the results. Default limit is the QUERY_LIMIT.
+func (j *SearchJob) Items(limit *int, offset *int) ([]map[string]interface{}, error) {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	if j.status != model.SearchJobStatusCompleted {
+		return nil, nil
+	}
+
+	start := 0
+	if offset != nil {
+		if *offset < 0 {
+			return nil, fmt.Errorf("invalid offset: %d. Offset must be non-negative", *offset)
+		}
+		start = min(*offset, len(j.items))
+	}
+	end := len(j.items)
+	pageSize := int(config.Cfg.QueryLimit) // #nosec G115 - QueryLimit is parsed as 32 bits.
+	if limit != nil && *limit > 0 {
+		pageSize = *limit
+	}
+	if limit == nil || *limit != -1 {
+		end = min(start+pageSize, len(j.items))
+	}
+	return j.items[start:end], nil
+}
+
+func (j *SearchJob) Related() []SearchRelatedResult {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.related
+}
@@ -0,0 +1,214 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSearchJobStore() *searchJobStore {
+	return &searchJobStore{jobs: map[string]*SearchJob{}}
+}
+
+func Test_SearchJobStore_MaxPerUser(t *testing.T) {
+	store := newTestSearchJobStore()
+	maxPerUser := config.Cfg.SearchJob.MaxPerUser
+	defer func() { config.Cfg.SearchJob.MaxPerUser = maxPerUser }()
+	config.Cfg.SearchJob.MaxPerUser = 1
+
+	job, err := store.add("user-a", false)
+	assert.Nil(t, err)
+	assert.Equal(t, model.SearchJobStatusPending, job.Status())
+	assert.Equal(t, 32, len(job.ID()))
+
+	_, err = store.add("user-a", false)
+	assert.NotNil(t, err, "Expected error when the user exceeds the maximum jobs.")
+
+	_, err = store.add("user-b", false)
+	assert.Nil(t, err, "The limit should apply to each user.")
+
+	job.update(model.SearchJobStatusCompleted, 100, "")
+	_, err = store.add("user-a", false)
+	assert.Nil(t, err, "Completed jobs should not count towards the limit.")
+}
+
+func Test_SearchJobStore_Get(t *testing.T) {
+	store := newTestSearchJobStore()
+	job, _ := store.add("user-a", false)
+
+	assert.Equal(t, job, store.get("user-a", job.id))
+	assert.Nil(t, store.get("user-b", job.id), "Only the user who submitted the job can get it.")
+	assert.Nil(t, store.get("user-a", "unknown-id"))
+}
+
+func Test_SearchJobStore_RemoveExpired(t *testing.T) {
+	store := newTestSearchJobStore()
+	job, _ := store.add("user-a", false)
+	job.update(model.SearchJobStatusCompleted, 100, "")
+	expired := time.Now().Add(-time.Duration(config.Cfg.SearchJob.TTL+1000) * time.Millisecond)
+	job.completed = &expired
+
+	assert.Nil(t, store.get("user-a", job.id), "Expected the job to be removed after the TTL.")
+	assert.Equal(t, 0, len(store.jobs))
+}
+
+// Completes the job with the number of items, at the given time.
+func completeTestSearchJob(job *SearchJob, items int, completed time.Time) {
+	job.items = make([]map[string]interface{}, items)
+	job.update(model.SearchJobStatusCompleted, 100, "")
+	job.completed = &completed
+}
+
+func Test_SearchJobStore_MaxStored(t *testing.T) {
+	store := newTestSearchJobStore()
+	limits := config.Cfg.SearchJob
+	t.Cleanup(func() { config.Cfg.SearchJob = limits })
+	config.Cfg.SearchJob.MaxPerUser, config.Cfg.SearchJob.MaxStoredPerUser, config.Cfg.SearchJob.MaxStored = 5, 2, 3
+
+	a1, _ := store.add("user-a", false)
+	completeTestSearchJob(a1, 0, time.Now().Add(-2*time.Minute))
+	a2, _ := store.add("user-a", false)
+	completeTestSearchJob(a2, 0, time.Now().Add(-time.Minute))
+	a3, err := store.add("user-a", false)
+	assert.Nil(t, err)
+	assert.Nil(t, store.get("user-a", a1.id), "Expected the oldest completed job of the user to be removed.")
+	assert.NotNil(t, store.get("user-a", a2.id))
+
+	_, err = store.add("user-b", false)
+	assert.Nil(t, err)
+	_, err = store.add("user-b", false)
+	assert.Nil(t, err)
+	assert.Nil(t, store.get("user-a", a2.id), "Expected the oldest completed job to be removed for other users.")
+	assert.NotNil(t, store.get("user-a", a3.id), "Jobs pending or running aren't removed.")
+
+	_, err = store.add("user-b", false)
+	assert.EqualError(t, err, "the user has 2 search jobs, the maximum is 2. Wait for a job to complete and try again")
+	_, err = store.add("user-a", false)
+	assert.EqualError(t, err, "there are 3 search jobs pending or running, the maximum is 3. Try again later")
+}
+
+func Test_SearchJobStore_MaxStoredItems(t *testing.T) {
+	store := newTestSearchJobStore()
+	limits := config.Cfg.SearchJob
+	t.Cleanup(func() { config.Cfg.SearchJob = limits })
+	config.Cfg.SearchJob.MaxStoredItemsPerUser, config.Cfg.SearchJob.MaxStoredItems = 10, 15
+
+	a1, _ := store.add("user-a", false)
+	a2, _ := store.add("user-a", false)
+	completeTestSearchJob(a1, 6, time.Now().Add(-2*time.Minute))
+	completeTestSearchJob(a2, 6, time.Now().Add(-time.Minute))
+	store.completed(a2)
+	assert.Nil(t, store.get("user-a", a1.id), "Expected the oldest job to be removed when the user has too many items.")
+	assert.NotNil(t, store.get("user-a", a2.id))
+
+	b1, _ := store.add("user-b", false)
+	completeTestSearchJob(b1, 4, time.Now())
+	store.completed(b1)
+	assert.NotNil(t, store.get("user-a", a2.id), "Expected the jobs to be kept within the limit of items.")
+	b2, _ := store.add("user-b", false)
+	completeTestSearchJob(b2, 6, time.Now())
+	store.completed(b2)
+	assert.Nil(t, store.get("user-a", a2.id), "Expected the oldest job to be removed when there are too many items.")
+	assert.NotNil(t, store.get("user-b", b1.id))
+}
+
+func Test_SearchJob_MessageCopy(t *testing.T) {
+	job := &SearchJob{}
+	job.update(model.SearchJobStatusFailed, 0, "timeout")
+
+	message := job.Message()
+	assert.Equal(t, "timeout", *message)
+	assert.NotSame(t, &job.message, message, "Expected a copy, the message changes with the job updates.")
+}
+
+func Test_SearchJob_Run(t *testing.T) {
+	val1 := "Template"
+	limit := -1
+	searchInput := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&val1}}},
+		Limit: &limit}
+	search, mockPool := newMockSearchResolver(t, searchJobInput(searchInput), nil,
+		rbac.UserData{CsResources: []rbac.Resource{}}, map[string]string{"kind": "string"})
+	search.countWithItems = true
+	mockRows := newMockRows("./mocks/mock.json")
+	mockRows.columnHeaders = []string{"uid", "cluster", "data", "total"}
+	for _, row := range mockRows.mockData {
+		row["total"] = float64(len(mockRows.mockData) + 5) // More than the job limit.
+	}
+	mockPool.EXPECT().Query(gomock.Any(),
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data", COUNT(*) OVER() AS "total" FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR FALSE)) LIMIT $4`),
+		gomock.Eq([]interface{}{"kind", "Template", "{}", int64(config.Cfg.SearchJob.MaxResults)}),
+	).Return(mockRows, nil)
+
+	job, _ := newTestSearchJobStore().add("user-a", false)
+	ctx, cancel := context.WithCancel(search.context)
+	job.cancel = cancel
+
+	job.run(ctx, search)
+
+	assert.Equal(t, model.SearchJobStatusCompleted, job.Status())
+	assert.Equal(t, 100, job.Progress())
+	assert.NotNil(t, job.Completed())
+	assert.Nil(t, job.Message())
+	assert.Equal(t, len(mockRows.mockData)+5, *job.Count(), "The count should include the items over the limit.")
+	items, _ := job.Items(&limit, nil)
+	assert.Equal(t, len(mockRows.mockData), len(items))
+}
+
+func Test_searchJobInput(t *testing.T) {
+	maxResults := config.Cfg.SearchJob.MaxResults
+	t.Cleanup(func() { config.Cfg.SearchJob.MaxResults = maxResults })
+	config.Cfg.SearchJob.MaxResults = 5000
+	unlimited, lower, higher := -1, 10, 6000
+
+	assert.Equal(t, 5000, *searchJobInput(nil).Limit)
+	assert.Equal(t, 5000, *searchJobInput(&model.SearchInput{Limit: &unlimited}).Limit)
+	assert.Equal(t, 10, *searchJobInput(&model.SearchInput{Limit: &lower}).Limit)
+	input := &model.SearchInput{Limit: &higher}
+	assert.Equal(t, 5000, *searchJobInput(input).Limit)
+	assert.Equal(t, 6000, *input.Limit, "The input of the request shouldn't be modified.")
+}
+
+func Test_SearchJob_RunRbacError(t *testing.T) {
+	search, _ := newMockSearchResolver(t, &model.SearchInput{}, nil, rbac.UserData{},
+		map[string]string{"kind": "string"})
+	job, _ := newTestSearchJobStore().add("user-a", false)
+	ctx, cancel := context.WithCancel(search.context)
+	job.cancel = cancel
+
+	job.run(ctx, search)
+
+	assert.Equal(t, model.SearchJobStatusFailed, job.Status())
+	assert.NotNil(t, job.Message())
+	assert.Nil(t, job.Count(), "Count is only available after the job completes.")
+}
+
+func Test_SearchJob_ItemsPagination(t *testing.T) {
+	job := &SearchJob{status: model.SearchJobStatusCompleted}
+	for i := 0; i < 5; i++ {
+		job.items = append(job.items, map[string]interface{}{"name": i})
+	}
+	limit, offset := 2, 3
+
+	items, err := job.Items(&limit, &offset)
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]interface{}{{"name": 3}, {"name": 4}}, items)
+
+	all := -1
+	items, _ = job.Items(&all, nil)
+	assert.Equal(t, 5, len(items))
+
+	offset = 10
+	items, _ = job.Items(&limit, &offset)
+	assert.Equal(t, 0, len(items))
+
+	offset = -1
+	_, err = job.Items(&limit, &offset)
+	assert.NotNil(t, err)
+}
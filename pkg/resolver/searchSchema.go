@@ -62,7 +62,7 @@ func (s *SearchSchema) buildSearchSchemaQuery(ctx context.Context) {
 
 	//FROM CLAUSE
 	schemaTable := goqu.S("search").Table("resources")
-	ds := goqu.From(schemaTable)
+	ds := dialect.From(schemaTable)
 
 	//WHERE CLAUSE
 	var whereDs []exp.Expression
@@ -71,7 +71,7 @@ func (s *SearchSchema) buildSearchSchemaQuery(ctx context.Context) {
 	if s.input != nil && len(s.input.Filters) > 0 {
 		if len(s.input.Keywords) > 0 {
 			jsb := goqu.L("jsonb_each_text(?)", goqu.C("data"))
-			ds = goqu.From(schemaTable, jsb)
+			ds = dialect.From(schemaTable, jsb)
 		}
 		whereDs, s.propTypes, _ = WhereClauseFilter(ctx, s.input, s.propTypes)
 	}
@@ -82,7 +82,7 @@ func (s *SearchSchema) buildSearchSchemaQuery(ctx context.Context) {
 	// if one of them is not nil, userData is not empty
 	if s.userData.CsResources != nil || s.userData.NsResources != nil || s.userData.ManagedClusters != nil {
 		whereDs = append(whereDs,
-			buildRbacWhereClause(ctx, s.userData, userInfo)) // add rbac
+			rbacWhereClause(ctx, s.userData, userInfo)) // add rbac
 	} else {
 		klog.Errorf("Error building search schema query: RBAC clause is required!"+
 			" None found for search schema query for user %s with uid %s ",
@@ -106,7 +106,7 @@ func (s *SearchSchema) buildSearchSchemaQuery(ctx context.Context) {
 			Limit(config.Cfg.QueryLimit * 100).As("schema"))
 	}
 	//Get the query
-	sql, params, err := selectDs.ToSQL()
+	sql, params, err := selectDs.Prepared(true).ToSQL()
 	if err != nil {
 		klog.Errorf("Error building SearchSchema query: %s", err.Error())
 	}
@@ -126,7 +126,7 @@ func (s *SearchSchema) searchSchemaResults(ctx context.Context) (map[string]inte
 		schemaMap[key] = struct{}{}
 	}
 
-	rows, err := s.pool.Query(ctx, s.query)
+	rows, err := s.pool.Query(ctx, s.query, s.params...)
 	if err != nil {
 		klog.Error("Error fetching search schema results from db ", err)
 		return srchSchema, err
@@ -17,7 +17,7 @@ func Test_SearchSchema_Query(t *testing.T) {
 	resolver, _ := newMockSearchSchema(t, searchInput, rbac.UserData{CsResources: []rbac.Resource{}}, nil)
 
 	resolver.userData = rbac.UserData{CsResources: []rbac.Resource{}}
-	sql := `SELECT DISTINCT "prop" FROM (SELECT jsonb_object_keys(jsonb_strip_nulls("data")) AS "prop" FROM "search"."resources" WHERE (("cluster" = ANY ('{}')) OR FALSE) LIMIT 100000) AS "schema"`
+	sql := `SELECT DISTINCT "prop" FROM (SELECT jsonb_object_keys(jsonb_strip_nulls("data")) AS "prop" FROM "search"."resources" WHERE (("cluster" = ANY ($1)) OR FALSE) LIMIT $2) AS "schema"`
 	// Execute function
 	resolver.buildSearchSchemaQuery(context.TODO())
 
@@ -33,7 +33,7 @@ func Test_SearchSchema_Query_WithFilters(t *testing.T) {
 	resolver, _ := newMockSearchSchema(t, searchInput, rbac.UserData{CsResources: []rbac.Resource{}}, propTypesMock)
 
 	resolver.userData = rbac.UserData{CsResources: []rbac.Resource{}}
-	sql := `SELECT DISTINCT "prop" FROM (SELECT jsonb_object_keys(jsonb_strip_nulls("data")) AS "prop" FROM "search"."resources" WHERE ("data"->'namespace'?('openshift') AND (("cluster" = ANY ('{}')) OR FALSE)) LIMIT 100000) AS "schema"`
+	sql := `SELECT DISTINCT "prop" FROM (SELECT jsonb_object_keys(jsonb_strip_nulls("data")) AS "prop" FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR FALSE)) LIMIT $4) AS "schema"`
 	// Execute function
 	resolver.buildSearchSchemaQuery(context.Background())
 
@@ -59,7 +59,8 @@ func Test_SearchSchema_Results(t *testing.T) {
 	mockRows := newMockRowsWithoutRBAC("../resolver/mocks/mock.json", searchInput, " ", 0)
 	// Mock the database query
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "prop" FROM (SELECT jsonb_object_keys(jsonb_strip_nulls("data")) AS "prop" FROM "search"."resources" WHERE (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments'))))))) LIMIT 100000) AS "schema"`),
+		gomock.Eq(`SELECT DISTINCT "prop" FROM (SELECT jsonb_object_keys(jsonb_strip_nulls("data")) AS "prop" FROM "search"."resources" WHERE (("cluster" = ANY ($1)) OR ("data"?$2 AND ((NOT("data"?$3) AND ((NOT("data"?$4) AND data->$5?$6) OR (data->$7?$8 AND data->$9?$10))) OR ((data->$11?|$12 AND ((NOT("data"?$13) AND data->$14?$15) OR (data->$16?$17 AND data->$18?$19))) OR (data->$20?|$21 AND ((data->$22?$23 AND data->$24?$25) OR (data->$26?$27 AND data->$28?$29))))))) LIMIT $30) AS "schema"`),
+		gomock.Eq([]interface{}{"{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(100000)}),
 	).Return(mockRows, nil)
 	resolver.buildSearchSchemaQuery(context.TODO())
 	res, _ := resolver.searchSchemaResults(context.TODO())
@@ -91,7 +92,8 @@ func Test_SearchSchema_Results_WithFilter(t *testing.T) {
 	mockRows := newMockRowsWithoutRBAC("../resolver/mocks/mock.json", searchInput, " ", 0)
 	// Mock the database query
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "prop" FROM (SELECT jsonb_object_keys(jsonb_strip_nulls("data")) AS "prop" FROM "search"."resources" WHERE ("data"->'namespace'?('openshift') AND (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))) LIMIT 100000) AS "schema"`),
+		gomock.Eq(`SELECT DISTINCT "prop" FROM (SELECT jsonb_object_keys(jsonb_strip_nulls("data")) AS "prop" FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR ("data"?$4 AND ((NOT("data"?$5) AND ((NOT("data"?$6) AND data->$7?$8) OR (data->$9?$10 AND data->$11?$12))) OR ((data->$13?|$14 AND ((NOT("data"?$15) AND data->$16?$17) OR (data->$18?$19 AND data->$20?$21))) OR (data->$22?|$23 AND ((data->$24?$25 AND data->$26?$27) OR (data->$28?$29 AND data->$30?$31)))))))) LIMIT $32) AS "schema"`),
+		gomock.Eq([]interface{}{"namespace", "openshift", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(100000)}),
 	).Return(mockRows, nil)
 	resolver.buildSearchSchemaQuery(context.Background())
 	res, _ := resolver.searchSchemaResults(context.Background())
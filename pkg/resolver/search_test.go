@@ -30,8 +30,8 @@ func Test_SearchResolver_Count(t *testing.T) {
 	// Mock the database query
 	mockRow := &Row{MockValue: 10}
 	mockPool.EXPECT().QueryRow(gomock.Any(),
-		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ("data"->'kind'?('Pod') AND (("cluster" = ANY ('{}')) OR FALSE))`),
-		gomock.Eq([]interface{}{})).Return(mockRow)
+		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR FALSE))`),
+		gomock.Eq([]interface{}{"kind", "Pod", "{}"})).Return(mockRow)
 
 	// Execute function
 	r, err := resolver.Count()
@@ -56,8 +56,8 @@ func Test_SearchResolver_MatchManagedHubCount(t *testing.T) {
 	// Mock the database query
 	mockRow := &Row{MockValue: 10}
 	mockPool.EXPECT().QueryRow(gomock.Any(),
-		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ("data"->'kind'?('Pod') AND (("cluster" = ANY ('{}')) OR FALSE))`),
-		gomock.Eq([]interface{}{})).Return(mockRow)
+		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR FALSE))`),
+		gomock.Eq([]interface{}{"kind", "Pod", "{}"})).Return(mockRow)
 
 	// Execute function
 	r, err := resolver.Count()
@@ -109,8 +109,8 @@ func Test_SearchResolver_Count_WithRBAC(t *testing.T) {
 	// Mock the database query
 	mockRow := &Row{MockValue: 10}
 	mockPool.EXPECT().QueryRow(gomock.Any(),
-		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ("data"->'kind'?('Pod') AND (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments'))))))))`),
-		gomock.Eq([]interface{}{})).Return(mockRow)
+		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR ("data"?$4 AND ((NOT("data"?$5) AND ((NOT("data"?$6) AND data->$7?$8) OR (data->$9?$10 AND data->$11?$12))) OR ((data->$13?|$14 AND ((NOT("data"?$15) AND data->$16?$17) OR (data->$18?$19 AND data->$20?$21))) OR (data->$22?|$23 AND ((data->$24?$25 AND data->$26?$27) OR (data->$28?$29 AND data->$30?$31))))))))`),
+		gomock.Eq([]interface{}{"kind", "Pod", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments"})).Return(mockRow)
 
 	// Execute function
 	r, err := resolver.Count()
@@ -133,8 +133,8 @@ func Test_SearchResolver_CountWithOperator(t *testing.T) {
 	// Mock the database query
 	mockRow := &Row{MockValue: 1}
 	mockPool.EXPECT().QueryRow(gomock.Any(),
-		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ((("data"->'current')::numeric >= '1') AND (("cluster" = ANY ('{}')) OR FALSE))`),
-		gomock.Eq([]interface{}{})).Return(mockRow)
+		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ((("data"->$1)::numeric >= $2) AND (("cluster" = ANY ($3)) OR FALSE))`),
+		gomock.Eq([]interface{}{"current", "1", "{}"})).Return(mockRow)
 
 	// Execute function
 	r, err := resolver.Count()
@@ -156,8 +156,8 @@ func Test_SearchResolver_CountWithOperatorNum(t *testing.T) {
 	// Mock the database query
 	mockRow := &Row{MockValue: 1}
 	mockPool.EXPECT().QueryRow(gomock.Any(),
-		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ((("data"->'current')::numeric IN ('1')) AND (("cluster" = ANY ('{}')) OR FALSE))`),
-		gomock.Eq([]interface{}{})).Return(mockRow)
+		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ((("data"->$1)::numeric IN ($2)) AND (("cluster" = ANY ($3)) OR FALSE))`),
+		gomock.Eq([]interface{}{"current", "1", "{}"})).Return(mockRow)
 
 	// Execute function
 	r, err := resolver.Count()
@@ -179,8 +179,8 @@ func Test_SearchResolver_CountWithOperatorString(t *testing.T) {
 	// Mock the database query
 	mockRow := &Row{MockValue: 1}
 	mockPool.EXPECT().QueryRow(gomock.Any(),
-		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ("data"->'kind'?('Template') AND (("cluster" = ANY ('{}')) OR FALSE))`),
-		gomock.Eq([]interface{}{})).Return(mockRow)
+		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR FALSE))`),
+		gomock.Eq([]interface{}{"kind", "Template", "{}"})).Return(mockRow)
 
 	// Execute function
 	r, err := resolver.Count()
@@ -204,8 +204,8 @@ func Test_SearchResolver_Items(t *testing.T) {
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock.json", searchInput, "string", 0)
 
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>'kind' ILIKE ANY ('{"template"}')) AND (("cluster" = ANY ('{}')) OR FALSE)) LIMIT 1000`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>$1 ILIKE ANY ($2)) AND (("cluster" = ANY ($3)) OR FALSE)) LIMIT $4`),
+		gomock.Eq([]interface{}{"kind", "{\"template\"}", "{}", int64(1000)}),
 	).Return(mockRows, nil)
 
 	// Execute the function
@@ -239,51 +239,60 @@ func Test_SearchResolver_Items(t *testing.T) {
 type TestOperatorItem struct {
 	searchInput *model.SearchInput
 	mockQuery   string
+	mockParams  []interface{}
 }
 
 func Test_SearchResolver_ItemsWithNumOperator(t *testing.T) {
 	val1 := ">1"
 	testOperatorGreater := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: "current", Values: []*string{&val1}}}},
-		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->'current')::numeric > '1') AND (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))) LIMIT 1000`,
+		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->$1)::numeric > $2) AND (("cluster" = ANY ($3)) OR ("data"?$4 AND ((NOT("data"?$5) AND ((NOT("data"?$6) AND data->$7?$8) OR (data->$9?$10 AND data->$11?$12))) OR ((data->$13?|$14 AND ((NOT("data"?$15) AND data->$16?$17) OR (data->$18?$19 AND data->$20?$21))) OR (data->$22?|$23 AND ((data->$24?$25 AND data->$26?$27) OR (data->$28?$29 AND data->$30?$31)))))))) LIMIT $32`,
+		mockParams:  []interface{}{"current", "1", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(1000)},
 	}
 	val2 := "<4"
 	testOperatorLesser := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: "current", Values: []*string{&val2}}}},
-		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->'current')::numeric < '4') AND (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))) LIMIT 1000`,
+		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->$1)::numeric < $2) AND (("cluster" = ANY ($3)) OR ("data"?$4 AND ((NOT("data"?$5) AND ((NOT("data"?$6) AND data->$7?$8) OR (data->$9?$10 AND data->$11?$12))) OR ((data->$13?|$14 AND ((NOT("data"?$15) AND data->$16?$17) OR (data->$18?$19 AND data->$20?$21))) OR (data->$22?|$23 AND ((data->$24?$25 AND data->$26?$27) OR (data->$28?$29 AND data->$30?$31)))))))) LIMIT $32`,
+		mockParams:  []interface{}{"current", "4", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(1000)},
 	}
 	val3 := ">=1"
 	testOperatorGreaterorEqual := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: "current", Values: []*string{&val3}}}},
-		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->'current')::numeric >= '1') AND (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))) LIMIT 1000`,
+		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->$1)::numeric >= $2) AND (("cluster" = ANY ($3)) OR ("data"?$4 AND ((NOT("data"?$5) AND ((NOT("data"?$6) AND data->$7?$8) OR (data->$9?$10 AND data->$11?$12))) OR ((data->$13?|$14 AND ((NOT("data"?$15) AND data->$16?$17) OR (data->$18?$19 AND data->$20?$21))) OR (data->$22?|$23 AND ((data->$24?$25 AND data->$26?$27) OR (data->$28?$29 AND data->$30?$31)))))))) LIMIT $32`,
+		mockParams:  []interface{}{"current", "1", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(1000)},
 	}
 	val4 := "<=3"
 	testOperatorLesserorEqual := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: "current", Values: []*string{&val4}}}},
-		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->'current')::numeric <= '3') AND (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))) LIMIT 1000`,
+		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->$1)::numeric <= $2) AND (("cluster" = ANY ($3)) OR ("data"?$4 AND ((NOT("data"?$5) AND ((NOT("data"?$6) AND data->$7?$8) OR (data->$9?$10 AND data->$11?$12))) OR ((data->$13?|$14 AND ((NOT("data"?$15) AND data->$16?$17) OR (data->$18?$19 AND data->$20?$21))) OR (data->$22?|$23 AND ((data->$24?$25 AND data->$26?$27) OR (data->$28?$29 AND data->$30?$31)))))))) LIMIT $32`,
+		mockParams:  []interface{}{"current", "3", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(1000)},
 	}
 
 	val5 := "!4"
 	testOperatorNot := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: "current", Values: []*string{&val5}}}},
-		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->'current')::numeric NOT IN ('4')) AND (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))) LIMIT 1000`,
+		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->$1)::numeric NOT IN ($2)) AND (("cluster" = ANY ($3)) OR ("data"?$4 AND ((NOT("data"?$5) AND ((NOT("data"?$6) AND data->$7?$8) OR (data->$9?$10 AND data->$11?$12))) OR ((data->$13?|$14 AND ((NOT("data"?$15) AND data->$16?$17) OR (data->$18?$19 AND data->$20?$21))) OR (data->$22?|$23 AND ((data->$24?$25 AND data->$26?$27) OR (data->$28?$29 AND data->$30?$31)))))))) LIMIT $32`,
+		mockParams:  []interface{}{"current", "4", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(1000)},
 	}
 
 	val6 := "!=4"
 	testOperatorNotEqual := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: "current", Values: []*string{&val6}}}},
-		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->'current')::numeric NOT IN ('4')) AND (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))) LIMIT 1000`,
+		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->$1)::numeric NOT IN ($2)) AND (("cluster" = ANY ($3)) OR ("data"?$4 AND ((NOT("data"?$5) AND ((NOT("data"?$6) AND data->$7?$8) OR (data->$9?$10 AND data->$11?$12))) OR ((data->$13?|$14 AND ((NOT("data"?$15) AND data->$16?$17) OR (data->$18?$19 AND data->$20?$21))) OR (data->$22?|$23 AND ((data->$24?$25 AND data->$26?$27) OR (data->$28?$29 AND data->$30?$31)))))))) LIMIT $32`,
+		mockParams:  []interface{}{"current", "4", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(1000)},
 	}
 
 	val7 := "=3"
 	testOperatorEqual := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: "current", Values: []*string{&val7}}}},
-		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->'current')::numeric IN ('3')) AND (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))) LIMIT 1000`,
+		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ((("data"->$1)::numeric IN ($2)) AND (("cluster" = ANY ($3)) OR ("data"?$4 AND ((NOT("data"?$5) AND ((NOT("data"?$6) AND data->$7?$8) OR (data->$9?$10 AND data->$11?$12))) OR ((data->$13?|$14 AND ((NOT("data"?$15) AND data->$16?$17) OR (data->$18?$19 AND data->$20?$21))) OR (data->$22?|$23 AND ((data->$24?$25 AND data->$26?$27) OR (data->$28?$29 AND data->$30?$31)))))))) LIMIT $32`,
+		mockParams:  []interface{}{"current", "3", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(1000)},
 	}
 
 	testOperatorMultiple := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: "current", Values: []*string{&val1, &val2}}}},
-		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (((("data"->'current')::numeric < '4') OR (("data"->'current')::numeric > '1')) AND (("cluster" = ANY ('{"managed1","managed2"}')) OR ("data"?'_hubClusterResource' AND ((NOT("data"?'namespace') AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'nodes') OR (data->'apigroup'?'storage.k8s.io' AND data->'kind_plural'?'csinodes'))) OR ((data->'namespace'?|'{"default"}' AND ((NOT("data"?'apigroup') AND data->'kind_plural'?'configmaps') OR (data->'apigroup'?'v4' AND data->'kind_plural'?'services'))) OR (data->'namespace'?|'{"ocm"}' AND ((data->'apigroup'?'v1' AND data->'kind_plural'?'pods') OR (data->'apigroup'?'v2' AND data->'kind_plural'?'deployments')))))))) LIMIT 1000`,
+		mockQuery:   `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (((("data"->$1)::numeric < $2) OR (("data"->$3)::numeric > $4)) AND (("cluster" = ANY ($5)) OR ("data"?$6 AND ((NOT("data"?$7) AND ((NOT("data"?$8) AND data->$9?$10) OR (data->$11?$12 AND data->$13?$14))) OR ((data->$15?|$16 AND ((NOT("data"?$17) AND data->$18?$19) OR (data->$20?$21 AND data->$22?$23))) OR (data->$24?|$25 AND ((data->$26?$27 AND data->$28?$29) OR (data->$30?$31 AND data->$32?$33)))))))) LIMIT $34`,
+		mockParams:  []interface{}{"current", "4", "current", "1", "{\"managed1\",\"managed2\"}", "_hubClusterResource", "namespace", "apigroup", "kind_plural", "nodes", "apigroup", "storage.k8s.io", "kind_plural", "csinodes", "namespace", "{\"default\"}", "apigroup", "kind_plural", "configmaps", "apigroup", "v4", "kind_plural", "services", "namespace", "{\"ocm\"}", "apigroup", "v1", "kind_plural", "pods", "apigroup", "v2", "kind_plural", "deployments", int64(1000)},
 	}
 
 	testOperators := []TestOperatorItem{
@@ -296,7 +305,7 @@ func Test_SearchResolver_ItemsWithNumOperator(t *testing.T) {
 func Test_SearchResolver_ItemsWithDateOperator(t *testing.T) {
 	//define schema table:
 	schemaTable := goqu.S("search").Table("resources")
-	ds := goqu.From(schemaTable)
+	ds := dialect.From(schemaTable)
 	prop := "created"
 
 	val8 := "year"
@@ -306,55 +315,61 @@ func Test_SearchResolver_ItemsWithDateOperator(t *testing.T) {
 	rbac := buildRbacWhereClause(context.TODO(),
 		rbac.UserData{CsResources: csres, NsResources: nsres, ManagedClusters: mc},
 		getUserInfo())
-	mockQueryYear, _, _ := ds.SelectDistinct("uid", "cluster", "data").Where(goqu.L(`"data"->>?`, prop).Gt(opValMap[">"][0]), rbac).Limit(1000).ToSQL()
+	mockQueryYear, mockParamsYear, _ := ds.SelectDistinct("uid", "cluster", "data").Where(goqu.L(`"data"->>?`, prop).Gt(opValMap[">"][0]), rbac).Limit(1000).Prepared(true).ToSQL()
 
 	testOperatorYear := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: prop, Values: []*string{&val8}}}},
 		mockQuery:   mockQueryYear, // `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->>'created' > ('2021-05-16T13:11:12Z')) LIMIT 1000`,
+		mockParams:  mockParamsYear,
 	}
 
 	val9 := "hour"
 	opValMap = getOperatorIfDateFilter(prop, []string{val9}, map[string][]string{})
-	mockQueryHour, _, _ := ds.SelectDistinct("uid", "cluster", "data").Where(goqu.L(`"data"->>?`, prop).Gt(opValMap[">"][0]), rbac).Limit(1000).ToSQL()
+	mockQueryHour, mockParamsHour, _ := ds.SelectDistinct("uid", "cluster", "data").Where(goqu.L(`"data"->>?`, prop).Gt(opValMap[">"][0]), rbac).Limit(1000).Prepared(true).ToSQL()
 
 	testOperatorHour := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: prop, Values: []*string{&val9}}}},
 		mockQuery:   mockQueryHour, // `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->>'created' > ('2021-05-16T13:11:12Z')) LIMIT 1000`,
+		mockParams:  mockParamsHour,
 	}
 
 	val10 := "day"
 	opValMap = getOperatorIfDateFilter(prop, []string{val10}, map[string][]string{})
-	mockQueryDay, _, _ := ds.SelectDistinct("uid", "cluster", "data").Where(goqu.L(`"data"->>?`, prop).Gt(goqu.L("?", opValMap[">"][0])), rbac).Limit(1000).ToSQL()
+	mockQueryDay, mockParamsDay, _ := ds.SelectDistinct("uid", "cluster", "data").Where(goqu.L(`"data"->>?`, prop).Gt(goqu.L("?", opValMap[">"][0])), rbac).Limit(1000).Prepared(true).ToSQL()
 
 	testOperatorDay := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: prop, Values: []*string{&val10}}}},
 		mockQuery:   mockQueryDay, // `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->>'created' > ('2021-05-16T13:11:12Z')) LIMIT 1000`,
+		mockParams:  mockParamsDay,
 	}
 
 	val11 := "week"
 	opValMap = getOperatorIfDateFilter(prop, []string{val11}, map[string][]string{})
-	mockQueryWeek, _, _ := ds.SelectDistinct("uid", "cluster", "data").Where(goqu.L(`"data"->>?`, prop).Gt(goqu.L("?", opValMap[">"][0])), rbac).Limit(1000).ToSQL()
+	mockQueryWeek, mockParamsWeek, _ := ds.SelectDistinct("uid", "cluster", "data").Where(goqu.L(`"data"->>?`, prop).Gt(goqu.L("?", opValMap[">"][0])), rbac).Limit(1000).Prepared(true).ToSQL()
 
 	testOperatorWeek := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: prop, Values: []*string{&val11}}}},
 		mockQuery:   mockQueryWeek, // `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->>'created' > ('2021-05-16T13:11:12Z')) LIMIT 1000`,
+		mockParams:  mockParamsWeek,
 	}
 
 	val12 := "month"
 	opValMap = getOperatorIfDateFilter(prop, []string{val12}, map[string][]string{})
-	mockQueryMonth, _, _ := ds.SelectDistinct("uid", "cluster", "data").Where(goqu.L(`"data"->>?`, prop).Gt(goqu.L("?", opValMap[">"][0])), rbac).Limit(1000).ToSQL()
+	mockQueryMonth, mockParamsMonth, _ := ds.SelectDistinct("uid", "cluster", "data").Where(goqu.L(`"data"->>?`, prop).Gt(goqu.L("?", opValMap[">"][0])), rbac).Limit(1000).Prepared(true).ToSQL()
 
 	testOperatorMonth := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: prop, Values: []*string{&val12}}}},
 		mockQuery:   mockQueryMonth, // `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->>'created' > ('2021-05-16T13:11:12Z')) LIMIT 1000`,
+		mockParams:  mockParamsMonth,
 	}
 	opValMap = getOperatorIfDateFilter(prop, []string{val8, val9}, map[string][]string{})
-	mockQueryMultiple, _, _ := ds.SelectDistinct("uid", "cluster", "data").Where(goqu.Or(goqu.L(`"data"->>?`, prop).Gt(opValMap[">"][0]),
-		goqu.L(`"data"->>?`, prop).Gt(opValMap[">"][1])), rbac).Limit(1000).ToSQL()
+	mockQueryMultiple, mockParamsMultiple, _ := ds.SelectDistinct("uid", "cluster", "data").Where(goqu.Or(goqu.L(`"data"->>?`, prop).Gt(opValMap[">"][0]),
+		goqu.L(`"data"->>?`, prop).Gt(opValMap[">"][1])), rbac).Limit(1000).Prepared(true).ToSQL()
 
 	testoperatorMultiple := TestOperatorItem{
 		searchInput: &model.SearchInput{Filters: []*model.SearchFilter{{Property: prop, Values: []*string{&val8, &val9}}}},
 		mockQuery:   mockQueryMultiple, // `SELECT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->>'created' > ('2021-05-16T13:11:12Z')) LIMIT 1000`,
+		mockParams:  mockParamsMultiple,
 	}
 	testOperators := []TestOperatorItem{
 		testOperatorYear, testOperatorHour, testOperatorDay, testOperatorWeek, testOperatorMonth,
@@ -376,7 +391,7 @@ func testAllOperators(t *testing.T, testOperators []TestOperatorItem) {
 
 		mockPool.EXPECT().Query(gomock.Any(),
 			gomock.Eq(currTest.mockQuery),
-			gomock.Eq([]interface{}{}),
+			gomock.Eq(currTest.mockParams),
 		).Return(mockRows, nil)
 
 		// Execute the function
@@ -421,9 +436,9 @@ func Test_SearchResolver_Items_Multiple_Filter(t *testing.T) {
 	// Mock the database queries.
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock.json", searchInput, "string", 0)
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->'namespace'?|'{"openshift","openshift-monitoring"}' AND ("cluster" IN ('local-cluster')) AND (("cluster" = ANY ('{}')) OR FALSE)) LIMIT 10`),
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->$1?|$2 AND ("cluster" IN ($3)) AND (("cluster" = ANY ($4)) OR FALSE)) LIMIT $5`),
 		// gomock.Eq("SELECT uid, cluster, data FROM search.resources  WHERE lower(data->> 'namespace')=any($1) AND cluster=$2 LIMIT 10"),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq([]interface{}{"namespace", "{\"openshift\",\"openshift-monitoring\"}", "local-cluster", "{}", int64(10)}),
 	).Return(mockRows, nil)
 
 	// Execute the function
@@ -471,8 +486,8 @@ func Test_SearchWithMultipleClusterFilter_NegativeLimit_Query(t *testing.T) {
 
 	// Mock the database query
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->'namespace'?('openshift') AND ("cluster" IN ('local-cluster', 'remote-1')) AND (("cluster" = ANY ('{}')) OR FALSE))`),
-		gomock.Eq([]interface{}{})).Return(mockRows, nil)
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->$1?($2) AND ("cluster" IN ($3, $4)) AND (("cluster" = ANY ($5)) OR FALSE))`),
+		gomock.Eq([]interface{}{"namespace", "openshift", "local-cluster", "remote-1", "{}"})).Return(mockRows, nil)
 
 	// Execute function
 	result, err := resolver.Items()
@@ -516,8 +531,8 @@ func Test_SearchResolver_Keywords(t *testing.T) {
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock.json", searchInput, "string", 0)
 
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources", jsonb_each_text("data") WHERE (("value" ILIKE '%Template%') AND (("cluster" = ANY ('{}')) OR FALSE)) LIMIT 10`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources", jsonb_each_text("data") WHERE (("value" ILIKE $1) AND (("cluster" = ANY ($2)) OR FALSE)) LIMIT $3`),
+		gomock.Eq([]interface{}{"%Template%", "{}", int64(10)}),
 	).Return(mockRows, nil)
 
 	// Execute the function
@@ -554,8 +569,8 @@ func Test_SearchResolver_Uids(t *testing.T) {
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock.json", searchInput, "string", 0)
 
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT "uid" FROM "search"."resources" WHERE (("data"->>'kind' ILIKE ANY ('{"template"}')) AND (("cluster" = ANY ('{}')) OR FALSE)) LIMIT 1000`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT "uid" FROM "search"."resources" WHERE (("data"->>$1 ILIKE ANY ($2)) AND (("cluster" = ANY ($3)) OR FALSE)) LIMIT $4`),
+		gomock.Eq([]interface{}{"kind", "{\"template\"}", "{}", int64(1000)}),
 	).Return(mockRows, nil)
 
 	// Execute the function
@@ -623,6 +638,39 @@ func Test_buildRbacWhereClauseCsNsAndMc(t *testing.T) {
 	assert.Equal(t, expectedSql, gotSql)
 }
 
+func Test_rbacWhereClause(t *testing.T) {
+	csres, nsScopeAccess, managedClusters := newUserData()
+	ud := rbac.UserData{CsResources: csres, NsResources: nsScopeAccess, ManagedClusters: managedClusters}
+	ctx := context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456")
+
+	compiled := rbacWhereClause(ctx, ud, getUserInfo())
+
+	expectedSql, _, _ := goqu.Select().Where(buildRbacWhereClause(ctx, ud, getUserInfo())).ToSQL()
+	gotSql, _, _ := goqu.Select().Where(compiled).ToSQL()
+	assert.Equal(t, expectedSql, gotSql)
+}
+
+func Test_buildRbacWhereClauseNsArrays(t *testing.T) {
+	threshold := config.Cfg.RbacArrayThreshold
+	defer func() { config.Cfg.RbacArrayThreshold = threshold }()
+	config.Cfg.RbacArrayThreshold = 1
+	_, nsScopeAccess, _ := newUserData()
+	nsScopeAccess["all"] = []rbac.Resource{{Apigroup: "*", Kind: "*"}}
+	ud := rbac.UserData{NsResources: nsScopeAccess}
+
+	rbacCombined := buildRbacWhereClause(context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456"),
+		ud, getUserInfo())
+	expectedSql := `SELECT * WHERE (("cluster" = ANY ('{}')) OR ("data"?'_hubClusterResource' AND (FALSE OR (data->'namespace'?|'{"all","default","ocm"}' AND EXISTS (SELECT 1 FROM unnest('{"all","default","default","ocm","ocm"}'::text[], '{"*","","v4","v1","v2"}'::text[], '{"*","configmaps","services","pods","deployments"}'::text[]) AS rbac(namespace, apigroup, kind) WHERE rbac.namespace = data->>'namespace' AND (rbac.apigroup = '*' OR (rbac.apigroup = '' AND NOT("data"?'apigroup')) OR (rbac.apigroup <> '' AND data->>'apigroup' = rbac.apigroup)) AND (rbac.kind = '*' OR data->>'kind_plural' = rbac.kind))))))`
+	gotSql, _, _ := goqu.Select().Where(rbacCombined).ToSQL()
+	assert.Equal(t, expectedSql, gotSql)
+
+	// The arrays are bind parameters.
+	_, params, _ := dialect.From("t").Where(rbacCombined).Prepared(true).ToSQL()
+	assert.Equal(t, []interface{}{"{}", "_hubClusterResource", "namespace", "{\"all\",\"default\",\"ocm\"}",
+		"{\"all\",\"default\",\"default\",\"ocm\",\"ocm\"}", "{\"*\",\"\",\"v4\",\"v1\",\"v2\"}",
+		"{\"*\",\"configmaps\",\"services\",\"pods\",\"deployments\"}", "apigroup"}, params)
+}
+
 func Test_SearchResolver_Items_Labels(t *testing.T) {
 	// Create a SearchResolver instance with a mock connection pool.
 	cluster := "local-cluster"
@@ -640,8 +688,8 @@ func Test_SearchResolver_Items_Labels(t *testing.T) {
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock.json", searchInput, "string", limit)
 
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->'kind'?('Template') AND ("cluster" IN ('local-cluster')) AND "data"->'label' @> '{"samples.operator.openshift.io/managed":"true"}' AND (("cluster" = ANY ('{}')) OR FALSE)) LIMIT 10`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->$1?($2) AND ("cluster" IN ($3)) AND "data"->$4 @> $5 AND (("cluster" = ANY ($6)) OR FALSE)) LIMIT $7`),
+		gomock.Eq([]interface{}{"kind", "Template", "local-cluster", "label", "{\"samples.operator.openshift.io/managed\":\"true\"}", "{}", int64(10)}),
 	).Return(mockRows, nil)
 
 	// Execute the function
@@ -688,8 +736,8 @@ func Test_SearchResolver_Items_Container(t *testing.T) {
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock.json", searchInput, "array", limit)
 
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->'kind'?('Template') AND ("cluster" IN ('local-cluster')) AND "data"->'container' @> '["acm-agent"]' AND (("cluster" = ANY ('{}')) OR FALSE)) LIMIT 10`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->$1?($2) AND ("cluster" IN ($3)) AND "data"->$4 @> $5 AND (("cluster" = ANY ($6)) OR FALSE)) LIMIT $7`),
+		gomock.Eq([]interface{}{"kind", "Template", "local-cluster", "container", "[\"acm-agent\"]", "{}", int64(10)}),
 	).Return(mockRows, nil)
 
 	// Execute the function
@@ -757,8 +805,8 @@ func Test_SearchResolver_UidsAllAccess(t *testing.T) {
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock.json", searchInput, "string", 0)
 
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT "uid" FROM "search"."resources" WHERE (("data"->>'kind' ILIKE ANY ('{"template"}')) AND (("cluster" = ANY ('{"managed-cluster1"}')) OR "data"?'_hubClusterResource')) LIMIT 1000`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT "uid" FROM "search"."resources" WHERE (("data"->>$1 ILIKE ANY ($2)) AND (("cluster" = ANY ($3)) OR "data"?$4)) LIMIT $5`),
+		gomock.Eq([]interface{}{"kind", "{\"template\"}", "{\"managed-cluster1\"}", "_hubClusterResource", int64(1000)}),
 	).Return(mockRows, nil)
 
 	// Execute the function
@@ -864,8 +912,8 @@ func Test_SearchResolver_SearchUserAllAccess(t *testing.T) {
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock.json", searchInput, "string", 0)
 
 	mockPool.EXPECT().Query(gomock.Any(),
-		gomock.Eq(`SELECT "uid" FROM "search"."resources" WHERE (("data"->>'kind' ILIKE ANY ('{"template"}')) AND (("cluster" NOT IN (SELECT "cluster" FROM "search"."resources" WHERE ((data ? '_hubClusterResource') IS TRUE) LIMIT 1)) OR FALSE)) LIMIT 1000`),
-		gomock.Eq([]interface{}{}),
+		gomock.Eq(`SELECT "uid" FROM "search"."resources" WHERE (("data"->>$1 ILIKE ANY ($2)) AND (("cluster" NOT IN (SELECT "cluster" FROM "search"."resources" WHERE ((data ? '_hubClusterResource') IS TRUE) LIMIT $3)) OR FALSE)) LIMIT $4`),
+		gomock.Eq([]interface{}{"kind", "{\"template\"}", int64(1), int64(1000)}),
 	).Return(mockRows, nil)
 
 	// Execute the function
@@ -948,7 +996,7 @@ func Test_SearchResolver_Items_MultipleLabels(t *testing.T) {
 
 	// Mock the database queries.
 	mockRows := newMockRowsWithoutRBAC("./mocks/mock.json", searchInput, "string", limit)
-	mockPool.EXPECT().Query(gomock.Any(), gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->'kind'?('Template') AND ("cluster" IN ('local-cluster')) AND EXISTS((SELECT 1 FROM jsonb_each_text("data"->'label') As kv(key, value) WHERE (((key LIKE 'samples%') AND (value LIKE 'tru%')) OR ((key LIKE 'app%') AND (value LIKE '%prometheus%'))))) AND (("cluster" = ANY ('{}')) OR FALSE)) LIMIT 10`), gomock.Eq([]interface{}{})).Return(mockRows, nil)
+	mockPool.EXPECT().Query(gomock.Any(), gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->$1?($2) AND ("cluster" IN ($3)) AND EXISTS((SELECT 1 FROM jsonb_each_text("data"->$4) As kv(key, value) WHERE (((key LIKE $5) AND (value LIKE $6)) OR ((key LIKE $7) AND (value LIKE $8))))) AND (("cluster" = ANY ($9)) OR FALSE)) LIMIT $10`), gomock.Eq([]interface{}{"kind", "Template", "local-cluster", "label", "samples%", "tru%", "app%", "%prometheus%", "{}", int64(10)})).Return(mockRows, nil)
 
 	// Execute the function
 	result, err := resolver.Items()
@@ -990,132 +1038,146 @@ func Test_SearchResolver_Items_MultipleLabels(t *testing.T) {
 
 func TestSearchResolverArrayLabel(t *testing.T) {
 	type test struct {
-		name          string
-		cluster       string
-		val1          string
-		val2          string
-		filterProp1   string
-		filterProp2   string
-		expectedQuery string
+		name           string
+		cluster        string
+		val1           string
+		val2           string
+		filterProp1    string
+		filterProp2    string
+		expectedQuery  string
+		expectedParams []interface{}
 	}
 
 	tests := []test{
 		{
-			name:          "Match Array",
-			cluster:       "local*",
-			val1:          "Temp*",
-			val2:          "acm-agent",
-			filterProp1:   "kind",
-			filterProp2:   "container",
-			expectedQuery: `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>'kind' LIKE 'Temp%') AND ("cluster" LIKE 'local%') AND "data"->'container' @> '["acm-agent"]' AND (("cluster" = ANY ('{"test"}')) OR FALSE)) LIMIT 10`,
+			name:           "Match Array",
+			cluster:        "local*",
+			val1:           "Temp*",
+			val2:           "acm-agent",
+			filterProp1:    "kind",
+			filterProp2:    "container",
+			expectedQuery:  `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>$1 LIKE $2) AND ("cluster" LIKE $3) AND "data"->$4 @> $5 AND (("cluster" = ANY ($6)) OR FALSE)) LIMIT $7`,
+			expectedParams: []interface{}{"kind", "Temp%", "local%", "container", "[\"acm-agent\"]", "{\"test\"}", int64(10)},
 		},
 		{
-			name:          "Not Match Array",
-			cluster:       "local*",
-			val1:          "Temp*",
-			val2:          `!acm-agent`,
-			filterProp1:   "kind",
-			filterProp2:   "container",
-			expectedQuery: `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>'kind' LIKE 'Temp%') AND ("cluster" LIKE 'local%') AND NOT("data"->'container' @> '["acm-agent"]') AND (("cluster" = ANY ('{"test"}')) OR FALSE)) LIMIT 10`,
+			name:           "Not Match Array",
+			cluster:        "local*",
+			val1:           "Temp*",
+			val2:           `!acm-agent`,
+			filterProp1:    "kind",
+			filterProp2:    "container",
+			expectedQuery:  `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>$1 LIKE $2) AND ("cluster" LIKE $3) AND NOT("data"->$4 @> $5) AND (("cluster" = ANY ($6)) OR FALSE)) LIMIT $7`,
+			expectedParams: []interface{}{"kind", "Temp%", "local%", "container", "[\"acm-agent\"]", "{\"test\"}", int64(10)},
 		},
 		{
-			name:          "Not Equal To Match Array",
-			cluster:       "local*",
-			val1:          "Temp*",
-			val2:          `!=acm-agent`,
-			filterProp1:   "kind",
-			filterProp2:   "container",
-			expectedQuery: `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>'kind' LIKE 'Temp%') AND ("cluster" LIKE 'local%') AND NOT("data"->'container' @> '["acm-agent"]') AND (("cluster" = ANY ('{"test"}')) OR FALSE)) LIMIT 10`,
+			name:           "Not Equal To Match Array",
+			cluster:        "local*",
+			val1:           "Temp*",
+			val2:           `!=acm-agent`,
+			filterProp1:    "kind",
+			filterProp2:    "container",
+			expectedQuery:  `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>$1 LIKE $2) AND ("cluster" LIKE $3) AND NOT("data"->$4 @> $5) AND (("cluster" = ANY ($6)) OR FALSE)) LIMIT $7`,
+			expectedParams: []interface{}{"kind", "Temp%", "local%", "container", "[\"acm-agent\"]", "{\"test\"}", int64(10)},
 		},
 		{
-			name:          "Partial Match Array",
-			cluster:       "local*",
-			val1:          "Temp*",
-			val2:          "acm-*",
-			filterProp1:   "kind",
-			filterProp2:   "container",
-			expectedQuery: `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>'kind' LIKE 'Temp%') AND ("cluster" LIKE 'local%') AND EXISTS((SELECT 1 FROM jsonb_array_elements_text("data"->'container') As arrayProp WHERE (arrayProp LIKE 'acm-%'))) AND (("cluster" = ANY ('{"test"}')) OR FALSE)) LIMIT 10`,
+			name:           "Partial Match Array",
+			cluster:        "local*",
+			val1:           "Temp*",
+			val2:           "acm-*",
+			filterProp1:    "kind",
+			filterProp2:    "container",
+			expectedQuery:  `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>$1 LIKE $2) AND ("cluster" LIKE $3) AND EXISTS((SELECT 1 FROM jsonb_array_elements_text("data"->$4) As arrayProp WHERE (arrayProp LIKE $5))) AND (("cluster" = ANY ($6)) OR FALSE)) LIMIT $7`,
+			expectedParams: []interface{}{"kind", "Temp%", "local%", "container", "acm-%", "{\"test\"}", int64(10)},
 		},
 		{
-			name:          "Partial Not Match Array",
-			cluster:       "local*",
-			val1:          "Temp*",
-			val2:          "!acm-*",
-			filterProp1:   "kind",
-			filterProp2:   "container",
-			expectedQuery: `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>'kind' LIKE 'Temp%') AND ("cluster" LIKE 'local%') AND NOT EXISTS((SELECT 1 FROM jsonb_array_elements_text("data"->'container') As arrayProp WHERE (arrayProp LIKE 'acm-%'))) AND (("cluster" = ANY ('{"test"}')) OR FALSE)) LIMIT 10`,
+			name:           "Partial Not Match Array",
+			cluster:        "local*",
+			val1:           "Temp*",
+			val2:           "!acm-*",
+			filterProp1:    "kind",
+			filterProp2:    "container",
+			expectedQuery:  `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>$1 LIKE $2) AND ("cluster" LIKE $3) AND NOT EXISTS((SELECT 1 FROM jsonb_array_elements_text("data"->$4) As arrayProp WHERE (arrayProp LIKE $5))) AND (("cluster" = ANY ($6)) OR FALSE)) LIMIT $7`,
+			expectedParams: []interface{}{"kind", "Temp%", "local%", "container", "acm-%", "{\"test\"}", int64(10)},
 		},
 		{
-			name:          "Partial Match Label Key And Value",
-			cluster:       "!local*",
-			val1:          "Temp*",
-			val2:          "samples.operator.openshift.io/man*:tru*",
-			filterProp1:   "kind",
-			filterProp2:   "label",
-			expectedQuery: `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>'kind' LIKE 'Temp%') AND NOT(("cluster" LIKE 'local%')) AND EXISTS((SELECT 1 FROM jsonb_each_text("data"->'label') As kv(key, value) WHERE ((key LIKE 'samples.operator.openshift.io/man%') AND (value LIKE 'tru%')))) AND (("cluster" = ANY ('{"test"}')) OR FALSE)) LIMIT 10`,
+			name:           "Partial Match Label Key And Value",
+			cluster:        "!local*",
+			val1:           "Temp*",
+			val2:           "samples.operator.openshift.io/man*:tru*",
+			filterProp1:    "kind",
+			filterProp2:    "label",
+			expectedQuery:  `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>$1 LIKE $2) AND NOT(("cluster" LIKE $3)) AND EXISTS((SELECT 1 FROM jsonb_each_text("data"->$4) As kv(key, value) WHERE ((key LIKE $5) AND (value LIKE $6)))) AND (("cluster" = ANY ($7)) OR FALSE)) LIMIT $8`,
+			expectedParams: []interface{}{"kind", "Temp%", "local%", "label", "samples.operator.openshift.io/man%", "tru%", "{\"test\"}", int64(10)},
 		},
 		{
-			name:          "Partial Match Label Key Or Value",
-			cluster:       "!local*",
-			val1:          "Temp*",
-			val2:          "samples.operator.openshift.io/man*",
-			filterProp1:   "kind",
-			filterProp2:   "label",
-			expectedQuery: `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>'kind' LIKE 'Temp%') AND NOT(("cluster" LIKE 'local%')) AND EXISTS((SELECT 1 FROM jsonb_each_text("data"->'label') As kv(key, value) WHERE ((key LIKE ('samples.operator.openshift.io/man%')) OR (value LIKE ('samples.operator.openshift.io/man%'))))) AND (("cluster" = ANY ('{"test"}')) OR FALSE)) LIMIT 10`,
+			name:           "Partial Match Label Key Or Value",
+			cluster:        "!local*",
+			val1:           "Temp*",
+			val2:           "samples.operator.openshift.io/man*",
+			filterProp1:    "kind",
+			filterProp2:    "label",
+			expectedQuery:  `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>$1 LIKE $2) AND NOT(("cluster" LIKE $3)) AND EXISTS((SELECT 1 FROM jsonb_each_text("data"->$4) As kv(key, value) WHERE ((key LIKE ($5)) OR (value LIKE ($6))))) AND (("cluster" = ANY ($7)) OR FALSE)) LIMIT $8`,
+			expectedParams: []interface{}{"kind", "Temp%", "local%", "label", "samples.operator.openshift.io/man%", "samples.operator.openshift.io/man%", "{\"test\"}", int64(10)},
 		},
 		{
-			name:          "Partial Match Label Not Key Or Value",
-			cluster:       "!local*",
-			val1:          "Temp*",
-			val2:          "!samples.operator.openshift.io/man*=tru*",
-			filterProp1:   "kind",
-			filterProp2:   "label",
-			expectedQuery: `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>'kind' LIKE 'Temp%') AND NOT(("cluster" LIKE 'local%')) AND NOT EXISTS((SELECT 1 FROM jsonb_each_text("data"->'label') As kv(key, value) WHERE ((key LIKE 'samples.operator.openshift.io/man%') AND (value LIKE 'tru%')))) AND (("cluster" = ANY ('{"test"}')) OR FALSE)) LIMIT 10`,
+			name:           "Partial Match Label Not Key Or Value",
+			cluster:        "!local*",
+			val1:           "Temp*",
+			val2:           "!samples.operator.openshift.io/man*=tru*",
+			filterProp1:    "kind",
+			filterProp2:    "label",
+			expectedQuery:  `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>$1 LIKE $2) AND NOT(("cluster" LIKE $3)) AND NOT EXISTS((SELECT 1 FROM jsonb_each_text("data"->$4) As kv(key, value) WHERE ((key LIKE $5) AND (value LIKE $6)))) AND (("cluster" = ANY ($7)) OR FALSE)) LIMIT $8`,
+			expectedParams: []interface{}{"kind", "Temp%", "local%", "label", "samples.operator.openshift.io/man%", "tru%", "{\"test\"}", int64(10)},
 		},
 		{
-			name:          "Match Label Not Key Or Value",
-			cluster:       "!local*",
-			val1:          "Temp*",
-			val2:          "!samples.operator.openshift.io/managed=true",
-			filterProp1:   "kind",
-			filterProp2:   "label",
-			expectedQuery: `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>'kind' LIKE 'Temp%') AND NOT(("cluster" LIKE 'local%')) AND NOT("data"->'label' @> '{"samples.operator.openshift.io/managed":"true"}') AND (("cluster" = ANY ('{"test"}')) OR FALSE)) LIMIT 10`,
+			name:           "Match Label Not Key Or Value",
+			cluster:        "!local*",
+			val1:           "Temp*",
+			val2:           "!samples.operator.openshift.io/managed=true",
+			filterProp1:    "kind",
+			filterProp2:    "label",
+			expectedQuery:  `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>$1 LIKE $2) AND NOT(("cluster" LIKE $3)) AND NOT("data"->$4 @> $5) AND (("cluster" = ANY ($6)) OR FALSE)) LIMIT $7`,
+			expectedParams: []interface{}{"kind", "Temp%", "local%", "label", "{\"samples.operator.openshift.io/managed\":\"true\"}", "{\"test\"}", int64(10)},
 		},
 		{
-			name:          "Match filter Only star",
-			cluster:       "local*",
-			val1:          "Temp*",
-			val2:          "*",
-			filterProp1:   "kind",
-			filterProp2:   "namespace",
-			expectedQuery: `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>'kind' LIKE 'Temp%') AND ("cluster" LIKE 'local%') AND ("data"->>'namespace' LIKE '%') AND (("cluster" = ANY ('{"test"}')) OR FALSE)) LIMIT 10`,
+			name:           "Match filter Only star",
+			cluster:        "local*",
+			val1:           "Temp*",
+			val2:           "*",
+			filterProp1:    "kind",
+			filterProp2:    "namespace",
+			expectedQuery:  `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("data"->>$1 LIKE $2) AND ("cluster" LIKE $3) AND ("data"->>$4 LIKE $5) AND (("cluster" = ANY ($6)) OR FALSE)) LIMIT $7`,
+			expectedParams: []interface{}{"kind", "Temp%", "local%", "namespace", "%", "{\"test\"}", int64(10)},
 		},
 		{
-			name:          "Partial Match 2 Arrays",
-			cluster:       "local*",
-			val1:          "*agent-1*",
-			val2:          "*agent-2*",
-			filterProp1:   "container",
-			filterProp2:   "container",
-			expectedQuery: `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (EXISTS((SELECT 1 FROM jsonb_array_elements_text("data"->'container') As arrayProp WHERE (arrayProp LIKE '%agent-1%'))) AND ("cluster" LIKE 'local%') AND EXISTS((SELECT 1 FROM jsonb_array_elements_text("data"->'container') As arrayProp WHERE (arrayProp LIKE '%agent-2%'))) AND (("cluster" = ANY ('{"test"}')) OR FALSE)) LIMIT 10`,
+			name:           "Partial Match 2 Arrays",
+			cluster:        "local*",
+			val1:           "*agent-1*",
+			val2:           "*agent-2*",
+			filterProp1:    "container",
+			filterProp2:    "container",
+			expectedQuery:  `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (EXISTS((SELECT 1 FROM jsonb_array_elements_text("data"->$1) As arrayProp WHERE (arrayProp LIKE $2))) AND ("cluster" LIKE $3) AND EXISTS((SELECT 1 FROM jsonb_array_elements_text("data"->$4) As arrayProp WHERE (arrayProp LIKE $5))) AND (("cluster" = ANY ($6)) OR FALSE)) LIMIT $7`,
+			expectedParams: []interface{}{"container", "%agent-1%", "local%", "container", "%agent-2%", "{\"test\"}", int64(10)},
 		},
 		{
-			name:          "Match 2 Arrays",
-			cluster:       "local-cluster",
-			val1:          "acm-agent-1",
-			val2:          "acm-agent-2",
-			filterProp1:   "container",
-			filterProp2:   "container",
-			expectedQuery: `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->'container' @> '["acm-agent-1"]' AND ("cluster" IN ('local-cluster')) AND "data"->'container' @> '["acm-agent-2"]' AND (("cluster" = ANY ('{"test"}')) OR FALSE)) LIMIT 10`,
+			name:           "Match 2 Arrays",
+			cluster:        "local-cluster",
+			val1:           "acm-agent-1",
+			val2:           "acm-agent-2",
+			filterProp1:    "container",
+			filterProp2:    "container",
+			expectedQuery:  `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->$1 @> $2 AND ("cluster" IN ($3)) AND "data"->$4 @> $5 AND (("cluster" = ANY ($6)) OR FALSE)) LIMIT $7`,
+			expectedParams: []interface{}{"container", "[\"acm-agent-1\"]", "local-cluster", "container", "[\"acm-agent-2\"]", "{\"test\"}", int64(10)},
 		},
 		{
-			name:          "Match 1 Arrays And Partial Match 2nd array",
-			cluster:       "local-cluster",
-			val1:          "acm-agent-1",
-			val2:          "*acm-agent-2",
-			filterProp1:   "container",
-			filterProp2:   "container",
-			expectedQuery: `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->'container' @> '["acm-agent-1"]' AND ("cluster" IN ('local-cluster')) AND EXISTS((SELECT 1 FROM jsonb_array_elements_text("data"->'container') As arrayProp WHERE (arrayProp LIKE '%acm-agent-2'))) AND (("cluster" = ANY ('{"test"}')) OR FALSE)) LIMIT 10`,
+			name:           "Match 1 Arrays And Partial Match 2nd array",
+			cluster:        "local-cluster",
+			val1:           "acm-agent-1",
+			val2:           "*acm-agent-2",
+			filterProp1:    "container",
+			filterProp2:    "container",
+			expectedQuery:  `SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE ("data"->$1 @> $2 AND ("cluster" IN ($3)) AND EXISTS((SELECT 1 FROM jsonb_array_elements_text("data"->$4) As arrayProp WHERE (arrayProp LIKE $5))) AND (("cluster" = ANY ($6)) OR FALSE)) LIMIT $7`,
+			expectedParams: []interface{}{"container", "[\"acm-agent-1\"]", "local-cluster", "container", "%acm-agent-2", "{\"test\"}", int64(10)},
 		},
 	}
 
@@ -1143,7 +1205,7 @@ func TestSearchResolverArrayLabel(t *testing.T) {
 			resolver, mockPool := newMockSearchResolver(t, searchInput, nil, ud, propTypesMock)
 			mockRows := newMockRowsWithoutRBAC("./mocks/mock.json", searchInput, "string", limit)
 
-			mockPool.EXPECT().Query(gomock.Any(), gomock.Eq(tc.expectedQuery), gomock.Eq([]interface{}{})).Return(mockRows, nil)
+			mockPool.EXPECT().Query(gomock.Any(), gomock.Eq(tc.expectedQuery), gomock.Eq(tc.expectedParams)).Return(mockRows, nil)
 
 			result, err := resolver.Items()
 			assert.Nil(t, err)
@@ -1279,6 +1341,8 @@ func Test_buildRbacWhereClause_clusterAdmin(t *testing.T) {
 }
 
 func Test_buildRbacWhereClause_fineGrainedRBAC_noNamespaces(t *testing.T) {
+	fineGrainedRbac := config.Cfg.Features.FineGrainedRbac
+	t.Cleanup(func() { config.Cfg.Features.FineGrainedRbac = fineGrainedRbac })
 	config.Cfg.Features.FineGrainedRbac = true
 	mock_userData := rbac.UserData{IsClusterAdmin: false, NsResources: map[string][]rbac.Resource{"ns-1": []rbac.Resource{{Kind: "Pod"}}}}
 
@@ -1292,6 +1356,8 @@ func Test_buildRbacWhereClause_fineGrainedRBAC_noNamespaces(t *testing.T) {
 }
 
 func Test_buildRbacWhereClause_fineGrainedRBAC(t *testing.T) {
+	fineGrainedRbac := config.Cfg.Features.FineGrainedRbac
+	t.Cleanup(func() { config.Cfg.Features.FineGrainedRbac = fineGrainedRbac })
 	config.Cfg.Features.FineGrainedRbac = true
 	mock_userData := rbac.UserData{
 		IsClusterAdmin: false,
@@ -1835,8 +1901,10 @@ func Test_BuildSearchQuery_WithOffsetAndOrderBy(t *testing.T) {
 	assert.Nil(t, err)
 
 	// Verify the query contains OFFSET, LIMIT, and ORDER BY
-	assert.Contains(t, resolver.query, "OFFSET 20", "Query should contain OFFSET clause")
-	assert.Contains(t, resolver.query, "LIMIT 10", "Query should contain LIMIT clause")
+	assert.Contains(t, resolver.query, "OFFSET $", "Query should contain OFFSET clause")
+	assert.Contains(t, resolver.query, "LIMIT $", "Query should contain LIMIT clause")
+	assert.Equal(t, []interface{}{int64(10), int64(20)}, resolver.params[len(resolver.params)-2:],
+		"LIMIT and OFFSET values should be bind parameters")
 	assert.Contains(t, resolver.query, "ORDER BY", "Query should contain ORDER BY clause")
 	assert.Contains(t, resolver.query, "DESC", "Query should contain DESC direction")
 }
@@ -1863,8 +1931,10 @@ func Test_BuildSearchQuery_WithOnlyOffset(t *testing.T) {
 	assert.Nil(t, err)
 
 	// Verify the query contains OFFSET and LIMIT but not ORDER BY
-	assert.Contains(t, resolver.query, "OFFSET 50", "Query should contain OFFSET clause")
-	assert.Contains(t, resolver.query, "LIMIT 25", "Query should contain LIMIT clause")
+	assert.Contains(t, resolver.query, "OFFSET $", "Query should contain OFFSET clause")
+	assert.Contains(t, resolver.query, "LIMIT $", "Query should contain LIMIT clause")
+	assert.Equal(t, []interface{}{int64(25), int64(50)}, resolver.params[len(resolver.params)-2:],
+		"LIMIT and OFFSET values should be bind parameters")
 	assert.NotContains(t, resolver.query, "ORDER BY", "Query should not contain ORDER BY when not specified")
 }
 
@@ -1915,7 +1985,8 @@ func Test_BuildSearchQuery_ZeroOffset(t *testing.T) {
 
 	// Verify the query does NOT contain OFFSET (since 0 is redundant)
 	assert.NotContains(t, resolver.query, "OFFSET", "Query should not contain OFFSET clause for offset=0")
-	assert.Contains(t, resolver.query, "LIMIT 10", "Query should contain LIMIT clause")
+	assert.Contains(t, resolver.query, "LIMIT $", "Query should contain LIMIT clause")
+	assert.Equal(t, int64(10), resolver.params[len(resolver.params)-1], "LIMIT value should be a bind parameter")
 }
 
 // Test_BuildSearchQuery_CountIgnoresOffsetAndOrderBy validates that count queries
@@ -2086,8 +2157,10 @@ func Test_Pagination_WithKeywords(t *testing.T) {
 	assert.Nil(t, err)
 
 	// Verify query contains both keyword handling and pagination
-	assert.Contains(t, resolver.query, "OFFSET 10", "Query should contain OFFSET for pagination")
-	assert.Contains(t, resolver.query, "LIMIT 5", "Query should contain LIMIT for pagination")
+	assert.Contains(t, resolver.query, "OFFSET $", "Query should contain OFFSET for pagination")
+	assert.Contains(t, resolver.query, "LIMIT $", "Query should contain LIMIT for pagination")
+	assert.Equal(t, []interface{}{int64(5), int64(10)}, resolver.params[len(resolver.params)-2:],
+		"LIMIT and OFFSET values should be bind parameters")
 	// Keywords are handled via jsonb_each_text in the FROM clause
 	assert.NotEmpty(t, resolver.query, "Query should be built successfully with keywords")
 }
@@ -2190,8 +2263,10 @@ func Test_FullPagination_AllFeatures(t *testing.T) {
 	assert.Nil(t, err)
 
 	// Verify all pagination features are present in the query
-	assert.Contains(t, resolver.query, "OFFSET 5", "Query should contain OFFSET")
-	assert.Contains(t, resolver.query, "LIMIT 10", "Query should contain LIMIT")
+	assert.Contains(t, resolver.query, "OFFSET $", "Query should contain OFFSET")
+	assert.Contains(t, resolver.query, "LIMIT $", "Query should contain LIMIT")
+	assert.Equal(t, []interface{}{int64(10), int64(5)}, resolver.params[len(resolver.params)-2:],
+		"LIMIT and OFFSET values should be bind parameters")
 	assert.Contains(t, resolver.query, "ORDER BY", "Query should contain ORDER BY")
 	assert.Contains(t, resolver.query, "DESC", "Query should contain DESC direction")
 	assert.Contains(t, resolver.query, "data->>'created'", "Query should include order field in SELECT")
@@ -2221,8 +2296,10 @@ func Test_Pagination_LargeOffset(t *testing.T) {
 	assert.Nil(t, err)
 
 	// Verify large offset is handled
-	assert.Contains(t, resolver.query, "OFFSET 50000", "Query should contain large OFFSET value")
-	assert.Contains(t, resolver.query, "LIMIT 100", "Query should contain LIMIT")
+	assert.Contains(t, resolver.query, "OFFSET $", "Query should contain large OFFSET value")
+	assert.Contains(t, resolver.query, "LIMIT $", "Query should contain LIMIT")
+	assert.Equal(t, []interface{}{int64(100), int64(50000)}, resolver.params[len(resolver.params)-2:],
+		"LIMIT and OFFSET values should be bind parameters")
 }
 
 // Test_OrderBy_InvalidProperty tests behavior when orderBy specifies a property
@@ -2358,3 +2435,102 @@ func Test_OrderBy_UidColumn(t *testing.T) {
 	assert.Contains(t, resolver.query, "\"uid\"", "Query should reference uid column directly")
 	assert.NotContains(t, resolver.query, "data->>'uid'", "Query should NOT extract uid from jsonb")
 }
+
+func Test_SearchResolver_ItemsWithCount(t *testing.T) {
+	val1 := "Template"
+	limit := 2
+	searchInput := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&val1}}},
+		Limit: &limit}
+	resolver, mockPool := newMockSearchResolver(t, searchInput, nil, rbac.UserData{CsResources: []rbac.Resource{}},
+		map[string]string{"kind": "string"})
+	resolver.countWithItems = true
+
+	mockRows := newMockRows("./mocks/mock.json")
+	mockRows.mockData = mockRows.mockData[:2]
+	mockRows.columnHeaders = []string{"uid", "cluster", "data", "total"}
+	for _, row := range mockRows.mockData {
+		row["total"] = float64(10)
+	}
+	// Expect a single query for the count, items and hasMore.
+	mockPool.EXPECT().Query(gomock.Any(),
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data", COUNT(*) OVER() AS "total" FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR FALSE)) LIMIT $4`),
+		gomock.Eq([]interface{}{"kind", "Template", "{}", int64(2)}),
+	).Return(mockRows, nil).Times(1)
+
+	count, err := resolver.Count()
+	assert.Nil(t, err)
+	assert.Equal(t, 10, count)
+
+	items, err := resolver.Items()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(items))
+
+	hasMore, err := resolver.HasMore()
+	assert.Nil(t, err)
+	assert.True(t, hasMore)
+}
+
+func Test_SearchResolver_ItemsWithCount_OffsetPastLastItem(t *testing.T) {
+	val1 := "Template"
+	offset := 50
+	searchInput := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&val1}}},
+		Offset: &offset}
+	resolver, mockPool := newMockSearchResolver(t, searchInput, nil, rbac.UserData{CsResources: []rbac.Resource{}},
+		map[string]string{"kind": "string"})
+	resolver.countWithItems = true
+
+	mockPool.EXPECT().Query(gomock.Any(),
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data", COUNT(*) OVER() AS "total" FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR FALSE)) LIMIT $4 OFFSET $5`),
+		gomock.Eq([]interface{}{"kind", "Template", "{}", int64(1000), int64(50)}),
+	).Return(&MockRows{mockData: []map[string]interface{}{}}, nil)
+	// The count can't be read from the items, so it falls back to the count query.
+	mockPool.EXPECT().QueryRow(gomock.Any(),
+		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR FALSE))`),
+		gomock.Eq([]interface{}{"kind", "Template", "{}"})).Return(&Row{MockValue: 20})
+
+	items, err := resolver.Items()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(items))
+
+	count, err := resolver.Count()
+	assert.Nil(t, err)
+	assert.Equal(t, 20, count)
+
+	hasMore, err := resolver.HasMore()
+	assert.Nil(t, err)
+	assert.False(t, hasMore)
+}
+
+func Test_SearchResolver_HasMore(t *testing.T) {
+	val1 := "Pod"
+	limit := 5
+	searchInput := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&val1}}},
+		Limit: &limit}
+	resolver, mockPool := newMockSearchResolver(t, searchInput, nil, rbac.UserData{CsResources: []rbac.Resource{}},
+		map[string]string{"kind": "string"})
+
+	mockPool.EXPECT().QueryRow(gomock.Any(),
+		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR FALSE))`),
+		gomock.Eq([]interface{}{"kind", "Pod", "{}"})).Return(&Row{MockValue: 10})
+
+	hasMore, err := resolver.HasMore()
+	assert.Nil(t, err)
+	assert.True(t, hasMore)
+}
+
+func Test_SearchResolver_HasMore_NoLimit(t *testing.T) {
+	val1 := "Pod"
+	limit := -1
+	searchInput := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&val1}}},
+		Limit: &limit}
+	resolver, _ := newMockSearchResolver(t, searchInput, nil, rbac.UserData{CsResources: []rbac.Resource{}},
+		map[string]string{"kind": "string"})
+
+	hasMore, err := resolver.HasMore()
+	assert.Nil(t, err)
+	assert.False(t, hasMore, "All items are returned when there isn't a limit.")
+}
+
+func Test_selectedFields_NoOperationContext(t *testing.T) {
+	assert.Equal(t, 0, len(selectedFields(context.Background())))
+}
@@ -0,0 +1,395 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/lib/pq"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+	db "github.com/stolostron/search-v2-api/pkg/database"
+	"github.com/stolostron/search-v2-api/pkg/metrics"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	klog "k8s.io/klog/v2"
+)
+
+const similarDefaultLimit = 10
+
+var (
+	// Suffix added to Pod names by the ReplicaSet, StatefulSet, DaemonSet or Job controllers. Ex: -x7k2p
+	podNameSuffix = regexp.MustCompile(`-[a-z0-9]{5}$`)
+	// Generated segment, like a ReplicaSet hash or a StatefulSet ordinal. Ex: -5f5575c669 or -0
+	generatedNameSegment = regexp.MustCompile(`-[a-z0-9]*[0-9][a-z0-9]*$`)
+)
+
+type SimilarResult struct {
+	criteria  []model.SimilarityCriteria
+	limit     uint
+	owner     *resourceOwner // Owner of the reference resource. Nil if it doesn't have an owner.
+	params    []interface{}
+	pool      pgxpoolmock.PgxPool
+	propTypes map[string]string
+	query     string
+	reference map[string]interface{} // The resource used to find similar resources.
+	uid       string
+	userData  rbac.UserData
+}
+
+type resourceOwner struct {
+	kind string
+	name string
+}
+
+func Similar(ctx context.Context, uid string, by []model.SimilarityCriteria, limit *int) ([]*model.SimilarResource, error) {
+	defer metrics.SlowLog("SimilarResolver", 0)()
+
+	if uid == "" {
+		return nil, fmt.Errorf("invalid input. The uid must not be empty")
+	}
+
+	userData, userDataErr := rbac.GetCache().GetUserData(ctx)
+	if userDataErr != nil {
+		return nil, userDataErr
+	}
+
+	// check that shared cache has resource datatypes
+	propTypes, err := getPropertyType(ctx, false)
+	if err != nil {
+		klog.Warningf("Error creating datatype map. Error: [%s] ", err)
+	}
+
+	similarResult := &SimilarResult{
+		criteria:  similarCriteria(by),
+		limit:     similarLimit(limit),
+		pool:      db.GetConnPool(ctx),
+		propTypes: propTypes,
+		uid:       uid,
+		userData:  userData,
+	}
+	if err := similarResult.resolveReference(ctx); err != nil {
+		return nil, err
+	}
+	if err := similarResult.buildSimilarQuery(ctx); err != nil {
+		return nil, err
+	}
+	if similarResult.query == "" { // The reference doesn't have values to match any of the criteria.
+		return []*model.SimilarResource{}, nil
+	}
+	return similarResult.similarResults(ctx)
+}
+
+// Defaults to all criteria. Removes duplicates.
+func similarCriteria(by []model.SimilarityCriteria) []model.SimilarityCriteria {
+	if len(by) == 0 {
+		return model.AllSimilarityCriteria
+	}
+	criteria := []model.SimilarityCriteria{}
+	for _, c := range model.AllSimilarityCriteria {
+		for _, b := range by {
+			if b == c {
+				criteria = append(criteria, c)
+				break
+			}
+		}
+	}
+	return criteria
+}
+
+func similarLimit(limit *int) uint {
+	if limit == nil || *limit <= 0 {
+		return similarDefaultLimit
+	}
+	if uint(*limit) > config.Cfg.QueryLimit { // #nosec G115 - Validated positive via > 0 check
+		return config.Cfg.QueryLimit
+	}
+	return uint(*limit) // #nosec G115 - Validated positive via > 0 check
+}
+
+// Resolves the reference resource, with RBAC applied, and its owner if needed.
+// The data isn't formatted, so labels and arrays can be used to build the query.
+func (s *SimilarResult) resolveReference(ctx context.Context) error {
+	limit := 1
+	reference := &SearchResult{
+		context:    ctx,
+		extraWhere: []exp.Expression{goqu.C("uid").Eq(s.uid)},
+		input:      &model.SearchInput{Limit: &limit},
+		propTypes:  s.propTypes,
+		userData:   s.userData,
+	}
+	if err := reference.buildSearchQuery(ctx, false, false); err != nil {
+		return err
+	}
+	rows, err := s.pool.Query(ctx, reference.query, reference.params...)
+	if err != nil {
+		klog.Errorf("Error resolving query [%s] with args [%+v]. Error: [%+v]", reference.query, reference.params, err)
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var uid, cluster string
+		var data map[string]interface{}
+		if scanErr := rows.Scan(&uid, &cluster, &data); scanErr != nil {
+			klog.Error("Error reading reference resource ", scanErr)
+			continue
+		}
+		s.reference = data
+	}
+	if s.reference == nil {
+		return fmt.Errorf("resource with uid [%s] not found", s.uid)
+	}
+
+	if s.hasCriteria(model.SimilarityCriteriaOwner) {
+		return s.resolveOwner(ctx)
+	}
+	return nil
+}
+
+// Sample query:
+// SELECT "o"."data"->>'kind', "o"."data"->>'name' FROM "search"."edges" AS "e"
+// INNER JOIN "search"."resources" AS "o" ON ("o"."uid" = "e"."destid")
+// WHERE (("e"."sourceid" = 'local-cluster/abc') AND ("e"."edgetype" = 'ownedBy')) LIMIT 1
+func (s *SimilarResult) resolveOwner(ctx context.Context) error {
+	sql, params, err := dialect.From(goqu.S("search").Table("edges").As("e")).
+		Join(goqu.S("search").Table("resources").As("o"), goqu.On(goqu.I("o.uid").Eq(goqu.I("e.destid")))).
+		Select(goqu.L(`"o"."data"->>'kind'`), goqu.L(`"o"."data"->>'name'`)).
+		Where(goqu.I("e.sourceid").Eq(s.uid), goqu.I("e.edgetype").Eq("ownedBy")).
+		Limit(1).Prepared(true).ToSQL()
+	if err != nil {
+		klog.Errorf("Error building Similar owner query: %s", err.Error())
+		return err
+	}
+	rows, err := s.pool.Query(ctx, sql, params...)
+	if err != nil {
+		klog.Errorf("Error resolving owner. Query [%s] with args [%+v]. Error: [%+v]", sql, params, err)
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		owner := &resourceOwner{}
+		if scanErr := rows.Scan(&owner.kind, &owner.name); scanErr != nil {
+			klog.Error("Error reading owner of resource ", scanErr)
+			continue
+		}
+		s.owner = owner
+	}
+	return nil
+}
+
+func (s *SimilarResult) hasCriteria(criteria model.SimilarityCriteria) bool {
+	for _, c := range s.criteria {
+		if c == criteria {
+			return true
+		}
+	}
+	return false
+}
+
+// Builds the condition to match each criteria using the values of the reference resource.
+// Criteria without values on the reference resource are skipped.
+func (s *SimilarResult) criteriaExpressions() ([]model.SimilarityCriteria, []exp.Expression) {
+	criteria := []model.SimilarityCriteria{}
+	expressions := []exp.Expression{}
+	for _, c := range s.criteria {
+		var expression exp.Expression
+		switch c {
+		case model.SimilarityCriteriaLabels:
+			// Shares at least one label.
+			labels, _ := s.reference["label"].(map[string]interface{})
+			keys := make([]string, 0, len(labels))
+			for key := range labels {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			labelExps := []exp.Expression{}
+			for _, key := range keys {
+				label, err := json.Marshal(map[string]interface{}{key: labels[key]})
+				if err != nil {
+					klog.Warningf("Error encoding label %s. Error: %s", key, err)
+					continue
+				}
+				labelExps = append(labelExps, goqu.L(`"data"->'label' @> ?`, string(label)))
+			}
+			if len(labelExps) > 0 {
+				expression = goqu.Or(labelExps...)
+			}
+		case model.SimilarityCriteriaOwner:
+			// Owner with the same kind and name.
+			if s.owner != nil {
+				expression = goqu.L("EXISTS ?", dialect.From(goqu.S("search").Table("edges").As("e")).
+					Join(goqu.S("search").Table("resources").As("o"), goqu.On(goqu.I("o.uid").Eq(goqu.I("e.destid")))).
+					Select(goqu.L("1")).
+					Where(goqu.I("e.sourceid").Eq(goqu.I("resources.uid")), goqu.I("e.edgetype").Eq("ownedBy"),
+						goqu.L(`"o"."data"->>'kind'`).Eq(s.owner.kind), goqu.L(`"o"."data"->>'name'`).Eq(s.owner.name)))
+			}
+		case model.SimilarityCriteriaImage:
+			// Uses at least one of the container images.
+			images := stringValues(s.reference["image"])
+			if len(images) > 0 {
+				sort.Strings(images)
+				expression = goqu.L(`"data"->'image' ? ?`, goqu.Literal("?|"), pq.Array(images))
+			}
+		case model.SimilarityCriteriaNamePrefix:
+			// Name with the same stem, ignoring generated suffixes.
+			if stem := nameStem(s.referenceValue("name"), s.referenceValue("kind")); stem != "" {
+				expression = goqu.Or(goqu.L(`"data"->>'name'`).Eq(stem), goqu.L(`"data"->>'name'`).Like(stem+"-%"))
+			}
+		}
+		if expression != nil {
+			criteria = append(criteria, c)
+			expressions = append(expressions, expression)
+		}
+	}
+	return criteria, expressions
+}
+
+// Sample query:
+// SELECT "uid", "cluster", "data", (COALESCE("data"->'label' @> '{"app":"nginx"}', false))::int AS "labels",
+// (COALESCE("data"->'image' ?| '{nginx:1.25}', false))::int AS "image",
+// (COALESCE(..., false))::int + (COALESCE(..., false))::int AS "score"
+// FROM "search"."resources" WHERE (("data"->>'kind' = 'Pod') AND ("uid" != 'local-cluster/abc')
+// AND (("data"->'label' @> '{"app":"nginx"}') OR ("data"->'image' ?| '{nginx:1.25}')) AND (<RBAC>))
+// ORDER BY "score" DESC, "uid" ASC LIMIT 10
+func (s *SimilarResult) buildSimilarQuery(ctx context.Context) error {
+	criteria, expressions := s.criteriaExpressions()
+	if len(expressions) == 0 {
+		klog.V(3).Infof("Resource [%s] doesn't have values to match the criteria %v", s.uid, s.criteria)
+		s.query = ""
+		s.params = nil
+		return nil
+	}
+
+	// SELECT CLAUSE
+	// Add a column with 1 or 0 for each criteria, and the sum as score. A criteria on a missing property is NULL,
+	// counted as 0 so the score isn't NULL.
+	selectCols := []interface{}{"uid", "cluster", "data"}
+	scoreCols := make([]string, len(expressions))
+	scoreArgs := make([]interface{}, len(expressions))
+	for i, expression := range expressions {
+		selectCols = append(selectCols,
+			goqu.L("(COALESCE(?, false))::int", expression).As(strings.ToLower(string(criteria[i]))))
+		scoreCols[i] = "(COALESCE(?, false))::int"
+		scoreArgs[i] = expression
+	}
+	selectCols = append(selectCols, goqu.L(strings.Join(scoreCols, " + "), scoreArgs...).As("score"))
+
+	// WHERE CLAUSE
+	whereDs := []exp.Expression{
+		goqu.L("???", goqu.L(`"data"->'kind'`), goqu.Literal("?"), s.referenceValue("kind")),
+		goqu.C("uid").Neq(s.uid),
+		goqu.Or(expressions...),
+	}
+
+	// get user info for logging
+	_, userInfo := rbac.GetCache().GetUserUID(ctx)
+
+	// RBAC CLAUSE
+	// if one of them is not nil, userData is not empty
+	if s.userData.CsResources != nil || s.userData.NsResources != nil || s.userData.ManagedClusters != nil {
+		whereDs = append(whereDs, rbacWhereClause(ctx, s.userData, userInfo)) // add rbac
+	} else {
+		s.query = ""
+		s.params = nil
+		return fmt.Errorf("RBAC clause is required! None found for similar query for uid %s for user %s with uid %s",
+			s.uid, userInfo.Username, userInfo.UID)
+	}
+
+	sql, params, err := dialect.From(goqu.S("search").Table("resources")).
+		Select(selectCols...).
+		Where(whereDs...).
+		Order(goqu.C("score").Desc(), goqu.C("uid").Asc()).
+		Limit(s.limit).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		klog.Errorf("Error building Similar query: %s", err.Error())
+		return err
+	}
+	s.query = sql
+	s.params = params
+	klog.V(5).Info("Similar Query: ", s.query)
+	return nil
+}
+
+// Execute the query and build the ranked results with the matched criteria.
+func (s *SimilarResult) similarResults(ctx context.Context) ([]*model.SimilarResource, error) {
+	klog.V(2).Info("Resolving similarResults()")
+	rows, err := s.pool.Query(ctx, s.query, s.params...)
+	if err != nil {
+		klog.Error("Error fetching similar results from db ", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	criteria, _ := s.criteriaExpressions()
+	result := []*model.SimilarResource{}
+	for rows.Next() {
+		var uid, cluster string
+		var data map[string]interface{}
+		matches := make([]int, len(criteria))
+		var score int
+		dest := []interface{}{&uid, &cluster, &data}
+		for i := range matches {
+			dest = append(dest, &matches[i])
+		}
+		dest = append(dest, &score)
+		if scanErr := rows.Scan(dest...); scanErr != nil {
+			klog.Error("Error reading similarResults ", scanErr)
+			return nil, scanErr
+		}
+		item := formatDataMap(data)
+		item["_uid"] = uid
+		item["cluster"] = cluster
+
+		reasons := []model.SimilarityCriteria{}
+		for i, match := range matches {
+			if match > 0 {
+				reasons = append(reasons, criteria[i])
+			}
+		}
+		result = append(result, &model.SimilarResource{Item: item, Score: score, Reasons: reasons})
+	}
+	return result, nil
+}
+
+func (s *SimilarResult) referenceValue(property string) string {
+	value, _ := s.reference[property].(string)
+	return value
+}
+
+// Removes the suffixes generated by kubernetes controllers from the name.
+// Ex: nginx-5f5575c669-x7k2p => nginx, web-0 => web
+func nameStem(name string, kind string) string {
+	stem := name
+	if kind == "Pod" {
+		stem = podNameSuffix.ReplaceAllString(stem, "")
+	}
+	for generatedNameSegment.MatchString(stem) {
+		stem = generatedNameSegment.ReplaceAllString(stem, "")
+	}
+	return stem
+}
+
+// Returns the values of a string or an array property.
+func stringValues(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			return []string{v}
+		}
+	case []interface{}:
+		values := []string{}
+		for _, val := range v {
+			values = append(values, fmt.Sprintf("%v", val))
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockSimilar(t *testing.T, criteria []model.SimilarityCriteria,
+	ud rbac.UserData) (*SimilarResult, *pgxpoolmock.MockPgxPool) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	mockResolver := &SimilarResult{
+		criteria:  similarCriteria(criteria),
+		limit:     similarDefaultLimit,
+		pool:      mockPool,
+		propTypes: map[string]string{"kind": "string"},
+		reference: map[string]interface{}{
+			"kind":  "Pod",
+			"name":  "nginx-5f5575c669-x7k2p",
+			"label": map[string]interface{}{"app": "nginx", "pod-template-hash": "5f5575c669"},
+			"image": []interface{}{"nginx:1.25"},
+		},
+		uid:      "local-cluster/pod-1",
+		userData: ud,
+	}
+	return mockResolver, mockPool
+}
+
+func Test_Similar_Query(t *testing.T) {
+	resolver, mockPool := newMockSimilar(t,
+		[]model.SimilarityCriteria{model.SimilarityCriteriaImage, model.SimilarityCriteriaLabels},
+		rbac.UserData{CsResources: []rbac.Resource{}})
+
+	mockRows := &MockRows{
+		mockData: []map[string]interface{}{
+			{"uid": "managed1/pod-2", "cluster": "managed1", "labels": float64(1), "image": float64(1), "score": float64(2),
+				"data": map[string]interface{}{"kind": "Pod", "name": "nginx-7d9c8b6f4-abcde"}},
+			{"uid": "managed2/pod-3", "cluster": "managed2", "labels": float64(0), "image": float64(1), "score": float64(1),
+				"data": map[string]interface{}{"kind": "Pod", "name": "web-0"}},
+			// Without labels, the labels criteria is NULL in the database and counted as 0.
+			{"uid": "managed2/pod-4", "cluster": "managed2", "labels": float64(0), "image": float64(1), "score": float64(1),
+				"data": map[string]interface{}{"kind": "Pod", "name": "nginx", "image": []interface{}{"nginx:1.25"}}},
+		},
+		columnHeaders: []string{"uid", "cluster", "data", "labels", "image", "score"},
+	}
+	mockPool.EXPECT().Query(gomock.Any(),
+		gomock.Eq(`SELECT "uid", "cluster", "data", (COALESCE(("data"->'label' @> $1 OR "data"->'label' @> $2), false))::int AS "labels", (COALESCE("data"->'image' ?| $3, false))::int AS "image", (COALESCE(("data"->'label' @> $4 OR "data"->'label' @> $5), false))::int + (COALESCE("data"->'image' ?| $6, false))::int AS "score" FROM "search"."resources" WHERE ("data"->'kind'?$7 AND ("uid" != $8) AND (("data"->'label' @> $9 OR "data"->'label' @> $10) OR "data"->'image' ?| $11) AND (("cluster" = ANY ($12)) OR FALSE)) ORDER BY "score" DESC, "uid" ASC LIMIT $13`),
+		gomock.Eq([]interface{}{`{"app":"nginx"}`, `{"pod-template-hash":"5f5575c669"}`, `{"nginx:1.25"}`,
+			`{"app":"nginx"}`, `{"pod-template-hash":"5f5575c669"}`, `{"nginx:1.25"}`, "Pod", "local-cluster/pod-1",
+			`{"app":"nginx"}`, `{"pod-template-hash":"5f5575c669"}`, `{"nginx:1.25"}`, "{}", int64(10)}),
+	).Return(mockRows, nil)
+
+	ctx := context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456")
+	err := resolver.buildSimilarQuery(ctx)
+	assert.Nil(t, err)
+	result, err := resolver.similarResults(ctx)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 3, len(result))
+	assert.Equal(t, "managed1/pod-2", result[0].Item["_uid"])
+	assert.Equal(t, 2, result[0].Score)
+	assert.Equal(t, []model.SimilarityCriteria{model.SimilarityCriteriaLabels, model.SimilarityCriteriaImage},
+		result[0].Reasons)
+	assert.Equal(t, "managed2/pod-3", result[1].Item["_uid"])
+	assert.Equal(t, 1, result[1].Score)
+	assert.Equal(t, []model.SimilarityCriteria{model.SimilarityCriteriaImage}, result[1].Reasons)
+	assert.Equal(t, "managed2/pod-4", result[2].Item["_uid"])
+	assert.Equal(t, 1, result[2].Score)
+	assert.Equal(t, []model.SimilarityCriteria{model.SimilarityCriteriaImage}, result[2].Reasons)
+}
+
+// Rows that fail to scan return an error, they aren't dropped from the results.
+type scanErrorRows struct {
+	*MockRows
+}
+
+func (r *scanErrorRows) Scan(dest ...interface{}) error {
+	return errors.New("cannot scan NULL into *int")
+}
+
+func Test_Similar_ScanError(t *testing.T) {
+	resolver, mockPool := newMockSimilar(t, []model.SimilarityCriteria{model.SimilarityCriteriaLabels},
+		rbac.UserData{CsResources: []rbac.Resource{}})
+	mockPool.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(&scanErrorRows{&MockRows{
+		mockData: []map[string]interface{}{{"uid": "managed1/pod-2"}}}}, nil)
+
+	ctx := context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456")
+	assert.Nil(t, resolver.buildSimilarQuery(ctx))
+	result, err := resolver.similarResults(ctx)
+
+	assert.EqualError(t, err, "cannot scan NULL into *int")
+	assert.Nil(t, result)
+}
+
+func Test_Similar_OwnerCriteria(t *testing.T) {
+	resolver, _ := newMockSimilar(t, []model.SimilarityCriteria{model.SimilarityCriteriaOwner},
+		rbac.UserData{CsResources: []rbac.Resource{}})
+
+	// Without an owner, there's nothing to match.
+	err := resolver.buildSimilarQuery(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "", resolver.query)
+
+	resolver.owner = &resourceOwner{kind: "ReplicaSet", name: "nginx-5f5575c669"}
+	err = resolver.buildSimilarQuery(context.Background())
+	assert.Nil(t, err)
+	assert.Contains(t, resolver.query, `(COALESCE(EXISTS (SELECT 1 FROM "search"."edges" AS "e" INNER JOIN "search"."resources" AS "o" ON ("o"."uid" = "e"."destid") WHERE (("e"."sourceid" = "resources"."uid") AND ("e"."edgetype" = $1) AND ("o"."data"->>'kind' = $2) AND ("o"."data"->>'name' = $3))), false))::int AS "owner"`)
+	assert.Equal(t, []interface{}{"ownedBy", "ReplicaSet", "nginx-5f5575c669"}, resolver.params[:3])
+}
+
+func Test_Similar_ReferenceNotFound(t *testing.T) {
+	resolver, mockPool := newMockSimilar(t, nil, rbac.UserData{CsResources: []rbac.Resource{}})
+	resolver.reference = nil
+
+	mockPool.EXPECT().Query(gomock.Any(),
+		gomock.Eq(`SELECT DISTINCT "uid", "cluster", "data" FROM "search"."resources" WHERE (("uid" = $1) AND (("cluster" = ANY ($2)) OR FALSE)) LIMIT $3`),
+		gomock.Eq([]interface{}{"local-cluster/pod-1", "{}", int64(1)}),
+	).Return(&MockRows{mockData: []map[string]interface{}{}}, nil)
+
+	ctx := context.WithValue(context.Background(), rbac.ContextAuthTokenKey, "123456")
+	err := resolver.resolveReference(ctx)
+	assert.EqualError(t, err, "resource with uid [local-cluster/pod-1] not found")
+}
+
+func Test_Similar_NoRbac(t *testing.T) {
+	resolver, _ := newMockSimilar(t, nil, rbac.UserData{})
+
+	err := resolver.buildSimilarQuery(context.Background())
+	assert.NotNil(t, err)
+	assert.Equal(t, "", resolver.query)
+}
+
+func Test_nameStem(t *testing.T) {
+	assert.Equal(t, "nginx", nameStem("nginx-5f5575c669-x7k2p", "Pod"))
+	assert.Equal(t, "web", nameStem("web-0", "Pod"))
+	assert.Equal(t, "nginx-proxy", nameStem("nginx-proxy", "Deployment"))
+	assert.Equal(t, "prometheus-operator", nameStem("prometheus-operator-5f5575c669", "ReplicaSet"))
+	assert.Equal(t, "12345", nameStem("12345", "ConfigMap"), "Names without generated segments are unchanged.")
+}
@@ -0,0 +1,72 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/jackc/pgx/v4"
+	klog "k8s.io/klog/v2"
+)
+
+// Sends the queries to a transaction instead of the pool.
+type snapshotPool struct {
+	pgxpoolmock.PgxPool // The pool, for the operations not used by the search queries.
+	tx                  pgx.Tx
+}
+
+func (p *snapshotPool) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return p.tx.Query(ctx, sql, args...)
+}
+
+func (p *snapshotPool) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return p.tx.QueryRow(ctx, sql, args...)
+}
+
+// Starts a read-only REPEATABLE READ transaction for the queries of this search result, so the items,
+// count and related see the same snapshot of the database while the indexer keeps writing.
+// The transaction ends after the last selected field is resolved, see snapshotFieldResolved(), or when the
+// request context is done. Must be called with the mutex locked.
+func (s *SearchResult) beginSnapshot() {
+	if !s.snapshot {
+		return
+	}
+	s.snapshot = false // Begin only once.
+
+	pool := s.pool
+	tx, err := pool.BeginTx(s.context, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		klog.Warningf("Unable to begin snapshot transaction, running the queries without it. %s", err)
+		return
+	}
+	klog.V(5).Info("Started snapshot transaction for search result.")
+	s.pool = &snapshotPool{PgxPool: pool, tx: tx}
+
+	stop := context.AfterFunc(s.context, func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if s.snapshotEnd != nil {
+			s.snapshotEnd()
+		}
+	})
+	s.snapshotEnd = func() {
+		stop()
+		// The transaction is read-only, so rollback and commit are the same.
+		if err := tx.Rollback(context.Background()); err != nil {
+			klog.Warningf("Error ending snapshot transaction. %s", err)
+		}
+		s.pool = pool
+		s.snapshotEnd = nil
+	}
+}
+
+// Called when a field of the search result is resolved. The snapshot ends after the last field selected
+// in the request, so its connection returns to the pool without waiting for the other inputs of the search
+// or a slow @defer client. Must be called with the mutex locked.
+func (s *SearchResult) snapshotFieldResolved() {
+	s.snapshotFields--
+	if s.snapshotFields == 0 && s.snapshotEnd != nil {
+		klog.V(5).Info("Ending snapshot transaction, all the fields of the search result are resolved.")
+		s.snapshotEnd()
+	}
+}
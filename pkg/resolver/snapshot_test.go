@@ -0,0 +1,122 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+// Mock transaction. Only implements the methods used by the search queries.
+type mockTx struct {
+	pgx.Tx
+	queries    []string
+	rolledBack chan struct{}
+	row        pgx.Row
+	rows       pgx.Rows
+}
+
+func (m *mockTx) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	m.queries = append(m.queries, sql)
+	return m.rows, nil
+}
+
+func (m *mockTx) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	m.queries = append(m.queries, sql)
+	return m.row
+}
+
+func (m *mockTx) Rollback(ctx context.Context) error {
+	close(m.rolledBack)
+	return nil
+}
+
+func newMockSnapshotSearch(t *testing.T) (*SearchResult, *mockTx, context.CancelFunc) {
+	val1 := "Template"
+	searchInput := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&val1}}}}
+	resolver, mockPool := newMockSearchResolver(t, searchInput, nil, rbac.UserData{CsResources: []rbac.Resource{}},
+		map[string]string{"kind": "string"})
+	ctx, cancel := context.WithCancel(resolver.context)
+	resolver.context = ctx
+	resolver.snapshot = true
+
+	tx := &mockTx{rolledBack: make(chan struct{}), row: &Row{MockValue: 10}, rows: newMockRows("./mocks/mock.json")}
+	mockPool.EXPECT().BeginTx(gomock.Any(),
+		gomock.Eq(pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})).Return(tx, nil).Times(1)
+	return resolver, tx, cancel
+}
+
+func Test_Snapshot_QueriesUseTransaction(t *testing.T) {
+	resolver, tx, cancel := newMockSnapshotSearch(t)
+	pool := resolver.pool
+
+	count, err := resolver.Count()
+	assert.Nil(t, err)
+	assert.Equal(t, 10, count)
+	items, err := resolver.Items()
+	assert.Nil(t, err)
+	assert.Equal(t, len(tx.rows.(*MockRows).mockData), len(items))
+
+	// Both queries run in the same transaction. The mock pool fails the test if queried directly.
+	assert.Equal(t, 2, len(tx.queries))
+
+	cancel()
+	select {
+	case <-tx.rolledBack:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the transaction to end when the request context is done.")
+	}
+	resolver.mutex.Lock()
+	defer resolver.mutex.Unlock()
+	assert.Equal(t, pool, resolver.pool, "Expected the pool to be restored after the transaction ends.")
+}
+
+func Test_Snapshot_EndsAfterLastField(t *testing.T) {
+	resolver, tx, cancel := newMockSnapshotSearch(t)
+	defer cancel()
+	pool := resolver.pool
+	resolver.snapshotFields = 2 // count and items
+
+	_, err := resolver.Count()
+	assert.Nil(t, err)
+	select {
+	case <-tx.rolledBack:
+		t.Fatal("Expected the transaction to stay open until the items are resolved.")
+	default:
+	}
+
+	_, err = resolver.Items()
+	assert.Nil(t, err)
+	select {
+	case <-tx.rolledBack:
+	default:
+		t.Fatal("Expected the transaction to end after the last field, before the request context is done.")
+	}
+	assert.Equal(t, 2, len(tx.queries))
+	assert.Equal(t, pool, resolver.pool, "Expected the pool to be restored after the transaction ends.")
+}
+
+func Test_Snapshot_BeginError(t *testing.T) {
+	val1 := "Template"
+	searchInput := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&val1}}}}
+	resolver, mockPool := newMockSearchResolver(t, searchInput, nil, rbac.UserData{CsResources: []rbac.Resource{}},
+		map[string]string{"kind": "string"})
+	resolver.snapshot = true
+
+	mockPool.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+	// Falls back to running the query on the pool.
+	mockPool.EXPECT().QueryRow(gomock.Any(),
+		gomock.Eq(`SELECT COUNT("uid") FROM "search"."resources" WHERE ("data"->$1?($2) AND (("cluster" = ANY ($3)) OR FALSE))`),
+		gomock.Eq([]interface{}{"kind", "Template", "{}"})).Return(&Row{MockValue: 5})
+
+	count, err := resolver.Count()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, count)
+}
@@ -0,0 +1,119 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type itemStreamsKey struct{}
+
+// The items of the lists with @stream, sent after the response of the operation. Lists are streamed
+// concurrently when they're in deferred fragments.
+type itemStreams struct {
+	mutex sync.Mutex
+	items []streamedItem
+}
+
+type streamedItem struct {
+	item  map[string]interface{}
+	label string
+	path  ast.Path // The path of the item in the response, with its index in the list.
+}
+
+// StreamDirective implements @stream for the lists of Map, like the items of a search. The first initialCount
+// items are returned, and the other items are sent by StreamOperations() after the response. Other lists, and
+// requests that don't accept multipart/mixed, have all the items.
+func StreamDirective(ctx context.Context, obj any, next graphql.Resolver, initialCount *int, label *string,
+	ifArg *bool) (any, error) {
+	count := 0
+	if initialCount != nil {
+		count = *initialCount
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("invalid initialCount: %d. initialCount must be non-negative", count)
+	}
+	res, err := next(ctx)
+	streams, ok := ctx.Value(itemStreamsKey{}).(*itemStreams)
+	if err != nil || !ok || (ifArg != nil && !*ifArg) {
+		return res, err
+	}
+	items, ok := res.([]map[string]interface{})
+	if !ok || len(items) <= count {
+		return res, nil
+	}
+	streamLabel := ""
+	if label != nil {
+		streamLabel = *label
+	}
+	streams.add(graphql.GetFieldContext(ctx).Path(), streamLabel, items, count)
+	return items[:count], nil
+}
+
+// StreamOperations sends the items of the lists with @stream after the response of a query and its deferred
+// fragments. Each item is sent as the data of an incremental payload with the index of the item in the path,
+// the same as a deferred fragment, so the clients merge it in the list.
+func StreamOperations(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	opCtx := graphql.GetOperationContext(ctx)
+	if opCtx.Operation == nil || opCtx.Operation.Operation != ast.Query ||
+		!strings.Contains(opCtx.Headers.Get("Accept"), "multipart/mixed") {
+		return next(ctx)
+	}
+	streams := &itemStreams{}
+	responses := next(context.WithValue(ctx, itemStreamsKey{}, streams))
+	operationDone := false
+	return func(ctx context.Context) *graphql.Response {
+		if !operationDone {
+			// The fields are resolved with the context of the response.
+			if response := responses(context.WithValue(ctx, itemStreamsKey{}, streams)); response != nil {
+				if streams.pending() && (response.HasNext == nil || !*response.HasNext) {
+					hasNext := true
+					response.HasNext = &hasNext
+				}
+				return response
+			}
+			operationDone = true
+		}
+		return streams.next()
+	}
+}
+
+// Adds the items of the list after the first count items.
+func (s *itemStreams) add(path ast.Path, label string, items []map[string]interface{}, count int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i := count; i < len(items); i++ {
+		itemPath := append(slices.Clone(path), ast.PathIndex(i))
+		s.items = append(s.items, streamedItem{item: items[i], label: label, path: itemPath})
+	}
+}
+
+func (s *itemStreams) pending() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.items) > 0
+}
+
+// Returns the response with the next item, or nil when all the items were sent.
+func (s *itemStreams) next() *graphql.Response {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.items) == 0 {
+		return nil
+	}
+	streamed := s.items[0]
+	s.items[0] = streamedItem{}
+	s.items = s.items[1:]
+
+	var data bytes.Buffer
+	graphql.MarshalMap(streamed.item).MarshalGQL(&data)
+	hasNext := len(s.items) > 0
+	return &graphql.Response{Data: data.Bytes(), Label: streamed.label, Path: streamed.path, HasNext: &hasNext}
+}
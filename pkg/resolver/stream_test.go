@@ -0,0 +1,99 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func newStreamTestItems() []map[string]interface{} {
+	return []map[string]interface{}{{"name": "pod-1"}, {"name": "pod-2"}, {"name": "pod-3"}}
+}
+
+// Context of the items field of search[0].
+func newStreamFieldContext(ctx context.Context) context.Context {
+	index := 0
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Field: graphql.CollectedField{Field: &ast.Field{Alias: "searchResult"}}})
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{Index: &index})
+	return graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Field: graphql.CollectedField{Field: &ast.Field{Alias: "items"}}})
+}
+
+// Operation context of a query, with the Accept header of the request.
+func newStreamOperationContext(accept string) context.Context {
+	return graphql.WithOperationContext(context.Background(), &graphql.OperationContext{
+		Operation: &ast.OperationDefinition{Operation: ast.Query},
+		Headers:   http.Header{"Accept": []string{accept}},
+	})
+}
+
+func resolveItems(ctx context.Context) (any, error) {
+	return newStreamTestItems(), nil
+}
+
+func Test_StreamDirective_NotStreamed(t *testing.T) {
+	one, disabled := 1, false
+	ctx := newStreamFieldContext(context.Background())
+
+	res, err := StreamDirective(ctx, nil, resolveItems, &one, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, res, 3, "Without multipart/mixed, all the items are in the response.")
+
+	ctx = newStreamFieldContext(context.WithValue(context.Background(), itemStreamsKey{}, &itemStreams{}))
+	res, err = StreamDirective(ctx, nil, resolveItems, &one, nil, &disabled)
+	assert.NoError(t, err)
+	assert.Len(t, res, 3, "With if: false, all the items are in the response.")
+
+	negative := -1
+	_, err = StreamDirective(ctx, nil, resolveItems, &negative, nil, nil)
+	assert.EqualError(t, err, "invalid initialCount: -1. initialCount must be non-negative")
+}
+
+func Test_StreamOperations(t *testing.T) {
+	ctx := newStreamOperationContext("multipart/mixed;deferSpec=20220824, application/json")
+	one, label := 1, "items"
+	handler := StreamOperations(ctx, func(ctx context.Context) graphql.ResponseHandler {
+		first := true
+		return func(ctx context.Context) *graphql.Response {
+			if !first {
+				return nil
+			}
+			first = false
+			res, err := StreamDirective(newStreamFieldContext(ctx), nil, resolveItems, &one, &label, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, []map[string]interface{}{{"name": "pod-1"}}, res)
+			return &graphql.Response{Data: []byte(`{"searchResult":[{"items":[{"name":"pod-1"}]}]}`)}
+		}
+	})
+
+	response := handler(ctx)
+	assert.True(t, *response.HasNext, "Expected more parts with the items streamed.")
+	for i, name := range []string{"pod-2", "pod-3"} {
+		response = handler(ctx)
+		assert.JSONEq(t, `{"name":"`+name+`"}`, string(response.Data))
+		assert.Equal(t, ast.Path{ast.PathName("searchResult"), ast.PathIndex(0), ast.PathName("items"),
+			ast.PathIndex(i + 1)}, response.Path)
+		assert.Equal(t, "items", response.Label)
+		assert.Equal(t, i == 0, *response.HasNext)
+	}
+	assert.Nil(t, handler(ctx))
+}
+
+func Test_StreamOperations_NotMultipart(t *testing.T) {
+	ctx := newStreamOperationContext("application/json")
+	one := 1
+	handler := StreamOperations(ctx, func(ctx context.Context) graphql.ResponseHandler {
+		res, err := StreamDirective(newStreamFieldContext(ctx), nil, resolveItems, &one, nil, nil)
+		assert.NoError(t, err)
+		assert.Len(t, res, 3, "Expected all the items when the client doesn't accept multipart/mixed.")
+		return func(ctx context.Context) *graphql.Response { return nil }
+	})
+
+	assert.Nil(t, handler(ctx))
+}
@@ -0,0 +1,130 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	defaultBatchWindowMs = 1000
+	defaultMaxBatchSize  = 500
+	maxBatchWindowMs     = 60000
+	maxMaxBatchSize      = 10000
+)
+
+// WatchBatchSubscription implements the GraphQL watchBatch subscription resolver.
+// The events of the watch subscription are grouped in batches. The watch keeps the events that don't fit while
+// a batch is waiting for the client, so the overflow policy still applies.
+func WatchBatchSubscription(ctx context.Context, input *model.SearchInput, initialState *bool, since *int,
+	overflow *model.WatchOverflow, diff *model.WatchDiff, properties []*string,
+	batchWindowMs, maxBatchSize *int) (<-chan *model.WatchBatch, error) {
+	window, maxSize := defaultBatchWindowMs, defaultMaxBatchSize
+	if batchWindowMs != nil {
+		window = *batchWindowMs
+	}
+	if maxBatchSize != nil {
+		maxSize = *maxBatchSize
+	}
+	if window < 1 || window > maxBatchWindowMs {
+		return nil, errors.New("invalid input. batchWindowMs must be between 1 and 60000")
+	}
+	if maxSize < 1 || maxSize > maxMaxBatchSize {
+		return nil, errors.New("invalid input. maxBatchSize must be between 1 and 10000")
+	}
+
+	events, err := watchEvents(ctx, input, nil, initialState, since, overflow)
+	if err != nil {
+		return nil, err
+	}
+	batches := make(chan *model.WatchBatch)
+	go batchEvents(ctx, events, batches, time.Duration(window)*time.Millisecond, maxSize,
+		newWatchPayload(diff, properties))
+	return batches, nil
+}
+
+// Groups the events in batches, sent window after the first event of the batch or when the batch is full.
+// The events are collapsed before they're reduced with the payload options, which may be nil.
+// Closes the batches channel when the events channel is closed.
+func batchEvents(ctx context.Context, events <-chan *model.Event, batches chan<- *model.WatchBatch,
+	window time.Duration, maxSize int, payload *watchPayload) {
+	defer close(batches)
+
+	batch := newEventBatch()
+	var timer *time.Timer
+	var timeout <-chan time.Time // Nil while the batch is empty.
+	send := func() bool {
+		if timer != nil {
+			timer.Stop()
+		}
+		timeout = nil
+		sent := batch.events
+		batch = newEventBatch()
+		if payload != nil {
+			sent = payload.applyAll(sent)
+			if len(sent) == 0 {
+				return true
+			}
+		}
+		select {
+		case batches <- &model.WatchBatch{Events: sent}:
+			klog.V(3).Infof("Subscription watchBatch sent a batch of %d events.", len(sent))
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if len(batch.events) == 0 {
+				timer = time.NewTimer(window)
+				timeout = timer.C
+			}
+			batch.add(event)
+			if len(batch.events) >= maxSize && !send() {
+				return
+			}
+		case <-timeout:
+			if !send() {
+				return
+			}
+		}
+	}
+}
+
+// Events of a batch. Multiple changes to the same resource are collapsed into one event.
+type eventBatch struct {
+	events  []*model.Event
+	pending map[string]*model.Event // The event of each resource (uid).
+}
+
+func newEventBatch() *eventBatch {
+	return &eventBatch{events: []*model.Event{}, pending: map[string]*model.Event{}}
+}
+
+func (b *eventBatch) add(event *model.Event) {
+	if event.UID == "" {
+		// SYNCED and RESYNC. The changes after them aren't collapsed with the changes before.
+		b.events = append(b.events, event)
+		b.pending = map[string]*model.Event{}
+		return
+	}
+	if queued, ok := b.pending[event.UID]; ok {
+		// The collapsed event has the seq of the latest change, so it moves to the end to keep the seq order,
+		// same as watchQueue.push().
+		b.events = slices.DeleteFunc(b.events, func(e *model.Event) bool { return e == queued })
+		event = coalesceEvents(queued, event)
+	}
+	b.pending[event.UID] = event
+	b.events = append(b.events, event)
+}
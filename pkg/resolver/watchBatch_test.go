@@ -0,0 +1,98 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func receiveBatch(t *testing.T, batches <-chan *model.WatchBatch) *model.WatchBatch {
+	select {
+	case batch := <-batches:
+		return batch
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for a batch.")
+		return nil
+	}
+}
+
+func Test_batchEvents_Window(t *testing.T) {
+	events := make(chan *model.Event, 10)
+	batches := make(chan *model.WatchBatch)
+	go batchEvents(context.Background(), events, batches, 20*time.Millisecond, 100, nil)
+
+	events <- newQueueEvent("a", "INSERT", 1)
+	events <- newQueueEvent("b", "UPDATE", 2)
+	events <- newQueueEvent("a", "UPDATE", 3)
+	start := time.Now()
+	batch := receiveBatch(t, batches)
+
+	assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+	assert.Len(t, batch.Events, 2)
+	assert.Equal(t, "b", batch.Events[0].UID)
+	// The collapsed event has the latest seq, so it's after b to keep the seq order.
+	assert.Equal(t, "a", batch.Events[1].UID)
+	assert.Equal(t, "INSERT", batch.Events[1].Operation)
+	assert.Equal(t, 3, batch.Events[1].NewData["seq"])
+	assert.Equal(t, 3, *batch.Events[1].Seq)
+
+	close(events)
+	_, ok := <-batches
+	assert.False(t, ok, "The batches channel should be closed.")
+}
+
+func Test_batchEvents_MaxSize(t *testing.T) {
+	events := make(chan *model.Event, 10)
+	batches := make(chan *model.WatchBatch)
+	go batchEvents(context.Background(), events, batches, time.Minute, 2, nil)
+	defer close(events)
+
+	events <- newQueueEvent("a", "UPDATE", 1)
+	events <- newQueueEvent("b", "UPDATE", 2)
+	events <- newQueueEvent("c", "UPDATE", 3)
+
+	batch := receiveBatch(t, batches) // Sent without waiting for the window.
+	assert.Len(t, batch.Events, 2)
+}
+
+func Test_eventBatch_SeqOrder(t *testing.T) {
+	batch := newEventBatch()
+
+	batch.add(newQueueEvent("a", "UPDATE", 1))
+	batch.add(newQueueEvent("b", "UPDATE", 2))
+	batch.add(newQueueEvent("a", "UPDATE", 3))
+	batch.add(newQueueEvent("c", "INSERT", 4))
+	batch.add(newQueueEvent("b", "DELETE", 5))
+
+	seqs := []int{}
+	for _, event := range batch.events {
+		seqs = append(seqs, *event.Seq)
+	}
+	assert.Equal(t, []int{3, 4, 5}, seqs)
+	assert.Equal(t, 0, batch.events[0].OldData["seq"], "Expected the oldData of the first change to a.")
+}
+
+func Test_eventBatch_NotCollapsedAcrossSynced(t *testing.T) {
+	batch := newEventBatch()
+
+	batch.add(&model.Event{UID: "a", Operation: eventAdded})
+	batch.add(&model.Event{Operation: eventSynced})
+	batch.add(&model.Event{UID: "a", Operation: "UPDATE"})
+
+	assert.Len(t, batch.events, 3)
+	assert.Equal(t, "UPDATE", batch.events[2].Operation)
+}
+
+func TestWatchBatchSubscription_InvalidOptions(t *testing.T) {
+	zero, tooMany := 0, maxMaxBatchSize+1
+
+	_, err := WatchBatchSubscription(context.Background(), nil, nil, nil, nil, nil, nil, &zero, nil)
+	assert.EqualError(t, err, "invalid input. batchWindowMs must be between 1 and 60000")
+
+	_, err = WatchBatchSubscription(context.Background(), nil, nil, nil, nil, nil, nil, nil, &tooMany)
+	assert.EqualError(t, err, "invalid input. maxBatchSize must be between 1 and 10000")
+}
@@ -0,0 +1,155 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+	"github.com/stolostron/search-v2-api/pkg/database"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	klog "k8s.io/klog/v2"
+)
+
+// WatchCountSubscription implements the GraphQL watchCount subscription resolver.
+// The count is updated with the changes received between the queries, so it may drift when a change is
+// received for a resource already counted. Counting again periodically corrects it.
+func WatchCountSubscription(ctx context.Context, input *model.SearchInput) (<-chan *int, error) {
+	result := make(chan *int)
+	if err := checkSubscriptionEnabled(); err != nil {
+		return result, err
+	}
+	if err := validateInputFilters(input); err != nil {
+		return result, err
+	}
+	if !hasFilterOrKeyword(input) {
+		return result, errors.New("invalid input. watchCount requires a filter or keyword")
+	}
+
+	subID := subscriptionID(ctx)
+	receiver := make(chan *model.Event, 100)
+	// Registered before counting, so no changes are missed.
+	subCtx, err := database.RegisterSubscription(ctx, subID, receiver)
+	if err != nil {
+		klog.Errorf("Failed to register subscription [%s]: %v", subID, err)
+		return nil, err
+	}
+
+	go func() {
+		defer func() {
+			klog.V(2).Infof("Closed subscription watchCount(%s).", subID)
+			database.UnregisterSubscription(subID)
+			close(result)
+			close(receiver)
+		}()
+		refresh := time.Duration(config.Cfg.Subscription.CountRefresh) * time.Millisecond
+		sendCount(subCtx, subID, input, receiver, result, refresh, func() (int, error) {
+			return countResources(subCtx, input)
+		})
+	}()
+	return result, nil
+}
+
+// Sends the count, then updates it with the changes received. Counts again every refresh interval, when
+// the effect of a change is unknown, or when changes were dropped. Only the latest count is sent, the
+// client doesn't need the values it missed.
+// The changes received before a count started are in the count, so they're skipped.
+func sendCount(ctx context.Context, subID string, input *model.SearchInput, receiver <-chan *model.Event,
+	result chan<- *int, refresh time.Duration, count func() (int, error)) {
+	filter := newEventFilter(input)
+	barrier := database.LastEventSeq()
+	current, err := count()
+	if err != nil {
+		klog.Errorf("Subscription watchCount(%s) failed to count the resources. %v", subID, err)
+		return
+	}
+	ticker := time.NewTicker(refresh)
+	defer ticker.Stop()
+
+	sent := -1
+	for {
+		var send chan<- *int // Nil when the client has the current count, disabling the case.
+		value := current
+		if current != sent {
+			send = result
+		}
+		recount := false
+		select {
+		case <-ctx.Done():
+			klog.V(3).Infof("Subscription watchCount(%s) closed.", subID)
+			return
+		case send <- &value:
+			sent = value
+			database.UpdateSubscriptionActivity(subID)
+		case event, ok := <-receiver:
+			if !ok {
+				return
+			}
+			if event.Seq != nil && *event.Seq <= barrier {
+				klog.V(4).Infof("Subscription watchCount(%s) skipped a change already counted (seq: %d).", subID,
+					*event.Seq)
+				continue
+			}
+			delta, known := countDelta(ctx, filter, event)
+			current += delta
+			recount = !known || database.TakeDroppedEvents(subID) > 0
+		case <-ticker.C:
+			recount = true
+		}
+
+		if recount {
+			barrier = database.LastEventSeq()
+			if value, err := count(); err != nil {
+				klog.Warningf("Subscription watchCount(%s) failed to count the resources again. %v", subID, err)
+			} else {
+				current = value
+			}
+		}
+	}
+}
+
+// Returns 1 if the change made a resource enter the results, -1 if it made a resource leave, or false if it
+// isn't known because the data was truncated.
+func countDelta(ctx context.Context, filter *eventFilter, event *model.Event) (int, bool) {
+	oldMatch, newMatch := false, false
+	switch event.Operation {
+	case "INSERT":
+		newMatch = event.NewData != nil && eventMatchesAllFilters(&model.Event{NewData: event.NewData}, filter)
+	case "UPDATE", "DELETE":
+		if event.OldData == nil {
+			return 0, false
+		}
+		oldMatch = eventMatchesAllFilters(&model.Event{OldData: event.OldData}, filter)
+		newMatch = event.Operation == "UPDATE" && event.NewData != nil &&
+			eventMatchesAllFilters(&model.Event{NewData: event.NewData}, filter)
+	}
+	if oldMatch == newMatch || !eventMatchesRbac(ctx, event) {
+		return 0, true
+	}
+	if newMatch {
+		return 1, true
+	}
+	return -1, true
+}
+
+// Counts the resources matching the input, with the user's current RBAC.
+func countResources(ctx context.Context, input *model.SearchInput) (int, error) {
+	userData, err := rbac.GetCache().GetUserData(ctx)
+	if err != nil {
+		return 0, err
+	}
+	propTypes, err := getPropertyType(ctx, false)
+	if err != nil {
+		klog.Warningf("Error creating datatype map. Error: [%s] ", err)
+	}
+	s := &SearchResult{
+		context:   ctx,
+		input:     input,
+		pool:      database.GetConnPool(ctx),
+		propTypes: propTypes,
+		userData:  userData,
+	}
+	return s.Count()
+}
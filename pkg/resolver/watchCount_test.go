@@ -0,0 +1,140 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+	"github.com/stolostron/search-v2-api/pkg/database"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPodData(status string) map[string]any {
+	return map[string]any{"kind": "Pod", "status": status, "namespace": "foo", "apigroup": "v1",
+		"kind_plural": "pods", "cluster": "local-cluster", "_hubClusterResource": true}
+}
+
+func receiveCount(t *testing.T, result <-chan *int) int {
+	select {
+	case count := <-result:
+		return *count
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the count.")
+		return 0
+	}
+}
+
+func Test_countDelta(t *testing.T) {
+	ctx := rbac.CreateTestContext("test-user-1", "testuser1")
+	userData := rbac.CreateTestUserWatchData("watch", "v1", "pods", "foo", true, time.Now(), 1*time.Minute)
+	rbac.SetupWatchCacheWithUserData(ctx, userData)
+	defer rbac.CleanupWatchCache(ctx)
+	running := "Running"
+	input := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "status", Values: []*string{&running}}}}
+
+	tests := []struct {
+		name  string
+		event *model.Event
+		delta int
+		known bool
+	}{
+		{"insert matching", &model.Event{Operation: "INSERT", NewData: newPodData("Running")}, 1, true},
+		{"insert not matching", &model.Event{Operation: "INSERT", NewData: newPodData("Pending")}, 0, true},
+		{"update entering", &model.Event{Operation: "UPDATE", OldData: newPodData("Pending"),
+			NewData: newPodData("Running")}, 1, true},
+		{"update leaving", &model.Event{Operation: "UPDATE", OldData: newPodData("Running"),
+			NewData: newPodData("Failed")}, -1, true},
+		{"update staying", &model.Event{Operation: "UPDATE", OldData: newPodData("Running"),
+			NewData: newPodData("Running")}, 0, true},
+		{"delete matching", &model.Event{Operation: "DELETE", OldData: newPodData("Running")}, -1, true},
+		{"delete truncated", &model.Event{Operation: "DELETE"}, 0, false},
+		{"update truncated", &model.Event{Operation: "UPDATE", NewData: newPodData("Running")}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta, known := countDelta(ctx, newEventFilter(input), tt.event)
+			assert.Equal(t, tt.delta, delta)
+			assert.Equal(t, tt.known, known)
+		})
+	}
+}
+
+func Test_sendCount(t *testing.T) {
+	ctx := rbac.CreateTestContext("test-user-1", "testuser1")
+	userData := rbac.CreateTestUserWatchData("watch", "v1", "pods", "foo", true, time.Now(), 1*time.Minute)
+	rbac.SetupWatchCacheWithUserData(ctx, userData)
+	defer rbac.CleanupWatchCache(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	kind := "Pod"
+	input := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&kind}}}}
+	receiver := make(chan *model.Event, 10)
+	result := make(chan *int)
+	counts := []int{5, 9} // The second query corrects the count.
+	queries := 0
+	go sendCount(ctx, "test-sub", input, receiver, result, time.Minute, func() (int, error) {
+		queries++
+		return counts[queries-1], nil
+	})
+
+	assert.Equal(t, 5, receiveCount(t, result))
+
+	receiver <- &model.Event{Operation: "INSERT", NewData: newPodData("Running")}
+	assert.Equal(t, 6, receiveCount(t, result))
+
+	receiver <- &model.Event{Operation: "DELETE"} // Truncated, counts again.
+	assert.Equal(t, 9, receiveCount(t, result))
+}
+
+func TestWatchCountSubscription_Disabled(t *testing.T) {
+	originalEnabled := config.Cfg.Features.SubscriptionEnabled
+	defer func() {
+		config.Cfg.Features.SubscriptionEnabled = originalEnabled
+	}()
+	config.Cfg.Features.SubscriptionEnabled = false
+
+	_, err := WatchCountSubscription(context.Background(), nil)
+
+	assert.EqualError(t, err,
+		"GraphQL subscription feature is disabled. To enable set env variable FEATURE_SUBSCRIPTION=true")
+}
+
+func Test_sendCount_SkipsCountedChanges(t *testing.T) {
+	ctx := rbac.CreateTestContext("test-user-1", "testuser1")
+	userData := rbac.CreateTestUserWatchData("watch", "v1", "pods", "foo", true, time.Now(), 1*time.Minute)
+	rbac.SetupWatchCacheWithUserData(ctx, userData)
+	defer rbac.CleanupWatchCache(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	kind := "Pod"
+	input := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&kind}}}}
+	counted, after := database.LastEventSeq(), database.LastEventSeq()+1
+	receiver := make(chan *model.Event, 10)
+	// Received after registering, before counting.
+	receiver <- &model.Event{Operation: "INSERT", NewData: newPodData("Running"), Seq: &counted}
+	result := make(chan *int)
+	go sendCount(ctx, "test-sub", input, receiver, result, time.Minute, func() (int, error) {
+		return 5, nil
+	})
+
+	assert.Equal(t, 5, receiveCount(t, result))
+
+	receiver <- &model.Event{Operation: "INSERT", NewData: newPodData("Running"), Seq: &after}
+	assert.Equal(t, 6, receiveCount(t, result), "Only the change after the count should be added.")
+}
+
+func TestWatchCountSubscription_NoFilter(t *testing.T) {
+	originalEnabled := config.Cfg.Features.SubscriptionEnabled
+	t.Cleanup(func() { config.Cfg.Features.SubscriptionEnabled = originalEnabled })
+	config.Cfg.Features.SubscriptionEnabled = true
+
+	_, err := WatchCountSubscription(context.Background(), nil)
+	assert.EqualError(t, err, "invalid input. watchCount requires a filter or keyword")
+
+	_, err = WatchCountSubscription(context.Background(), &model.SearchInput{})
+	assert.EqualError(t, err, "invalid input. watchCount requires a filter or keyword")
+}
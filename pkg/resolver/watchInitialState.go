@@ -0,0 +1,215 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/database"
+	"github.com/stolostron/search-v2-api/pkg/metrics"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	klog "k8s.io/klog/v2"
+)
+
+const initialStatePageSize = 1000 // Resources listed by each query of the initial state.
+
+// The initial state of a query of the watch.
+type initialStateQuery struct {
+	search *SearchResult
+	end    context.CancelFunc // Ends the snapshot transaction of the search.
+	names  []string           // Set in the ADDED events of named queries.
+}
+
+// Builds the search results to list the initial state of each query. Each query is listed in its own snapshot
+// transaction, which ends when the query is listed, so only one connection is used at a time.
+func newInitialStates(ctx context.Context, queries *watchQueries) ([]*initialStateQuery, error) {
+	states := make([]*initialStateQuery, 0, len(queries.queries))
+	for _, query := range queries.queries {
+		listCtx, listCancel := context.WithCancel(ctx)
+		search, err := newInitialStateResult(listCtx, query.Input)
+		if err != nil {
+			listCancel()
+			for _, state := range states {
+				state.end()
+			}
+			return nil, err
+		}
+		state := &initialStateQuery{search: search, end: listCancel}
+		if queries.named {
+			state.names = []string{query.Name}
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// Builds the search result to list the initial state of a watch. The resources are listed in pages ordered by
+// uid, in a read-only snapshot transaction so the pages are consistent. The transaction ends when the context
+// is cancelled.
+func newInitialStateResult(ctx context.Context, input *model.SearchInput) (*SearchResult, error) {
+	userData, err := rbac.GetCache().GetUserData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	propTypes, err := getPropertyType(ctx, false)
+	if err != nil {
+		klog.Warningf("Error creating datatype map. Error: [%s] ", err)
+	}
+
+	pageInput := *input
+	limit, orderBy := initialStatePageSize, "uid asc"
+	pageInput.Limit, pageInput.Offset, pageInput.OrderBy = &limit, nil, &orderBy
+	return &SearchResult{
+		context:   ctx,
+		input:     &pageInput,
+		pool:      database.GetConnPool(ctx),
+		propTypes: propTypes,
+		snapshot:  true,
+		userData:  userData,
+	}, nil
+}
+
+// Lists the resources matching each query and sends them to the client as ADDED events, then sends a SYNCED
+// event followed by the changes received while listing. The changes are filtered like any other change, and
+// queued with the overflow policy of the subscription, see watchQueue.
+// Returns false if the subscription was closed or the initial state couldn't be listed.
+func sendInitialState(ctx context.Context, subID string, states []*initialStateQuery, queries *watchQueries,
+	overflow *model.WatchOverflow, receiver <-chan *model.Event, result chan<- *model.Event) bool {
+	// Changes after this sequence number are sent after the SYNCED event, or included in the initial state.
+	seq := database.LastEventSeq()
+	listCtx, listCancel := context.WithCancel(ctx)
+	defer listCancel()
+	listed := make(chan error, 1)
+	go func() {
+		var err error
+		defer func() {
+			// The snapshots end before the listing is done, including the queries not listed after an error.
+			for _, state := range states {
+				state.end()
+			}
+			listed <- err
+		}()
+		for _, state := range states {
+			if err = state.search.listInitialState(listCtx, state.names, result); err != nil {
+				return
+			}
+			state.end()
+		}
+	}()
+
+	// Queue the changes received while listing, the listener drops the events when the receiver is full.
+	changes := newWatchQueue(subID, overflow, seq)
+	defer changes.close()
+	// Stops listing when the subscription is closed by the overflow policy. The result channel is closed after
+	// returning, so the listing must be done.
+	closeListing := func() bool {
+		listCancel()
+		<-listed
+		return false
+	}
+	for listing := true; listing; {
+		select {
+		case err := <-listed:
+			if err != nil {
+				klog.Errorf("Subscription watch(%s) failed to list the initial state. %v", subID, err)
+				return false
+			}
+			listing = false
+		case event, ok := <-receiver:
+			if !ok {
+				continue
+			}
+			if dropped := database.TakeDroppedEvents(subID); dropped > 0 && !changes.overflow(dropped) {
+				return closeListing()
+			}
+			if matched, ok := watchMatches(ctx, subID, queries, event); ok && !changes.push(matched) {
+				return closeListing()
+			}
+		}
+	}
+	klog.V(3).Infof("Subscription watch(%s) sent the initial state. Sending %d changes received while listing.",
+		subID, len(changes.events))
+
+	synced := &model.Event{Operation: eventSynced, Seq: &seq, Timestamp: time.Now().UTC().Format(time.RFC3339)}
+	select {
+	case result <- synced:
+	case <-ctx.Done():
+		return false
+	}
+	// RESYNC is queued after the changes if some were dropped.
+	for event := changes.next(); event != nil; event = changes.next() {
+		select {
+		case result <- event:
+			changes.pop()
+			database.UpdateSubscriptionActivity(subID)
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// Sends the resources matching the input as ADDED events, with the names of the query. The resources are sent
+// in uid order, waiting for the client to receive them instead of dropping them.
+func (s *SearchResult) listInitialState(ctx context.Context, queries []string, result chan<- *model.Event) error {
+	if !s.matchesManagedHubFilter() { // if current hub is not part of managedHub filter, there's no initial state
+		return nil
+	}
+	s.mutex.Lock()
+	s.beginSnapshot()
+	s.mutex.Unlock()
+
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	lastUID := ""
+	for {
+		s.extraWhere = []exp.Expression{goqu.C("uid").Gt(lastUID)}
+		if err := s.buildSearchQuery(s.context, false, false); err != nil {
+			return err
+		}
+		events, err := s.resolveInitialStatePage(timestamp)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			event.Queries = queries
+			select {
+			case result <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if len(events) < initialStatePageSize {
+			return nil
+		}
+		lastUID = events[len(events)-1].UID
+	}
+}
+
+// Runs the query for a page of the initial state. The events have the data as stored in the search index,
+// same as the changes.
+func (s *SearchResult) resolveInitialStatePage(timestamp string) ([]*model.Event, error) {
+	timer := prometheus.NewTimer(metrics.DBQueryDuration.WithLabelValues("listInitialState"))
+	defer timer.ObserveDuration()
+	rows, err := s.pool.Query(s.context, s.query, s.params...)
+	if err != nil {
+		klog.Errorf("Error resolving query [%s] with args [%+v]. Error: [%+v]", s.query, s.params, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*model.Event{}
+	for rows.Next() {
+		var uid, cluster string
+		var data map[string]interface{}
+		if err := rows.Scan(&uid, &cluster, &data); err != nil {
+			klog.Errorf("Error %s retrieving rows for query:%s", err.Error(), s.query)
+			return nil, err
+		}
+		events = append(events, &model.Event{UID: uid, Operation: eventAdded, NewData: data, Timestamp: timestamp})
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,151 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+// Builds a search result to list the initial state, same as newInitialStateResult() without the snapshot.
+func newMockInitialStateResult(t *testing.T, ctx context.Context, input *model.SearchInput) *SearchResult {
+	csRes, nsRes, managedClusters := newUserData()
+	ud := rbac.UserData{CsResources: csRes, NsResources: nsRes, ManagedClusters: managedClusters}
+	pageInput := *input
+	limit, orderBy := initialStatePageSize, "uid asc"
+	pageInput.Limit, pageInput.OrderBy = &limit, &orderBy
+
+	s, mockPool := newMockSearchResolver(t, &pageInput, nil, ud, map[string]string{"kind": "string"})
+	s.context = ctx
+	mockRows := &MockRows{
+		mockData: []map[string]interface{}{
+			{"uid": "local-cluster/pod-1", "cluster": "local-cluster", "data": map[string]interface{}{
+				"kind": "Pod", "name": "pod-1", "namespace": "foo", "apigroup": "v1", "kind_plural": "pods",
+				"cluster": "local-cluster", "_hubClusterResource": true}},
+		},
+		columnHeaders: []string{"uid", "cluster", "data"},
+	}
+	mockPool.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRows, nil)
+	return s
+}
+
+func Test_listInitialState(t *testing.T) {
+	kind := "Pod"
+	input := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&kind}}}}
+	s := newMockInitialStateResult(t, context.Background(), input)
+	result := make(chan *model.Event, 10)
+
+	err := s.listInitialState(context.Background(), nil, result)
+
+	assert.NoError(t, err)
+	assert.Contains(t, s.query, `("uid" > $`)
+	assert.Contains(t, s.query, `ORDER BY "uid" ASC LIMIT $`)
+	assert.Equal(t, int64(initialStatePageSize), s.params[len(s.params)-1])
+	assert.Len(t, result, 1)
+	event := <-result
+	assert.Equal(t, "local-cluster/pod-1", event.UID)
+	assert.Equal(t, eventAdded, event.Operation)
+	assert.Equal(t, "pod-1", event.NewData["name"])
+}
+
+func Test_sendInitialState_ChangesAfterSynced(t *testing.T) {
+	ctx := rbac.CreateTestContext("test-user-1", "testuser1")
+	userData := rbac.CreateTestUserWatchData("watch", "v1", "pods", "foo", true, time.Now(), 1*time.Minute)
+	rbac.SetupWatchCacheWithUserData(ctx, userData)
+	defer rbac.CleanupWatchCache(ctx)
+
+	kind := "Pod"
+	input := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&kind}}}}
+	s := newMockInitialStateResult(t, ctx, input)
+
+	// A change received while listing the initial state.
+	receiver := make(chan *model.Event, 10)
+	receiver <- &model.Event{UID: "local-cluster/pod-2", Operation: "INSERT", NewData: map[string]interface{}{
+		"kind": "Pod", "name": "pod-2", "namespace": "foo", "apigroup": "v1", "kind_plural": "pods",
+		"cluster": "local-cluster", "_hubClusterResource": true}}
+	result := make(chan *model.Event) // Unbuffered, listing waits for the client.
+
+	done := make(chan bool)
+	go func() {
+		states := []*initialStateQuery{{search: s, end: func() {}}}
+		done <- sendInitialState(ctx, "test-sub", states, watchInput(input), nil, receiver, result)
+	}()
+	time.Sleep(20 * time.Millisecond) // Let the change be received while listing.
+
+	operations := []string{}
+	for i := 0; i < 3; i++ {
+		select {
+		case event := <-result:
+			operations = append(operations, event.Operation+" "+event.UID)
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for events.")
+		}
+	}
+
+	assert.True(t, <-done)
+	assert.Equal(t, []string{"ADDED local-cluster/pod-1", "SYNCED ", "INSERT local-cluster/pod-2"}, operations)
+}
+
+func Test_sendInitialState_Overflow(t *testing.T) {
+	ctx := rbac.CreateTestContext("test-user-1", "testuser1")
+	userData := rbac.CreateTestUserWatchData("watch", "v1", "pods", "foo", true, time.Now(), 1*time.Minute)
+	rbac.SetupWatchCacheWithUserData(ctx, userData)
+	defer rbac.CleanupWatchCache(ctx)
+
+	kind := "Pod"
+	input := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&kind}}}}
+	for _, policy := range []model.WatchOverflow{model.WatchOverflowDropAndNotify, model.WatchOverflowDisconnect} {
+		s := newMockInitialStateResult(t, ctx, input)
+		// More changes received while listing than the queue holds.
+		receiver := make(chan *model.Event, watchQueueSize+1)
+		for i := 0; i <= watchQueueSize; i++ {
+			receiver <- newPodEvent(fmt.Sprintf("local-cluster/pod-%d", i), "foo")
+		}
+		result := make(chan *model.Event) // Unbuffered, listing waits for the client.
+
+		done := make(chan bool, 1)
+		go func() {
+			states := []*initialStateQuery{{search: s, end: func() {}}}
+			done <- sendInitialState(ctx, "test-sub", states, watchInput(input), &policy, receiver, result)
+		}()
+		// Let the changes be received while listing.
+		for deadline := time.Now().Add(time.Second); len(receiver) > 0 && time.Now().Before(deadline); {
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		operations := []string{}
+		for receiving := true; receiving; {
+			select {
+			case event := <-result:
+				operations = append(operations, event.Operation)
+			case sent := <-done:
+				assert.Equal(t, policy == model.WatchOverflowDropAndNotify, sent, "policy %s", policy)
+				receiving = false
+			case <-time.After(time.Second):
+				t.Fatal("Timed out waiting for events.")
+			}
+		}
+
+		if policy == model.WatchOverflowDisconnect {
+			assert.NotContains(t, operations, eventSynced, "Expected the subscription to close while listing.")
+			continue
+		}
+		assert.Len(t, operations, watchQueueSize+3)
+		assert.Equal(t, []string{"ADDED", "SYNCED"}, operations[:2])
+		assert.Equal(t, eventResync, operations[len(operations)-1], "Expected RESYNC after the dropped change.")
+	}
+}
+
+func TestWatchSubscription_InitialStateRequiresFilter(t *testing.T) {
+	initialState := true
+
+	_, err := WatchSubscription(context.Background(), &model.SearchInput{}, &initialState, nil, nil, nil, nil, nil)
+
+	assert.EqualError(t, err, "invalid input. initialState requires a filter or keyword")
+}
@@ -0,0 +1,139 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"slices"
+	"time"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/database"
+	"github.com/stolostron/search-v2-api/pkg/metrics"
+	klog "k8s.io/klog/v2"
+)
+
+const watchQueueSize = 100 // Events of a watch waiting for the client to receive them.
+
+// Events of a watch waiting for the client to receive them. When the client doesn't keep up and the queue is
+// full, the overflow policy of the subscription decides what happens with the events.
+type watchQueue struct {
+	subID   string
+	policy  model.WatchOverflow
+	events  []*queuedEvent
+	pending map[string]*queuedEvent // With COALESCE, the queued event of each resource (uid).
+	resync  bool                    // Events were dropped, RESYNC is queued when there's space.
+	lastSeq int                     // Sequence number of the last event queued or dropped.
+}
+
+type queuedEvent struct {
+	event *model.Event
+}
+
+func newWatchQueue(subID string, policy *model.WatchOverflow, lastSeq int) *watchQueue {
+	q := &watchQueue{subID: subID, policy: model.WatchOverflowDropAndNotify, lastSeq: lastSeq}
+	if policy != nil {
+		q.policy = *policy
+	}
+	if q.policy == model.WatchOverflowCoalesce {
+		q.pending = map[string]*queuedEvent{}
+	}
+	return q
+}
+
+// Returns the next event to send to the client, or nil if the queue is empty.
+func (q *watchQueue) next() *model.Event {
+	if len(q.events) == 0 {
+		return nil
+	}
+	return q.events[0].event
+}
+
+// Removes the event sent to the client. Queues RESYNC if events were dropped.
+func (q *watchQueue) pop() {
+	item := q.events[0]
+	q.events[0] = nil
+	q.events = q.events[1:]
+	if q.pending[item.event.UID] == item {
+		delete(q.pending, item.event.UID)
+	}
+	q.queueResync()
+}
+
+// Adds an event matching the watch to the queue. Returns false if the subscription must be closed.
+func (q *watchQueue) push(event *model.Event) bool {
+	if queued, ok := q.pending[event.UID]; ok {
+		// The coalesced event has the seq of the latest event, so it moves to the tail. The events stay in seq
+		// order, and a client resuming from its seq doesn't skip the events queued before it.
+		q.events = slices.DeleteFunc(q.events, func(item *queuedEvent) bool { return item == queued })
+		delete(q.pending, event.UID)
+		event = coalesceEvents(queued.event, event)
+		metrics.SubscriptionEventsCoalesced.WithLabelValues(q.subID).Inc()
+	}
+	q.queueResync() // Before the event, resuming from RESYNC must not skip it.
+	q.setLastSeq(event)
+	if len(q.events) >= watchQueueSize {
+		return q.overflow(1)
+	}
+	item := &queuedEvent{event: event}
+	q.events = append(q.events, item)
+	if q.pending != nil {
+		q.pending[event.UID] = item
+	}
+	return true
+}
+
+func (q *watchQueue) setLastSeq(event *model.Event) {
+	if event.Seq != nil {
+		q.lastSeq = *event.Seq
+	}
+}
+
+// Handles events that didn't fit in the queue, or in the channel from the listener. Returns false if the
+// subscription must be closed.
+func (q *watchQueue) overflow(dropped int) bool {
+	metrics.SubscriptionEventsDropped.WithLabelValues(q.subID).Add(float64(dropped))
+	if q.policy == model.WatchOverflowDisconnect {
+		klog.Warningf("Subscription watch(%s) client isn't receiving the events fast enough, closing.", q.subID)
+		return false
+	}
+	if !q.resync {
+		klog.Warningf("Subscription watch(%s) channel buffer is full, dropping events. RESYNC is sent when there's space.",
+			q.subID)
+	}
+	q.resync = true
+	return true
+}
+
+// Queues the RESYNC event after events were dropped, if there's space.
+func (q *watchQueue) queueResync() {
+	if !q.resync || len(q.events) >= watchQueueSize {
+		return
+	}
+	seq := q.lastSeq
+	if seq == 0 {
+		seq = database.LastEventSeq()
+	}
+	event := &model.Event{Operation: eventResync, Seq: &seq, Timestamp: time.Now().UTC().Format(time.RFC3339)}
+	q.events = append(q.events, &queuedEvent{event: event})
+	q.resync = false
+}
+
+// Removes the metrics of the subscription.
+func (q *watchQueue) close() {
+	metrics.SubscriptionEventsDropped.DeleteLabelValues(q.subID)
+	metrics.SubscriptionEventsCoalesced.DeleteLabelValues(q.subID)
+}
+
+// Returns the event to send instead of two events for the same resource.
+// A resource inserted and updated is sent as INSERT, and a resource updated twice keeps the first oldData.
+func coalesceEvents(queued, event *model.Event) *model.Event {
+	coalesced := *event
+	switch {
+	case queued.Operation == "INSERT" && event.Operation == "UPDATE":
+		coalesced.Operation, coalesced.OldData = "INSERT", nil
+	case queued.Operation == "UPDATE" && event.Operation == "UPDATE":
+		coalesced.OldData = queued.OldData
+	default:
+		return event
+	}
+	return &coalesced
+}
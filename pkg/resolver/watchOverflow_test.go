@@ -0,0 +1,135 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newQueueEvent(uid, operation string, seq int) *model.Event {
+	return &model.Event{UID: uid, Operation: operation, Seq: &seq,
+		NewData: map[string]any{"seq": seq}, OldData: map[string]any{"seq": seq - 1}}
+}
+
+// Sends the queued events to the client.
+func drainQueue(q *watchQueue) []*model.Event {
+	events := []*model.Event{}
+	for q.next() != nil {
+		events = append(events, q.next())
+		q.pop()
+	}
+	return events
+}
+
+func Test_watchQueue_DropAndNotify(t *testing.T) {
+	q := newWatchQueue("test-sub", nil, 0)
+	defer q.close()
+	for i := 1; i <= watchQueueSize+2; i++ {
+		assert.True(t, q.push(newQueueEvent(fmt.Sprintf("uid-%d", i), "INSERT", i)))
+	}
+	assert.Len(t, q.events, watchQueueSize)
+
+	q.pop() // Space returns, used by RESYNC.
+	q.pop()
+	assert.True(t, q.push(newQueueEvent("uid-after", "INSERT", watchQueueSize+3)))
+
+	events := drainQueue(q)
+	assert.Len(t, events, watchQueueSize)
+	resync := events[watchQueueSize-2]
+	assert.Equal(t, eventResync, resync.Operation)
+	assert.Equal(t, watchQueueSize+2, *resync.Seq) // The last event dropped.
+	assert.Equal(t, "uid-after", events[watchQueueSize-1].UID)
+}
+
+func Test_watchQueue_Coalesce(t *testing.T) {
+	policy := model.WatchOverflowCoalesce
+	q := newWatchQueue("test-sub", &policy, 0)
+	defer q.close()
+
+	q.push(newQueueEvent("a", "INSERT", 1))
+	q.push(newQueueEvent("b", "UPDATE", 2))
+	q.push(newQueueEvent("a", "UPDATE", 3))
+	q.push(newQueueEvent("b", "UPDATE", 4))
+	q.push(newQueueEvent("c", "UPDATE", 5))
+	q.push(newQueueEvent("c", "DELETE", 6))
+
+	events := drainQueue(q)
+	assert.Len(t, events, 3)
+	assert.Equal(t, "INSERT", events[0].Operation)
+	assert.Equal(t, 3, events[0].NewData["seq"])
+	assert.Nil(t, events[0].OldData)
+	assert.Equal(t, "UPDATE", events[1].Operation)
+	assert.Equal(t, 4, events[1].NewData["seq"])
+	assert.Equal(t, 1, events[1].OldData["seq"]) // From the first update.
+	assert.Equal(t, "DELETE", events[2].Operation)
+
+	// After it's sent, the next event for the resource is queued.
+	q.push(newQueueEvent("a", "UPDATE", 7))
+	assert.Len(t, q.events, 1)
+}
+
+// A client resuming from the seq of a coalesced event must have received the events queued before it.
+func Test_watchQueue_CoalesceResume(t *testing.T) {
+	policy := model.WatchOverflowCoalesce
+	q := newWatchQueue("test-sub", &policy, 0)
+	defer q.close()
+
+	q.push(newQueueEvent("a", "INSERT", 1))
+	q.push(newQueueEvent("b", "UPDATE", 2))
+	q.push(newQueueEvent("a", "UPDATE", 3))
+
+	// The client disconnects after receiving the coalesced event, and resumes with since: 3.
+	received := []string{}
+	for q.next() != nil {
+		event := q.next()
+		q.pop()
+		received = append(received, fmt.Sprintf("%s %d", event.UID, *event.Seq))
+		if *event.Seq == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"b 2", "a 3"}, received)
+	assert.Nil(t, q.next(), "No event before seq 3 should be left to send.")
+}
+
+func Test_watchQueue_CoalesceFull(t *testing.T) {
+	policy := model.WatchOverflowCoalesce
+	q := newWatchQueue("test-sub", &policy, 0)
+	defer q.close()
+	for i := 1; i <= watchQueueSize; i++ {
+		q.push(newQueueEvent(fmt.Sprintf("uid-%d", i), "UPDATE", i))
+	}
+
+	q.push(newQueueEvent("uid-1", "UPDATE", watchQueueSize+1)) // Coalesced.
+	assert.False(t, q.resync)
+	q.push(newQueueEvent("uid-new", "UPDATE", watchQueueSize+2)) // Dropped.
+	assert.True(t, q.resync)
+}
+
+func Test_watchQueue_Disconnect(t *testing.T) {
+	policy := model.WatchOverflowDisconnect
+	q := newWatchQueue("test-sub", &policy, 0)
+	defer q.close()
+	for i := 1; i <= watchQueueSize; i++ {
+		assert.True(t, q.push(newQueueEvent(fmt.Sprintf("uid-%d", i), "INSERT", i)))
+	}
+
+	assert.False(t, q.push(newQueueEvent("uid-new", "INSERT", watchQueueSize+1)))
+}
+
+func Test_watchQueue_DroppedByListener(t *testing.T) {
+	q := newWatchQueue("test-sub", nil, 10)
+	defer q.close()
+
+	assert.True(t, q.overflow(2))
+	q.push(newQueueEvent("a", "INSERT", 13))
+
+	events := drainQueue(q)
+	assert.Len(t, events, 2)
+	assert.Equal(t, eventResync, events[0].Operation)
+	assert.Equal(t, 10, *events[0].Seq) // The event before the dropped events.
+	assert.Equal(t, "a", events[1].UID)
+}
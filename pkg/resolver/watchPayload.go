@@ -0,0 +1,139 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+)
+
+// Options to reduce the events sent by a watch, for clients that don't need the full data.
+type watchPayload struct {
+	diff       model.WatchDiff
+	properties map[string]bool // Projection. Nil to send all the properties.
+}
+
+// Returns nil if the events are sent with the full data.
+func newWatchPayload(diff *model.WatchDiff, properties []*string) *watchPayload {
+	payload := &watchPayload{diff: model.WatchDiffNone}
+	if diff != nil {
+		payload.diff = *diff
+	}
+	for _, property := range properties {
+		if property == nil {
+			continue
+		}
+		if payload.properties == nil {
+			payload.properties = map[string]bool{}
+		}
+		payload.properties[*property] = true
+	}
+	if payload.diff == model.WatchDiffNone && payload.properties == nil {
+		return nil
+	}
+	return payload
+}
+
+// Returns the event to send, or nil if it's an UPDATE that doesn't change the properties sent.
+// An UPDATE without oldData, because the notification was truncated, is sent with the full newData.
+func (p *watchPayload) apply(event *model.Event) *model.Event {
+	if event.UID == "" { // SYNCED and RESYNC
+		return event
+	}
+	reduced := *event
+	reduced.NewData = p.project(event.NewData)
+	reduced.OldData = p.project(event.OldData)
+	if event.Operation != "UPDATE" || reduced.NewData == nil || reduced.OldData == nil {
+		return &reduced
+	}
+
+	changed := changedKeys(reduced.OldData, reduced.NewData)
+	if len(changed) == 0 {
+		return nil
+	}
+	switch p.diff {
+	case model.WatchDiffChangedKeys:
+		reduced.Changes = map[string]any{}
+		for _, key := range changed {
+			reduced.Changes[key] = reduced.NewData[key] // Nil if removed.
+		}
+		reduced.NewData, reduced.OldData = nil, nil
+	case model.WatchDiffJSONPatch:
+		reduced.Patch = jsonPatch("", reduced.OldData, reduced.NewData)
+		reduced.NewData, reduced.OldData = nil, nil
+	}
+	return &reduced
+}
+
+// Applies the options to the events, removing the events that aren't sent.
+func (p *watchPayload) applyAll(events []*model.Event) []*model.Event {
+	result := make([]*model.Event, 0, len(events))
+	for _, event := range events {
+		if event = p.apply(event); event != nil {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// Returns the data with only the properties requested.
+func (p *watchPayload) project(data map[string]any) map[string]any {
+	if data == nil || p.properties == nil {
+		return data
+	}
+	projected := make(map[string]any, len(p.properties))
+	for key, value := range data {
+		if p.properties[key] {
+			projected[key] = value
+		}
+	}
+	return projected
+}
+
+// Returns the sorted keys that were added, removed or changed.
+func changedKeys(oldData, newData map[string]any) []string {
+	changed := []string{}
+	for key, value := range newData {
+		if oldValue, ok := oldData[key]; !ok || !reflect.DeepEqual(oldValue, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range oldData {
+		if _, ok := newData[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// Returns the JSON patch (RFC 6902) operations to change oldData to newData. Nested maps, like labels, are
+// patched by key instead of replaced.
+func jsonPatch(path string, oldData, newData map[string]any) []*model.PatchOperation {
+	patch := []*model.PatchOperation{}
+	for _, key := range changedKeys(oldData, newData) {
+		keyPath := path + "/" + escapeJSONPointer(key)
+		oldValue, inOld := oldData[key]
+		newValue, inNew := newData[key]
+		oldMap, oldIsMap := oldValue.(map[string]any)
+		newMap, newIsMap := newValue.(map[string]any)
+		switch {
+		case !inNew:
+			patch = append(patch, &model.PatchOperation{Op: "remove", Path: keyPath})
+		case !inOld:
+			patch = append(patch, &model.PatchOperation{Op: "add", Path: keyPath, Value: newValue})
+		case oldIsMap && newIsMap:
+			patch = append(patch, jsonPatch(keyPath, oldMap, newMap)...)
+		default:
+			patch = append(patch, &model.PatchOperation{Op: "replace", Path: keyPath, Value: newValue})
+		}
+	}
+	return patch
+}
+
+// Escapes a key for a JSON pointer (RFC 6901), for example the label key app.kubernetes.io/name.
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
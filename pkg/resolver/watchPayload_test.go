@@ -0,0 +1,87 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"testing"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newUpdateEvent() *model.Event {
+	return &model.Event{UID: "local-cluster/pod-1", Operation: "UPDATE",
+		OldData: map[string]any{"name": "pod-1", "status": "Pending", "restarts": 0, "ip": "10.0.0.1",
+			"label": map[string]any{"app": "a", "app.kubernetes.io/name": "a"}},
+		NewData: map[string]any{"name": "pod-1", "status": "Running", "restarts": 0, "node": "node-1",
+			"label": map[string]any{"app": "b", "app.kubernetes.io/name": "a", "tier": "web"}},
+	}
+}
+
+func Test_newWatchPayload_FullData(t *testing.T) {
+	none := model.WatchDiffNone
+
+	assert.Nil(t, newWatchPayload(nil, nil))
+	assert.Nil(t, newWatchPayload(&none, []*string{}))
+}
+
+func Test_watchPayload_ChangedKeys(t *testing.T) {
+	diff := model.WatchDiffChangedKeys
+	event := newUpdateEvent()
+
+	reduced := newWatchPayload(&diff, nil).apply(event)
+
+	assert.Nil(t, reduced.NewData)
+	assert.Nil(t, reduced.OldData)
+	assert.Equal(t, map[string]any{"status": "Running", "node": "node-1", "ip": nil,
+		"label": map[string]any{"app": "b", "app.kubernetes.io/name": "a", "tier": "web"}}, reduced.Changes)
+	assert.NotNil(t, event.NewData, "The shared event shouldn't be modified.")
+}
+
+func Test_watchPayload_JSONPatch(t *testing.T) {
+	diff := model.WatchDiffJSONPatch
+
+	reduced := newWatchPayload(&diff, nil).apply(newUpdateEvent())
+
+	assert.Nil(t, reduced.NewData)
+	assert.Equal(t, []*model.PatchOperation{
+		{Op: "remove", Path: "/ip"},
+		{Op: "replace", Path: "/label/app", Value: "b"},
+		{Op: "add", Path: "/label/tier", Value: "web"},
+		{Op: "add", Path: "/node", Value: "node-1"},
+		{Op: "replace", Path: "/status", Value: "Running"},
+	}, reduced.Patch)
+}
+
+func Test_watchPayload_Projection(t *testing.T) {
+	status, restarts := "status", "restarts"
+	payload := newWatchPayload(nil, []*string{&status, &restarts})
+
+	reduced := payload.apply(newUpdateEvent())
+	assert.Equal(t, map[string]any{"status": "Running", "restarts": 0}, reduced.NewData)
+	assert.Equal(t, map[string]any{"status": "Pending", "restarts": 0}, reduced.OldData)
+
+	// The properties selected didn't change.
+	unchanged := newUpdateEvent()
+	unchanged.NewData["status"] = "Pending"
+	assert.Nil(t, payload.apply(unchanged))
+
+	insert := &model.Event{UID: "local-cluster/pod-2", Operation: "INSERT", NewData: map[string]any{"name": "pod-2",
+		"status": "Running"}}
+	assert.Equal(t, map[string]any{"status": "Running"}, payload.apply(insert).NewData)
+}
+
+func Test_watchPayload_TruncatedOldData(t *testing.T) {
+	diff := model.WatchDiffChangedKeys
+	event := newUpdateEvent()
+	event.OldData = nil
+
+	reduced := newWatchPayload(&diff, nil).apply(event)
+
+	assert.Equal(t, event.NewData, reduced.NewData)
+	assert.Nil(t, reduced.Changes)
+}
+
+func Test_escapeJSONPointer(t *testing.T) {
+	assert.Equal(t, "app.kubernetes.io~1name", escapeJSONPointer("app.kubernetes.io/name"))
+	assert.Equal(t, "a~0b", escapeJSONPointer("a~b"))
+}
@@ -0,0 +1,112 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	klog "k8s.io/klog/v2"
+)
+
+const maxWatchQueries = 50
+
+// The queries of a watch: the input of watch(input), or the named inputs of watch(queries). The events are
+// matched against each query, then checked once with the user's RBAC.
+type watchQueries struct {
+	queries []*model.WatchQuery
+	filters []*eventFilter // The filter of each query, parsed once.
+	named   bool           // The events are sent with the names of the queries they match.
+}
+
+// Returns the unnamed query of watch(input).
+func watchInput(input *model.SearchInput) *watchQueries {
+	return &watchQueries{queries: []*model.WatchQuery{{Input: input}}, filters: []*eventFilter{newEventFilter(input)}}
+}
+
+// Validates the input or the named queries. Only one of them can be used.
+func newWatchQueries(input *model.SearchInput, queries []*model.WatchQuery) (*watchQueries, error) {
+	if len(queries) == 0 {
+		if err := validateInputFilters(input); err != nil {
+			return nil, err
+		}
+		return watchInput(input), nil
+	}
+	if input != nil {
+		return nil, errors.New("invalid input. Use input or queries, not both")
+	}
+	if len(queries) > maxWatchQueries {
+		return nil, fmt.Errorf("invalid input. A watch can have up to %d queries", maxWatchQueries)
+	}
+	names := map[string]bool{}
+	filters := make([]*eventFilter, 0, len(queries))
+	for _, query := range queries {
+		if query == nil || query.Name == "" {
+			return nil, errors.New("invalid input. The query name is required")
+		}
+		if names[query.Name] {
+			return nil, fmt.Errorf("invalid input. Duplicate query name: %s", query.Name)
+		}
+		names[query.Name] = true
+		if err := validateInputFilters(query.Input); err != nil {
+			return nil, err
+		}
+		filters = append(filters, newEventFilter(query.Input))
+	}
+	return &watchQueries{queries: queries, filters: filters, named: true}, nil
+}
+
+// Returns an error if a query of the initial state doesn't have a filter or keyword.
+func (q *watchQueries) validateInitialState() error {
+	for _, query := range q.queries {
+		if !hasFilterOrKeyword(query.Input) {
+			return errors.New("invalid input. initialState requires a filter or keyword")
+		}
+	}
+	return nil
+}
+
+// The queries of search require a filter or keyword, see buildSearchQuery().
+func hasFilterOrKeyword(input *model.SearchInput) bool {
+	return input != nil && (len(input.Filters) > 0 || len(input.Keywords) > 0)
+}
+
+// Returns the event to send if it matches any of the queries, with the names of the queries matched.
+func (q *watchQueries) match(event *model.Event) (*model.Event, bool) {
+	if !q.named {
+		return event, eventMatchesAllFilters(event, q.filters[0])
+	}
+	var names []string
+	for i, query := range q.queries {
+		if eventMatchesAllFilters(event, q.filters[i]) {
+			names = append(names, query.Name)
+		}
+	}
+	if names == nil {
+		return nil, false
+	}
+	tagged := *event
+	tagged.Queries = names
+	return &tagged, true
+}
+
+// Returns the event to send if it matches the queries and the user's RBAC.
+func watchMatches(ctx context.Context, subID string, queries *watchQueries, event *model.Event) (*model.Event, bool) {
+	// Filter event based on the input filters
+	matched, ok := queries.match(event)
+	if !ok {
+		klog.V(4).Infof("Subscription watch(%s) event did not match filters (UID: %s, Operation: %s)",
+			subID, event.UID, event.Operation)
+		return nil, false
+	}
+
+	// Filter events based on user RBAC, once for all the queries.
+	// Use the subscription context so cancellation aborts in-flight permission checks
+	if !eventMatchesRbac(ctx, event) {
+		klog.V(4).Infof("Subscription watch(%s) event did not match RBAC filters (UID: %s, Operation: %s)",
+			subID, event.UID, event.Operation)
+		return nil, false
+	}
+	return matched, true
+}
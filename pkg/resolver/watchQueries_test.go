@@ -0,0 +1,124 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+func newWatchQuery(name, property, value string) *model.WatchQuery {
+	return &model.WatchQuery{Name: name, Input: &model.SearchInput{Filters: []*model.SearchFilter{
+		{Property: property, Values: []*string{&value}}}}}
+}
+
+func newPodEvent(uid, namespace string) *model.Event {
+	return &model.Event{UID: uid, Operation: "INSERT", NewData: map[string]any{"kind": "Pod", "name": uid,
+		"namespace": namespace, "apigroup": "v1", "kind_plural": "pods", "cluster": "local-cluster",
+		"_hubClusterResource": true}}
+}
+
+func Test_newWatchQueries_Invalid(t *testing.T) {
+	pods, nsFoo := newWatchQuery("pods", "kind", "Pod"), newWatchQuery("foo", "namespace", "foo")
+	tooMany := []*model.WatchQuery{}
+	for i := 0; i <= maxWatchQueries; i++ {
+		tooMany = append(tooMany, newWatchQuery(fmt.Sprintf("q%d", i), "kind", "Pod"))
+	}
+
+	_, err := newWatchQueries(&model.SearchInput{}, []*model.WatchQuery{pods})
+	assert.EqualError(t, err, "invalid input. Use input or queries, not both")
+	_, err = newWatchQueries(nil, []*model.WatchQuery{pods, {Input: nsFoo.Input}})
+	assert.EqualError(t, err, "invalid input. The query name is required")
+	_, err = newWatchQueries(nil, []*model.WatchQuery{pods, newWatchQuery("pods", "namespace", "foo")})
+	assert.EqualError(t, err, "invalid input. Duplicate query name: pods")
+	_, err = newWatchQueries(nil, tooMany)
+	assert.EqualError(t, err, "invalid input. A watch can have up to 50 queries")
+	_, err = newWatchQueries(nil, []*model.WatchQuery{pods, {Name: "invalid", Input: &model.SearchInput{
+		Filters: []*model.SearchFilter{{Property: "kind"}}}}})
+	assert.ErrorContains(t, err, "Values are required")
+
+	queries, err := newWatchQueries(nil, []*model.WatchQuery{pods, {Name: "all"}})
+	assert.NoError(t, err)
+	assert.EqualError(t, queries.validateInitialState(), "invalid input. initialState requires a filter or keyword")
+}
+
+func Test_watchQueries_Match(t *testing.T) {
+	queries, err := newWatchQueries(nil, []*model.WatchQuery{newWatchQuery("pods", "kind", "Pod"),
+		newWatchQuery("foo", "namespace", "foo"), newWatchQuery("deployments", "kind", "Deployment")})
+	assert.NoError(t, err)
+	event := newPodEvent("pod-1", "foo")
+
+	matched, ok := queries.match(event)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"pods", "foo"}, matched.Queries)
+	assert.Nil(t, event.Queries, "The shared event shouldn't be modified.")
+
+	matched, ok = queries.match(newPodEvent("pod-2", "bar"))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"pods"}, matched.Queries)
+
+	deployment := newPodEvent("deployment-1", "bar")
+	deployment.NewData["kind"] = "ReplicaSet"
+	_, ok = queries.match(deployment)
+	assert.False(t, ok)
+}
+
+func Test_watchQueries_MatchInput(t *testing.T) {
+	event := newPodEvent("pod-1", "foo")
+
+	matched, ok := watchInput(newWatchQuery("", "kind", "Pod").Input).match(event)
+
+	assert.True(t, ok)
+	assert.Same(t, event, matched, "The event of watch(input) isn't copied.")
+	assert.Nil(t, matched.Queries)
+}
+
+func Test_sendInitialState_NamedQueries(t *testing.T) {
+	ctx := rbac.CreateTestContext("test-user-1", "testuser1")
+	userData := rbac.CreateTestUserWatchData("watch", "v1", "pods", "foo", true, time.Now(), 1*time.Minute)
+	rbac.SetupWatchCacheWithUserData(ctx, userData)
+	defer rbac.CleanupWatchCache(ctx)
+
+	pods, anyCase := newWatchQuery("pods", "kind", "Pod"), newWatchQuery("any-case", "kind", "pod")
+	queries, err := newWatchQueries(nil, []*model.WatchQuery{pods, anyCase})
+	assert.NoError(t, err)
+	var ended atomic.Int32
+	end := func() { ended.Add(1) }
+	states := []*initialStateQuery{
+		{search: newMockInitialStateResult(t, ctx, pods.Input), end: end, names: []string{"pods"}},
+		{search: newMockInitialStateResult(t, ctx, anyCase.Input), end: end, names: []string{"any-case"}},
+	}
+	receiver := make(chan *model.Event, 10)
+	receiver <- newPodEvent("local-cluster/pod-2", "foo") // Received while listing.
+	result := make(chan *model.Event, 10)
+
+	assert.True(t, sendInitialState(ctx, "test-sub", states, queries, nil, receiver, result))
+
+	assert.GreaterOrEqual(t, ended.Load(), int32(2), "The snapshot of each query should end before returning.")
+	events := []string{}
+	for len(result) > 0 {
+		event := <-result
+		events = append(events, fmt.Sprintf("%s %s %v", event.Operation, event.UID, event.Queries))
+	}
+	assert.Equal(t, []string{"ADDED local-cluster/pod-1 [pods]", "ADDED local-cluster/pod-1 [any-case]",
+		"SYNCED  []", "INSERT local-cluster/pod-2 [pods any-case]"}, events)
+}
+
+func TestWatchSubscription_QueriesWithInput(t *testing.T) {
+	enabled := true
+	originalEnabled := config.Cfg.Features.SubscriptionEnabled
+	config.Cfg.Features.SubscriptionEnabled = enabled
+	defer func() { config.Cfg.Features.SubscriptionEnabled = originalEnabled }()
+
+	_, err := WatchSubscription(context.Background(), &model.SearchInput{}, nil, nil, nil, nil, nil,
+		[]*model.WatchQuery{newWatchQuery("pods", "kind", "Pod")})
+
+	assert.EqualError(t, err, "invalid input. Use input or queries, not both")
+}
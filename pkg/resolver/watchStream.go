@@ -0,0 +1,182 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+	"github.com/stolostron/search-v2-api/pkg/metrics"
+	klog "k8s.io/klog/v2"
+)
+
+// Comment sent when there are no events, so proxies don't close the idle connection. Same as the WebSocket ping.
+var watchStreamKeepAlive = 10 * time.Second
+
+// Options of the watch, from the query parameters of the request. Same as the arguments of the watch subscription.
+//
+//	GET /searchapi/watch?input={"filters":[{"property":"kind","values":["Pod"]}]}&initialState=true&diff=CHANGED_KEYS
+type watchStreamRequest struct {
+	input        *model.SearchInput
+	initialState *bool
+	since        *int // From the Last-Event-ID header, or the since parameter.
+	overflow     *model.WatchOverflow
+	diff         *model.WatchDiff
+	properties   []*string // Repeated or comma-separated.
+	queries      []*model.WatchQuery
+}
+
+// WatchStreamHandler streams the events of a watch as Server-Sent Events, for the clients that can't use
+// WebSocket. Each event is sent as JSON in a data field, with its seq as id, so a client reconnecting with the
+// Last-Event-ID header receives the changes it missed, see watch(since). Registered like the WebSocket
+// subscriptions, so the same limits apply.
+func WatchStreamHandler(w http.ResponseWriter, r *http.Request) {
+	if err := checkSubscriptionEnabled(); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	request, err := parseWatchStreamRequest(r)
+	if err != nil {
+		klog.Warningf("Invalid watch stream request. %s", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	connectionID := uuid.New().String()[:8]
+	connectedAt := time.Now()
+	ctx := context.WithValue(r.Context(), config.WSContextKeyConnectionID, connectionID)
+	ctx = context.WithValue(ctx, config.WSContextKeyConnectedAt, connectedAt)
+	events, err := WatchSubscription(ctx, request.input, request.initialState, request.since, request.overflow,
+		request.diff, request.properties, request.queries)
+	if err != nil {
+		status := http.StatusServiceUnavailable // The maximum active subscriptions is reached.
+		if strings.HasPrefix(err.Error(), "invalid") {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	metrics.SubscriptionsActive.Inc()
+	klog.V(2).Infof("Watch stream [%s] started.", connectionID)
+	defer func() {
+		metrics.SubscriptionsActive.Dec()
+		metrics.SubscriptionDuration.Observe(time.Since(connectedAt).Seconds())
+		klog.V(2).Infof("Watch stream [%s] closed after %v.", connectionID, time.Since(connectedAt))
+	}()
+	writeWatchStream(ctx, w, events)
+}
+
+// Writes the events until the subscription or the connection is closed.
+func writeWatchStream(ctx context.Context, w http.ResponseWriter, events <-chan *model.Event) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // Disables the buffering of nginx proxies.
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+	if err := rc.Flush(); err != nil {
+		klog.V(5).Infof("Unable to flush watch stream response. %s", err)
+	}
+
+	keepAlive := time.NewTicker(watchStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			err = writeWatchStreamEvent(w, event)
+		case <-keepAlive.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			klog.V(3).Infof("Error writing watch stream, the client disconnected. %s", err)
+			return
+		}
+	}
+}
+
+func writeWatchStreamEvent(w http.ResponseWriter, event *model.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if event.Seq != nil {
+		if _, err := fmt.Fprintf(w, "id: %d\n", *event.Seq); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "data: %s\n\n", data)
+	return err
+}
+
+// Parse and validate the query parameters and the Last-Event-ID header.
+func parseWatchStreamRequest(r *http.Request) (*watchStreamRequest, error) {
+	query := r.URL.Query()
+	request := &watchStreamRequest{}
+	if value := query.Get("input"); value != "" {
+		request.input = &model.SearchInput{}
+		if err := json.Unmarshal([]byte(value), request.input); err != nil {
+			return nil, fmt.Errorf("invalid input. Error decoding the input parameter: %s", err)
+		}
+	}
+	if value := query.Get("queries"); value != "" {
+		if err := json.Unmarshal([]byte(value), &request.queries); err != nil {
+			return nil, fmt.Errorf("invalid input. Error decoding the queries parameter: %s", err)
+		}
+	}
+	if value := query.Get("initialState"); value != "" {
+		initialState, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input. initialState must be true or false")
+		}
+		request.initialState = &initialState
+	}
+	since := r.Header.Get("Last-Event-ID")
+	if since == "" {
+		since = query.Get("since")
+	}
+	if since != "" {
+		seq, err := strconv.Atoi(since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input. The last event ID must be a sequence number")
+		}
+		request.since = &seq
+	}
+	if value := query.Get("overflow"); value != "" {
+		overflow := model.WatchOverflow(value)
+		if !overflow.IsValid() {
+			return nil, fmt.Errorf("invalid input. Unknown overflow: %s", value)
+		}
+		request.overflow = &overflow
+	}
+	if value := query.Get("diff"); value != "" {
+		diff := model.WatchDiff(value)
+		if !diff.IsValid() {
+			return nil, fmt.Errorf("invalid input. Unknown diff: %s", value)
+		}
+		request.diff = &diff
+	}
+	for _, value := range query["properties"] {
+		for _, property := range strings.Split(value, ",") {
+			if property = strings.TrimSpace(property); property != "" {
+				request.properties = append(request.properties, &property)
+			}
+		}
+	}
+	return request, nil
+}
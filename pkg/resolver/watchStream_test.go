@@ -0,0 +1,106 @@
+// Copyright Contributors to the Open Cluster Management project
+package resolver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stolostron/search-v2-api/graph/model"
+	"github.com/stolostron/search-v2-api/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newWatchStreamRequest(query url.Values) *http.Request {
+	return httptest.NewRequest(http.MethodGet, "/searchapi/watch?"+query.Encode(), nil)
+}
+
+func Test_parseWatchStreamRequest(t *testing.T) {
+	r := newWatchStreamRequest(url.Values{
+		"input":        {`{"filters":[{"property":"kind","values":["Pod"]}]}`},
+		"initialState": {"true"},
+		"since":        {"3"},
+		"overflow":     {"COALESCE"},
+		"diff":         {"CHANGED_KEYS"},
+		"properties":   {"name,status", "namespace"},
+	})
+	r.Header.Set("Last-Event-ID", "42")
+
+	request, err := parseWatchStreamRequest(r)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "kind", request.input.Filters[0].Property)
+	assert.True(t, *request.initialState)
+	assert.Equal(t, 42, *request.since, "The Last-Event-ID header should have precedence.")
+	assert.Equal(t, model.WatchOverflowCoalesce, *request.overflow)
+	assert.Equal(t, model.WatchDiffChangedKeys, *request.diff)
+	assert.Len(t, request.properties, 3)
+	assert.Equal(t, "namespace", *request.properties[2])
+}
+
+func Test_parseWatchStreamRequest_Queries(t *testing.T) {
+	r := newWatchStreamRequest(url.Values{"queries": {`[{"name":"pods","input":{"filters":[{"property":"kind",` +
+		`"values":["Pod"]}]}},{"name":"all","input":{"keywords":["search"]}}]`}})
+
+	request, err := parseWatchStreamRequest(r)
+
+	assert.NoError(t, err)
+	assert.Nil(t, request.input)
+	assert.Len(t, request.queries, 2)
+	assert.Equal(t, "pods", request.queries[0].Name)
+	assert.Equal(t, "search", *request.queries[1].Input.Keywords[0])
+}
+
+func Test_parseWatchStreamRequest_Invalid(t *testing.T) {
+	for _, query := range []url.Values{
+		{"input": {"{"}},
+		{"queries": {`{"name":"pods"}`}},
+		{"initialState": {"maybe"}},
+		{"since": {"latest"}},
+		{"overflow": {"IGNORE"}},
+		{"diff": {"FULL"}},
+	} {
+		_, err := parseWatchStreamRequest(newWatchStreamRequest(query))
+		assert.Error(t, err, "Query %v", query)
+	}
+}
+
+func Test_writeWatchStream(t *testing.T) {
+	events := make(chan *model.Event, 2)
+	seq := 7
+	events <- &model.Event{UID: "local-cluster/pod-1", Operation: eventAdded, NewData: map[string]any{"name": "a"}}
+	events <- &model.Event{Operation: eventSynced, Seq: &seq}
+	close(events)
+	w := httptest.NewRecorder()
+
+	writeWatchStream(context.Background(), w, events)
+
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, `data: {"uid":"local-cluster/pod-1","operation":"ADDED","newData":{"name":"a"},"timestamp":""}`+
+		"\n\n"+`id: 7`+"\n"+`data: {"uid":"","operation":"SYNCED","timestamp":"","seq":7}`+"\n\n", w.Body.String())
+}
+
+func TestWatchStreamHandler_Disabled(t *testing.T) {
+	enabled := config.Cfg.Features.SubscriptionEnabled
+	config.Cfg.Features.SubscriptionEnabled = false
+	defer func() { config.Cfg.Features.SubscriptionEnabled = enabled }()
+	w := httptest.NewRecorder()
+
+	WatchStreamHandler(w, newWatchStreamRequest(url.Values{}))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestWatchStreamHandler_InvalidInput(t *testing.T) {
+	enabled := config.Cfg.Features.SubscriptionEnabled
+	config.Cfg.Features.SubscriptionEnabled = true
+	defer func() { config.Cfg.Features.SubscriptionEnabled = enabled }()
+	w := httptest.NewRecorder()
+
+	WatchStreamHandler(w, newWatchStreamRequest(url.Values{"input": {`{"filters":[{"property":"kind"}]}`}}))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Values are required")
+}
@@ -5,9 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"regexp"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/stolostron/search-v2-api/pkg/rbac"
 
@@ -19,110 +18,62 @@ import (
 	"github.com/stolostron/search-v2-api/pkg/database"
 )
 
-// parseOperatorAndValue parses a filter value to extract the operator and the actual value.
-// Returns the operator and the value.
-// Supported operators: !, !=, >, >=, <, <=, = (default)
-// This delegates to the shared getOperatorFromString helper to avoid code duplication.
-func parseOperatorAndValue(filterValue string) (operator string, value string) {
-	return getOperatorFromString(filterValue)
-}
+// Operations of the events generated by the watch subscription, in addition to INSERT, UPDATE and DELETE.
+const (
+	eventAdded  = "ADDED"  // Resource in the initial state of a watch.
+	eventResync = "RESYNC" // Changes were missed, the client must query the current state.
+	eventSynced = "SYNCED" // The initial state of a watch was sent.
+)
 
-// compareWithOperator compares two values using the specified operator.
-// Returns true if the comparison matches.
-func compareWithOperator(operator string, propertyValue interface{}, filterValue string) bool {
-	// Convert property value to string
-	propertyValueStr := ""
-	if strValue, ok := propertyValue.(string); ok {
-		propertyValueStr = strValue
-	} else {
-		propertyValueStr = fmt.Sprintf("%v", propertyValue)
-	}
+// The filter of a subscription, parsed once. The type of a property is the type of its value in the event, so
+// the values of a filter are parsed on the first event with each type, then reused. A property missing from the
+// event is parsed as a string. Used by the goroutine of the subscription only.
+type eventFilter struct {
+	keywords   []string
+	filters    []*model.SearchFilter
+	conditions []map[string]*filterCondition // Of each filter by type. Nil if the values can't be parsed.
+}
 
-	// Handle equality operators
-	if operator == "=" {
-		return propertyValueStr == filterValue
-	}
-	if operator == "!" || operator == "!=" {
-		return propertyValueStr != filterValue
+// Returns nil if there's no input, all the events match.
+func newEventFilter(input *model.SearchInput) *eventFilter {
+	if input == nil {
+		return nil
 	}
-
-	// Handle comparison operators (>, >=, <, <=)
-	// Try numeric comparison first
-	propFloat, propErr := strconv.ParseFloat(propertyValueStr, 64)
-	filterFloat, filterErr := strconv.ParseFloat(filterValue, 64)
-
-	if propErr == nil && filterErr == nil {
-		// Both values are numeric, do numeric comparison
-		return compareNumeric(operator, propFloat, filterFloat)
+	filter := &eventFilter{}
+	for _, keyword := range input.Keywords {
+		if keyword != nil {
+			filter.keywords = append(filter.keywords, *keyword)
+		}
 	}
-
-	// Fall back to string comparison
-	return compareString(operator, propertyValueStr, filterValue)
-}
-
-// compareNumeric performs numeric comparison using the specified operator.
-func compareNumeric(operator string, propValue, filterValue float64) bool {
-	switch operator {
-	case ">":
-		return propValue > filterValue
-	case ">=":
-		return propValue >= filterValue
-	case "<":
-		return propValue < filterValue
-	case "<=":
-		return propValue <= filterValue
-	}
-	return false
-}
-
-// compareString performs string comparison using the specified operator.
-func compareString(operator string, propValue, filterValue string) bool {
-	switch operator {
-	case ">":
-		return propValue > filterValue
-	case ">=":
-		return propValue >= filterValue
-	case "<":
-		return propValue < filterValue
-	case "<=":
-		return propValue <= filterValue
-	}
-	return false
-}
-
-// matchAnyLabel returns true if any of the label filters matches the event labels.
-// Equivalent to an OR operation.
-func matchAnyLabel(eventLabels map[string]interface{}, labelFilters []*string) bool {
-	for _, labelFilter := range labelFilters {
-		keyValue := strings.Split(*labelFilter, "=")
-		// Filter validated before as containing key=value pairs.
-		labelKey := keyValue[0]
-		labelValue := keyValue[1]
-		if value, exists := eventLabels[labelKey]; exists && value == labelValue {
-			return true
+	for _, inputFilter := range input.Filters {
+		if inputFilterValues(inputFilter) != nil {
+			filter.filters = append(filter.filters, inputFilter)
+			filter.conditions = append(filter.conditions, map[string]*filterCondition{})
 		}
 	}
-	return false
+	return filter
 }
 
-// matchesWildcard returns true if value matches the wildcard pattern.
-// The wildcard character '*' matches any sequence of characters (including empty).
-func matchesWildcard(value, pattern string) bool {
-	regexPattern := "^" + regexp.QuoteMeta(pattern) + "$"
-	regexPattern = strings.ReplaceAll(regexPattern, `\*`, ".*")
-	matched, err := regexp.MatchString(regexPattern, value)
-	if err != nil {
-		klog.Warningf("Failed to match wildcard pattern %s: %v", pattern, err)
-		return false
+// Returns the condition of the filter at index i for a property of the given type.
+func (f *eventFilter) condition(i int, dataType string) *filterCondition {
+	condition, parsed := f.conditions[i][dataType]
+	if !parsed {
+		var err error
+		property := f.filters[i].Property
+		condition, err = newFilterCondition(property, inputFilterValues(f.filters[i]), dataType)
+		if err != nil {
+			klog.V(4).Infof("Events with [%s] of type %s don't match the filters. %v", property, dataType, err)
+		}
+		f.conditions[i][dataType] = condition
 	}
-	return matched
+	return condition
 }
 
-// eventMatchesAllFilters Returns true if the event matches all the search input filters.
-// Equivalent to an AND operation.
-func eventMatchesAllFilters(event *model.Event, input *model.SearchInput) bool {
+// eventMatchesAllFilters Returns true if the event matches all the search input filters, the same as the search
+// queries.
+func eventMatchesAllFilters(event *model.Event, filter *eventFilter) bool {
 	// If no filters are specified, send all events
-	if input == nil {
+	if filter == nil {
 		return true
 	}
 
@@ -138,116 +89,22 @@ func eventMatchesAllFilters(event *model.Event, input *model.SearchInput) bool {
 		return false
 	}
 
-	// Check keywords (AND operation - all keywords must match)
-	for _, keyword := range input.Keywords {
-		if keyword == nil {
-			continue
-		}
-		keywordLower := strings.ToLower(*keyword)
-		found := false
-
-		// Search for keyword in any field value
-		strValue := ""
-		for _, value := range eventData {
-			if v, ok := value.(string); ok {
-				strValue = v
-			} else {
-				strValue = fmt.Sprintf("%v", value)
-			}
-			if strings.Contains(strings.ToLower(strValue), keywordLower) {
-				found = true
-				break
-			}
-		}
-
-		// If keyword not found in any field, event doesn't match
-		if !found {
+	conditions := make([]*filterCondition, len(filter.filters))
+	for i, inputFilter := range filter.filters {
+		if conditions[i] = filter.condition(i, eventDataType(eventData, inputFilter.Property)); conditions[i] == nil {
 			return false
 		}
 	}
+	return (&searchFilter{keywords: filter.keywords, conditions: conditions}).matches(eventData)
+}
 
-	// Check property filters (AND operation - all filters must match)
-	for _, filter := range input.Filters {
-		if filter == nil || filter.Property == "" {
-			continue
-		}
-
-		property := filter.Property
-		propertyValue, exists := eventData[property]
-
-		// If property doesn't exist in event data, filter doesn't match
-		if !exists {
-			return false
-		}
-
-		// If filter has no values, it's invalid - reject the event
-		if len(filter.Values) == 0 {
-			return false
-		}
-
-		// Check if label filter matches
-		if property == "label" {
-			if !matchAnyLabel(propertyValue.(map[string]interface{}), filter.Values) {
-				return false
-			}
-			continue
-		}
-
-		// Convert property value to string for comparison
-		propertyValueStr := ""
-		if strValue, ok := propertyValue.(string); ok {
-			propertyValueStr = strValue
-		} else {
-			// Try to convert other types to string
-			propertyValueStr = fmt.Sprintf("%v", propertyValue)
-		}
-
-		// Check if property value matches any of the filter values (OR operation)
-		matched := false
-		for _, filterValue := range filter.Values {
-			if filterValue == nil {
-				continue
-			}
-			fv := *filterValue
-			// Parse operator from filter value first
-			operator, value := parseOperatorAndValue(fv)
-
-			// Handle wildcards - only supported with equality operator
-			if strings.Contains(value, "*") {
-				// Wildcards only work with equality
-				if operator != "=" {
-					continue
-				}
-				if matchesWildcard(propertyValueStr, value) {
-					matched = true
-					break
-				}
-				continue
-			}
-
-			// Special case: Kind is compared case-insensitive for = and != operators to match search behavior.
-			if property == "kind" && (operator == "=" || operator == "!" || operator == "!=") {
-				isEqual := strings.EqualFold(propertyValueStr, value)
-				if (operator == "=" && isEqual) || ((operator == "!" || operator == "!=") && !isEqual) {
-					matched = true
-					break
-				}
-				continue
-			}
-
-			if compareWithOperator(operator, propertyValue, value) {
-				matched = true
-				break
-			}
-		}
-
-		// If none of the filter values matched, event doesn't match
-		if !matched {
-			return false
-		}
+// Returns the type of the property in the event data.
+func eventDataType(eventData map[string]any, property string) string {
+	value, exists := eventData[property]
+	if !exists || property == "cluster" || property == "managedHub" {
+		return "string"
 	}
-	// All filters matched
-	return true
+	return jsonbDataType(value)
 }
 
 func getEventDataFields(eventData map[string]any) (string, string, string, string, bool) {
@@ -321,16 +178,15 @@ func validateInputFilters(input *model.SearchInput) error {
 			if filter == nil || filter.Property == "" {
 				return fmt.Errorf("invalid filter. Property is required. Filter %+v", *filter)
 			}
-			// Validate label filter values are key=value pairs.
+			// Validate label filter values are key=value pairs, with an optional operator, unless partial matches.
+			// Same as decodeObject() for the search queries.
 			if filter.Property == "label" {
 				for _, value := range filter.Values {
-					// Reject operator-prefixed values for labels (operators not supported for labels yet)
-					if strings.HasPrefix(*value, "!") || strings.HasPrefix(*value, ">") || strings.HasPrefix(*value, "<") {
-						return fmt.Errorf("invalid filter. Operators are not supported for label values. {Property: %s Values: %s} ",
-							filter.Property, *value)
+					if value == nil || strings.Contains(*value, "*") {
+						continue
 					}
-					keyValue := strings.Split(*value, "=")
-					if len(keyValue) != 2 {
+					_, operand := getOperatorFromString(*value)
+					if len(strings.Split(operand, "=")) != 2 {
 						return fmt.Errorf("invalid filter. Value must be a key=value pair. {Property: %s Values: %s} ",
 							filter.Property, *value)
 					}
@@ -351,39 +207,95 @@ func validateInputFilters(input *model.SearchInput) error {
 }
 
 // WatchSubscriptions implements the GraphQL watch subscription resolver.
-func WatchSubscription(ctx context.Context, input *model.SearchInput) (<-chan *model.Event, error) {
-	result := make(chan *model.Event, 100)   // Channel to send events to the client.
+// With diff or properties, the events are reduced before they're sent, see watchPayload.
+// With queries, the events are matched against each query, see watchQueries.
+func WatchSubscription(ctx context.Context, input *model.SearchInput, initialState *bool, since *int,
+	overflow *model.WatchOverflow, diff *model.WatchDiff, properties []*string,
+	queries []*model.WatchQuery) (<-chan *model.Event, error) {
+	events, err := watchEvents(ctx, input, queries, initialState, since, overflow)
+	payload := newWatchPayload(diff, properties)
+	if err != nil || payload == nil {
+		return events, err
+	}
+
+	result := make(chan *model.Event)
+	go func() {
+		defer close(result)
+		for event := range events {
+			if event = payload.apply(event); event == nil {
+				continue
+			}
+			select {
+			case result <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return result, nil
+}
+
+// Returns the events matching the input or the named queries, with the full data.
+// With initialState, the resources matching the input are sent first as ADDED events, see sendInitialState().
+// With since, the changes after that sequence number are replayed first, see sendMissedEvents().
+// The overflow policy applies when the client doesn't receive the changes fast enough, see watchQueue.
+func watchEvents(ctx context.Context, input *model.SearchInput, namedQueries []*model.WatchQuery,
+	initialState *bool, since *int, overflow *model.WatchOverflow) (<-chan *model.Event, error) {
+	result := make(chan *model.Event)        // Channel to send events to the client. Buffered by the watchQueue.
 	receiver := make(chan *model.Event, 100) // Channel to receive events from the database.
 
 	// Check if the feature flag is enabled. If not, return an error.
-	if !config.Cfg.Features.SubscriptionEnabled {
-		klog.Info("GraphQL subscription feature is disabled. To enable set env variable FEATURE_SUBSCRIPTION=true")
-		ctx.Done()
-		return result, errors.New("GraphQL subscription feature is disabled. To enable set env variable FEATURE_SUBSCRIPTION=true")
+	if err := checkSubscriptionEnabled(); err != nil {
+		return result, err
 	}
 
 	// Validate the input filters.
-	if err := validateInputFilters(input); err != nil {
+	queries, err := newWatchQueries(input, namedQueries)
+	if err != nil {
 		return result, err
 	}
-
-	// Get WebSocket connection ID from the context. If not found, generate a new one.
-	subID, ok := ctx.Value(config.WSContextKeyConnectionID).(string)
-	if !ok {
-		subID = uuid.New().String()[:8]
-		klog.Errorf("Failed to get WebSocket connection ID from context. Generating a new one: %s", subID)
+	withInitialState := initialState != nil && *initialState
+	if withInitialState {
+		if err := queries.validateInitialState(); err != nil {
+			return result, err
+		}
 	}
 
+	subID := subscriptionID(ctx)
+
 	// Register the subscription before starting the goroutine so admission failures
 	// (e.g., max active limit reached) are propagated to the client as GraphQL errors.
 	// subCtx is a derived context that is also cancelled when the cleanup goroutine
 	// evicts this subscription (lifetime/idle expiry).
+	// With initialState, the subscription is registered before listing, so no changes are missed.
 	subCtx, err := database.RegisterSubscription(ctx, subID, receiver)
 	if err != nil {
 		klog.Errorf("Failed to register subscription [%s]: %v", subID, err)
 		return nil, err
 	}
 
+	// Get the missed changes after registering, so there's no gap with the changes received.
+	// The initial state is only needed if the changes can't be replayed.
+	var missed []*model.Event
+	resync := false
+	if since != nil {
+		var ok bool
+		if missed, ok = database.EventsSince(*since); !ok {
+			klog.V(2).Infof("Subscription watch(%s) can't replay the changes since %d.", subID, *since)
+			resync = !withInitialState
+		} else {
+			withInitialState = false
+		}
+	}
+
+	var initialStates []*initialStateQuery
+	if withInitialState {
+		if initialStates, err = newInitialStates(subCtx, queries); err != nil {
+			database.UnregisterSubscription(subID)
+			return nil, err
+		}
+	}
+
 	go func() {
 		defer func() {
 			klog.V(2).Infof("Closed subscription watch(%s).", subID)
@@ -392,49 +304,52 @@ func WatchSubscription(ctx context.Context, input *model.SearchInput) (<-chan *m
 			close(receiver)
 		}()
 
-		// Receive events from the database (receiver), filter, and send to the client (result).
+		if initialStates != nil && !sendInitialState(subCtx, subID, initialStates, queries, overflow, receiver,
+			result) {
+			return
+		}
+		// Changes with this sequence number or lower were already sent.
+		lastSeq, sent := sendMissedEvents(subCtx, subID, queries, missed, resync, result)
+		if !sent {
+			return
+		}
+
+		// Receive events from the database (receiver), filter, queue, and send to the client (result).
+		queue := newWatchQueue(subID, overflow, lastSeq)
+		defer queue.close()
 		for {
+			var send chan<- *model.Event // Nil when there's nothing to send, disabling the case.
+			next := queue.next()
+			if next != nil {
+				send = result
+			}
 			select {
 			case <-subCtx.Done():
 				klog.V(3).Infof("Subscription watch(%s) closed.", subID)
 				return
+			case send <- next:
+				queue.pop()
+				// Update activity only after successful delivery to tie idle timeout
+				// to actual subscription activity (events matching filters + RBAC),
+				// not global database traffic.
+				database.UpdateSubscriptionActivity(subID)
+				klog.V(3).Infof("Subscription watch(%s) sent event (UID: %s, Operation: %s) to client",
+					subID, next.UID, next.Operation)
 			case event, ok := <-receiver:
 				// If the receiver channel is closed, return.
 				if !ok {
 					klog.V(3).Infof("Subscription watch(%s) channel closed.", subID)
 					return
 				}
-
-				// Filter event based on the input filters
-				if !eventMatchesAllFilters(event, input) {
-					klog.V(4).Infof("Subscription watch(%s) event did not match filters (UID: %s, Operation: %s)",
-						subID, event.UID, event.Operation)
-					continue
+				if event.Seq != nil && *event.Seq <= lastSeq {
+					continue // Replayed.
 				}
-
-				// Filter events based on user RBAC
-				// Use subCtx so cancellation aborts in-flight permission checks
-				if !eventMatchesRbac(subCtx, event) {
-					klog.V(4).Infof("Subscription watch(%s) event did not match RBAC filters (UID: %s, Operation: %s)",
-						subID, event.UID, event.Operation)
-					continue
+				// Events dropped by the listener were received before this event.
+				if dropped := database.TakeDroppedEvents(subID); dropped > 0 && !queue.overflow(dropped) {
+					return
 				}
-
-				// Send filtered event to client
-				select {
-				case result <- event:
-					// Update activity only after successful delivery to tie idle timeout
-					// to actual subscription activity (events matching filters + RBAC),
-					// not global database traffic.
-					database.UpdateSubscriptionActivity(subID)
-					klog.V(3).Infof("Subscription watch(%s) sent event (UID: %s, Operation: %s) to client", subID, event.UID, event.Operation)
-					continue
-				case <-subCtx.Done():
-					klog.V(3).Infof("Subscription watch(%s) closed while sending event.", subID)
+				if matched, ok := watchMatches(subCtx, subID, queries, event); ok && !queue.push(matched) {
 					return
-				default:
-					klog.Warningf("Subscription watch(%s) channel buffer is full, dropping event.", subID)
-					continue
 				}
 			}
 		}
@@ -442,3 +357,67 @@ func WatchSubscription(ctx context.Context, input *model.SearchInput) (<-chan *m
 
 	return result, nil
 }
+
+// Returns an error if the subscription feature is disabled.
+func checkSubscriptionEnabled() error {
+	if !config.Cfg.Features.SubscriptionEnabled {
+		klog.Info("GraphQL subscription feature is disabled. To enable set env variable FEATURE_SUBSCRIPTION=true")
+		return errors.New("GraphQL subscription feature is disabled. To enable set env variable FEATURE_SUBSCRIPTION=true")
+	}
+	return nil
+}
+
+// Returns the WebSocket connection ID from the context. If not found, generates a new one.
+func subscriptionID(ctx context.Context) string {
+	subID, ok := ctx.Value(config.WSContextKeyConnectionID).(string)
+	if !ok {
+		subID = uuid.New().String()[:8]
+		klog.Errorf("Failed to get WebSocket connection ID from context. Generating a new one: %s", subID)
+	}
+	return subID
+}
+
+// Filters the event with the input filters and the user's RBAC, and sends it to the client, waiting for the
+// client to receive it. Returns false if the subscription was closed. The event is shared with the other
+// subscriptions, see Listener.forwardNotification().
+func forwardEvent(ctx context.Context, subID string, queries *watchQueries, event *model.Event,
+	result chan<- *model.Event) bool {
+	event, ok := watchMatches(ctx, subID, queries, event)
+	if !ok {
+		return true
+	}
+	select {
+	case result <- event:
+		database.UpdateSubscriptionActivity(subID)
+		return true
+	case <-ctx.Done():
+		klog.V(3).Infof("Subscription watch(%s) closed while sending event.", subID)
+		return false
+	}
+}
+
+// Sends the changes missed by a client that resumes watching, or a RESYNC event if they aren't available.
+// Returns the sequence number of the last change sent, and false if the subscription was closed.
+func sendMissedEvents(ctx context.Context, subID string, queries *watchQueries, missed []*model.Event,
+	resync bool, result chan<- *model.Event) (int, bool) {
+	if resync {
+		seq := database.LastEventSeq()
+		event := &model.Event{Operation: eventResync, Seq: &seq, Timestamp: time.Now().UTC().Format(time.RFC3339)}
+		select {
+		case result <- event:
+		case <-ctx.Done():
+			return 0, false
+		}
+	}
+	lastSeq := 0
+	for _, event := range missed {
+		if !forwardEvent(ctx, subID, queries, event, result) {
+			return lastSeq, false
+		}
+		lastSeq = *event.Seq
+	}
+	if len(missed) > 0 {
+		klog.V(3).Infof("Subscription watch(%s) replayed %d changes.", subID, len(missed))
+	}
+	return lastSeq, true
+}
@@ -3,6 +3,7 @@ package resolver
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -32,7 +33,7 @@ func TestEventMatchesFilters_MultiKeywordsAgainstLabels(t *testing.T) {
 		Keywords: []*string{&one, &two},
 	}
 	// Should match keywords in labels.
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match keywords against labels")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match keywords against labels")
 }
 
 // [AI]
@@ -49,7 +50,7 @@ func TestWatchSubscription_Disabled(t *testing.T) {
 	ctx := context.Background()
 	input := &model.SearchInput{}
 
-	resultChan, err := WatchSubscription(ctx, input)
+	resultChan, err := WatchSubscription(ctx, input, nil, nil, nil, nil, nil, nil)
 
 	// Verify error is returned when feature is disabled
 	assert.NotNil(t, err, "Should return error when subscription is disabled")
@@ -76,7 +77,7 @@ func TestWatchSubscription_Enabled(t *testing.T) {
 
 	input := &model.SearchInput{}
 
-	resultChan, err := WatchSubscription(ctx, input)
+	resultChan, err := WatchSubscription(ctx, input, nil, nil, nil, nil, nil, nil)
 
 	// Verify no error when feature is enabled
 	assert.Nil(t, err, "Should not return error when subscription is enabled")
@@ -116,7 +117,7 @@ func TestWatchSubscription_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	input := &model.SearchInput{}
 
-	resultChan, err := WatchSubscription(ctx, input)
+	resultChan, err := WatchSubscription(ctx, input, nil, nil, nil, nil, nil, nil)
 
 	assert.Nil(t, err, "Should not return error")
 	assert.NotNil(t, resultChan, "Result channel should be returned")
@@ -164,7 +165,7 @@ func TestWatchSubscription_MultipleSubscriptions(t *testing.T) {
 		wg.Add(1)
 		go func(index int) {
 			defer wg.Done()
-			ch, err := WatchSubscription(ctx, input)
+			ch, err := WatchSubscription(ctx, input, nil, nil, nil, nil, nil, nil)
 			assert.Nil(t, err, "Should not return error for subscription %d", index)
 			channels[index] = ch
 		}(i)
@@ -206,7 +207,7 @@ func TestWatchSubscription_EventForwarding(t *testing.T) {
 
 	input := &model.SearchInput{}
 
-	resultChan, err := WatchSubscription(ctx, input)
+	resultChan, err := WatchSubscription(ctx, input, nil, nil, nil, nil, nil, nil)
 
 	assert.Nil(t, err, "Should not return error")
 	assert.NotNil(t, resultChan, "Result channel should be returned")
@@ -262,7 +263,7 @@ func TestWatchSubscription_ChannelBufferHandling(t *testing.T) {
 
 	input := &model.SearchInput{}
 
-	resultChan, err := WatchSubscription(ctx, input)
+	resultChan, err := WatchSubscription(ctx, input, nil, nil, nil, nil, nil, nil)
 
 	assert.Nil(t, err, "Should not return error")
 	assert.NotNil(t, resultChan, "Result channel should be returned")
@@ -297,7 +298,7 @@ func TestWatchSubscription_NilInput(t *testing.T) {
 	defer cancel()
 
 	// Test with nil input - should still work as input is not currently used
-	resultChan, err := WatchSubscription(ctx, nil)
+	resultChan, err := WatchSubscription(ctx, nil, nil, nil, nil, nil, nil, nil)
 
 	assert.Nil(t, err, "Should not return error with nil input")
 	assert.NotNil(t, resultChan, "Result channel should be returned")
@@ -323,7 +324,7 @@ func TestWatchSubscription_RapidContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	input := &model.SearchInput{}
 
-	resultChan, err := WatchSubscription(ctx, input)
+	resultChan, err := WatchSubscription(ctx, input, nil, nil, nil, nil, nil, nil)
 
 	assert.Nil(t, err, "Should not return error")
 	assert.NotNil(t, resultChan, "Result channel should be returned")
@@ -368,7 +369,7 @@ func TestWatchSubscription_FilterInput(t *testing.T) {
 		},
 	}
 
-	resultChan, err := WatchSubscription(ctx, input)
+	resultChan, err := WatchSubscription(ctx, input, nil, nil, nil, nil, nil, nil)
 
 	assert.Nil(t, err, "Should not return error with filtered input")
 	assert.NotNil(t, resultChan, "Result channel should be returned")
@@ -390,11 +391,11 @@ func TestEventMatchesFilters_NoFilters(t *testing.T) {
 	}
 
 	// No input - should match
-	assert.True(t, eventMatchesAllFilters(event, nil))
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(nil)))
 
 	// Empty input - should match
 	emptyInput := &model.SearchInput{}
-	assert.True(t, eventMatchesAllFilters(event, emptyInput))
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(emptyInput)))
 }
 
 // [AI] Test eventMatchesFilters with property filters
@@ -420,7 +421,7 @@ func TestEventMatchesFilters_PropertyFilters(t *testing.T) {
 			},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match kind=Pod filter")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match kind=Pod filter")
 
 	// Filter NOT matching kind=Deployment
 	deploymentValue := "Deployment"
@@ -432,10 +433,11 @@ func TestEventMatchesFilters_PropertyFilters(t *testing.T) {
 			},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNoMatch), "Should not match kind=Deployment filter")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputNoMatch)), "Should not match kind=Deployment filter")
 }
 
-// [AI] Test eventMatchesFilters: kind equality (no wildcard) matches search behavior (case-insensitive).
+// [AI] Test eventMatchesFilters: kind equality (no wildcard) matches search behavior (case-insensitive for
+// lowercase values).
 // Wildcard patterns on kind remain case-sensitive; see TestEventMatchesFilters_WildcardKindCaseSensitive.
 func TestEventMatchesFilters_KindEqualityCaseInsensitive(t *testing.T) {
 	event := &model.Event{
@@ -454,7 +456,7 @@ func TestEventMatchesFilters_KindEqualityCaseInsensitive(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValueExact}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputExact), "Should match kind with exact case")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputExact)), "Should match kind with exact case")
 
 	kindValueLower := "pod"
 	inputLower := &model.SearchInput{
@@ -462,7 +464,7 @@ func TestEventMatchesFilters_KindEqualityCaseInsensitive(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValueLower}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputLower), "Should match kind case-insensitively (lowercase)")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputLower)), "Should match kind case-insensitively (lowercase)")
 
 	kindValueUpper := "POD"
 	inputUpper := &model.SearchInput{
@@ -470,7 +472,8 @@ func TestEventMatchesFilters_KindEqualityCaseInsensitive(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValueUpper}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputUpper), "Should match kind case-insensitively (uppercase)")
+	// Same as search, kind is compared case-insensitive only when a value starts with a lowercase letter.
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputUpper)), "Should match kind exactly (uppercase)")
 }
 
 // [AI] Test eventMatchesFilters with multiple filters (AND operation)
@@ -496,7 +499,7 @@ func TestEventMatchesFilters_MultipleFilters_AND(t *testing.T) {
 			{Property: nsFilter, Values: []*string{&nsValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match when all filters match")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match when all filters match")
 
 	// One filter doesn't match
 	wrongNsValue := "kube-system"
@@ -506,7 +509,7 @@ func TestEventMatchesFilters_MultipleFilters_AND(t *testing.T) {
 			{Property: nsFilter, Values: []*string{&wrongNsValue}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNoMatch), "Should not match when one filter doesn't match")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputNoMatch)), "Should not match when one filter doesn't match")
 }
 
 // [AI] Test eventMatchesFilters with multiple values per filter (OR operation)
@@ -531,7 +534,7 @@ func TestEventMatchesFilters_MultipleValues_OR(t *testing.T) {
 			},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match when one of the values matches")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match when one of the values matches")
 
 	// Filter with values that don't match
 	serviceValue := "Service"
@@ -544,7 +547,7 @@ func TestEventMatchesFilters_MultipleValues_OR(t *testing.T) {
 			},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNoMatch), "Should not match when none of the values match")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputNoMatch)), "Should not match when none of the values match")
 }
 
 // [AI] Test eventMatchesFilters with keywords
@@ -564,28 +567,34 @@ func TestEventMatchesFilters_Keywords(t *testing.T) {
 	input := &model.SearchInput{
 		Keywords: []*string{&keyword1},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match when keyword found")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match when keyword found")
 
 	// Keyword with different case
 	keyword2 := "NGINX"
 	inputCase := &model.SearchInput{
 		Keywords: []*string{&keyword2},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputCase), "Should match keyword case-insensitively")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputCase)), "Should match keyword case-insensitively")
 
-	// Multiple keywords (AND operation) - all must match
-	keyword3 := "production"
+	// Multiple keywords (AND operation) - all must match the same value, like the search query
+	keyword3 := "deployment"
 	inputMultiple := &model.SearchInput{
 		Keywords: []*string{&keyword1, &keyword3},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputMultiple), "Should match when all keywords found")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputMultiple)), "Should match when all keywords found")
+	keyword4 := "production"
+	inputOtherValues := &model.SearchInput{
+		Keywords: []*string{&keyword1, &keyword4},
+	}
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputOtherValues)),
+		"Should not match when the keywords are found in different values")
 
 	// Keyword that doesn't match
 	keywordNoMatch := "nonexistent"
 	inputNoMatch := &model.SearchInput{
 		Keywords: []*string{&keywordNoMatch},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNoMatch), "Should not match when keyword not found")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputNoMatch)), "Should not match when keyword not found")
 }
 
 // [AI] Test eventMatchesFilters with DELETE operation (uses OldData)
@@ -609,14 +618,14 @@ func TestEventMatchesFilters_DeleteOperation(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match DELETE event using OldData")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match DELETE event using OldData")
 
 	// Keyword search in OldData
 	keyword := "deleted"
 	inputKeyword := &model.SearchInput{
 		Keywords: []*string{&keyword},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputKeyword), "Should find keyword in OldData")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputKeyword)), "Should find keyword in OldData")
 }
 
 // [AI] Test eventMatchesFilters with both keywords and filters
@@ -641,7 +650,7 @@ func TestEventMatchesFilters_KeywordsAndFilters(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match when both keyword and filter match")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match when both keyword and filter match")
 
 	// Keyword matches but filter doesn't
 	wrongKind := "Pod"
@@ -651,7 +660,7 @@ func TestEventMatchesFilters_KeywordsAndFilters(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&wrongKind}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNoMatch), "Should not match when filter doesn't match")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputNoMatch)), "Should not match when filter doesn't match")
 }
 
 // [AI] Test eventMatchesFilters with missing property
@@ -673,7 +682,7 @@ func TestEventMatchesFilters_MissingProperty(t *testing.T) {
 			{Property: labelFilter, Values: []*string{&labelValue}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, input), "Should not match when property doesn't exist")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should not match when property doesn't exist")
 }
 
 // [AI] Test eventMatchesFilters with nil event data
@@ -692,7 +701,7 @@ func TestEventMatchesFilters_NilEventData(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValue}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, input), "Should not match when event has no data")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should not match when event has no data")
 }
 
 // [AI] Test eventMatchesFilters with empty filter values
@@ -712,8 +721,8 @@ func TestEventMatchesFilters_EmptyFilterValues(t *testing.T) {
 			{Property: kindFilter, Values: []*string{}},
 		},
 	}
-	// Empty values means no matching criteria, should not match
-	assert.False(t, eventMatchesAllFilters(event, input), "Should not match with empty filter values")
+	// Same as search, a filter without values is ignored. Subscriptions reject it, see validateInputFilters().
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should ignore a filter with empty values")
 }
 
 // [AI] Test eventMatchesFilters with non-string property values
@@ -736,7 +745,7 @@ func TestEventMatchesFilters_NonStringProperties(t *testing.T) {
 			{Property: replicasFilter, Values: []*string{&replicasValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match numeric property converted to string")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match numeric property converted to string")
 
 	// Filter on boolean property
 	readyFilter := "ready"
@@ -746,7 +755,7 @@ func TestEventMatchesFilters_NonStringProperties(t *testing.T) {
 			{Property: readyFilter, Values: []*string{&readyValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputBool), "Should match boolean property converted to string")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputBool)), "Should match boolean property converted to string")
 }
 
 // [AI] Test eventMatchesFilters with nil filter
@@ -764,7 +773,7 @@ func TestEventMatchesFilters_NilFilter(t *testing.T) {
 		Filters: []*model.SearchFilter{nil},
 	}
 	// Should skip nil filter and match (no valid filters)
-	assert.True(t, eventMatchesAllFilters(event, input), "Should skip nil filters")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should skip nil filters")
 }
 
 // [AI] Test eventMatchesFilters with empty property name
@@ -785,7 +794,7 @@ func TestEventMatchesFilters_EmptyProperty(t *testing.T) {
 		},
 	}
 	// Should skip filter with empty property
-	assert.True(t, eventMatchesAllFilters(event, input), "Should skip filters with empty property")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should skip filters with empty property")
 }
 
 // [AI] Test eventMatchesFilters with nil keyword
@@ -804,7 +813,7 @@ func TestEventMatchesFilters_NilKeyword(t *testing.T) {
 		Keywords: []*string{nil},
 	}
 	// Should skip nil keyword and match (no valid keywords)
-	assert.True(t, eventMatchesAllFilters(event, input), "Should skip nil keywords")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should skip nil keywords")
 }
 
 // [AI] Test eventMatchesFilters with complex multi-filter scenario
@@ -836,7 +845,7 @@ func TestEventMatchesFilters_ComplexScenario(t *testing.T) {
 			{Property: nsFilter, Values: []*string{&nsValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match complex filter scenario")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match complex filter scenario")
 
 	// One keyword missing
 	keywordMissing := "missing"
@@ -846,7 +855,7 @@ func TestEventMatchesFilters_ComplexScenario(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValue}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNoMatch), "Should not match when keyword missing")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputNoMatch)), "Should not match when keyword missing")
 }
 
 // [AI] Test eventMatchesFilters with nil filter value
@@ -868,7 +877,7 @@ func TestEventMatchesFilters_NilFilterValue(t *testing.T) {
 		},
 	}
 	// Should skip nil value and match with "Pod"
-	assert.True(t, eventMatchesAllFilters(event, input), "Should skip nil filter values")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should skip nil filter values")
 }
 
 // [AI] Test eventMatchesFilters with label matching
@@ -889,7 +898,7 @@ func TestEventMatchesFilters_LabelMatching(t *testing.T) {
 			{Property: "label", Values: []*string{&labelVal1}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input1), "Should match exact label key=value")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input1)), "Should match exact label key=value")
 
 	// Match on multiple labels (OR logic within label filter values? No, matchLabels returns true if ANY matches)
 	// matchLabels implementation: returns true if ANY of the labelFilters matches the event labels.
@@ -899,7 +908,7 @@ func TestEventMatchesFilters_LabelMatching(t *testing.T) {
 			{Property: "label", Values: []*string{&labelVal1, &labelVal2}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input2), "Should match if any label matches")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input2)), "Should match if any label matches")
 
 	// No match
 	labelValNoMatch := "app=apache"
@@ -908,7 +917,7 @@ func TestEventMatchesFilters_LabelMatching(t *testing.T) {
 			{Property: "label", Values: []*string{&labelValNoMatch}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNoMatch), "Should not match different value")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputNoMatch)), "Should not match different value")
 
 	// Key mismatch
 	labelKeyNoMatch := "tier=frontend"
@@ -917,7 +926,7 @@ func TestEventMatchesFilters_LabelMatching(t *testing.T) {
 			{Property: "label", Values: []*string{&labelKeyNoMatch}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputKeyNoMatch), "Should not match different key")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputKeyNoMatch)), "Should not match different key")
 }
 
 // [AI] Test WatchSubscription input validation
@@ -942,7 +951,7 @@ func TestWatchSubscription_InputValidation(t *testing.T) {
 			{Property: "kind", Values: []*string{&valOp}},
 		},
 	}
-	_, err := WatchSubscription(ctx, inputOp)
+	_, err := WatchSubscription(ctx, inputOp, nil, nil, nil, nil, nil, nil)
 	assert.NoError(t, err, "Operators should now be supported")
 
 	// Wildcard filters are supported
@@ -952,7 +961,7 @@ func TestWatchSubscription_InputValidation(t *testing.T) {
 			{Property: "kind", Values: []*string{&valWild}},
 		},
 	}
-	_, err = WatchSubscription(ctx, inputWild)
+	_, err = WatchSubscription(ctx, inputWild, nil, nil, nil, nil, nil, nil)
 	assert.NoError(t, err, "Wildcard filters should be accepted")
 
 	// Test invalid label format
@@ -962,20 +971,18 @@ func TestWatchSubscription_InputValidation(t *testing.T) {
 			{Property: "label", Values: []*string{&valLabel}},
 		},
 	}
-	_, err = WatchSubscription(ctx, inputLabel)
+	_, err = WatchSubscription(ctx, inputLabel, nil, nil, nil, nil, nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Value must be a key=value pair.")
 
-	// Test operator-prefixed label values are rejected
-	valLabelOp := "!env=prod"
+	// Test operator-prefixed and partial label values are accepted, same as search.
+	valLabelOp, valLabelPartial := "!env=prod", "app*"
 	inputLabelOp := &model.SearchInput{
 		Filters: []*model.SearchFilter{
-			{Property: "label", Values: []*string{&valLabelOp}},
+			{Property: "label", Values: []*string{&valLabelOp, &valLabelPartial}},
 		},
 	}
-	_, err = WatchSubscription(ctx, inputLabelOp)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "Operators are not supported for label values")
+	assert.NoError(t, validateInputFilters(inputLabelOp))
 
 	// Test empty property
 	val := "value"
@@ -984,12 +991,12 @@ func TestWatchSubscription_InputValidation(t *testing.T) {
 			{Property: "", Values: []*string{&val}},
 		},
 	}
-	_, err = WatchSubscription(ctx, inputEmptyProp)
+	_, err = WatchSubscription(ctx, inputEmptyProp, nil, nil, nil, nil, nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Property is required")
 }
 
-// [AI] Test parseOperatorAndValue function
+// [AI] Test getOperatorFromString function
 func TestParseOperatorAndValue(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -1018,91 +1025,13 @@ func TestParseOperatorAndValue(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			operator, value := parseOperatorAndValue(tt.filterValue)
+			operator, value := getOperatorFromString(tt.filterValue)
 			assert.Equal(t, tt.expectedOperator, operator, "Operator mismatch")
 			assert.Equal(t, tt.expectedValue, value, "Value mismatch")
 		})
 	}
 }
 
-// [AI] Test compareWithOperator function with string values
-func TestCompareValues_Strings(t *testing.T) {
-	tests := []struct {
-		name          string
-		operator      string
-		propertyValue interface{}
-		filterValue   string
-		expected      bool
-	}{
-		{"String equality match", "=", "Pod", "Pod", true},
-		{"String equality no match", "=", "Pod", "Deployment", false},
-		{"String not equal match", "!=", "Pod", "Deployment", true},
-		{"String not equal no match", "!=", "Pod", "Pod", false},
-		{"String greater than true", ">", "zebra", "apple", true},
-		{"String greater than false", ">", "apple", "zebra", false},
-		{"String greater or equal true", ">=", "pod", "pod", true},
-		{"String less than true", "<", "apple", "zebra", true},
-		{"String less than false", "<", "zebra", "apple", false},
-		{"String less or equal true", "<=", "pod", "pod", true},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := compareWithOperator(tt.operator, tt.propertyValue, tt.filterValue)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
-// [AI] Test compareWithOperator function with numeric values
-func TestCompareValues_Numeric(t *testing.T) {
-	tests := []struct {
-		name          string
-		operator      string
-		propertyValue interface{}
-		filterValue   string
-		expected      bool
-	}{
-		{"Numeric equality match", "=", 42, "42", true},
-		{"Numeric equality no match", "=", 42, "100", false},
-		{"Numeric not equal match", "!=", 42, "100", true},
-		{"Numeric not equal no match", "!=", 42, "42", false},
-		{"Numeric greater than true", ">", 100, "50", true},
-		{"Numeric greater than false", ">", 50, "100", false},
-		{"Numeric greater or equal true (equal)", ">=", 100, "100", true},
-		{"Numeric greater or equal true (greater)", ">=", 100, "50", true},
-		{"Numeric greater or equal false", ">=", 50, "100", false},
-		{"Numeric less than true", "<", 50, "100", true},
-		{"Numeric less than false", "<", 100, "50", false},
-		{"Numeric less or equal true (equal)", "<=", 100, "100", true},
-		{"Numeric less or equal true (less)", "<=", 50, "100", true},
-		{"Numeric less or equal false", "<=", 100, "50", false},
-		{"Float comparison", ">", 3.14, "3.0", true},
-		{"Negative numbers", "<", -10, "0", true},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := compareWithOperator(tt.operator, tt.propertyValue, tt.filterValue)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
-// [AI] Test compareWithOperator with mixed types
-func TestCompareValues_MixedTypes(t *testing.T) {
-	// Boolean converted to string
-	result := compareWithOperator("=", true, "true")
-	assert.True(t, result, "Boolean true should match string 'true'")
-
-	result = compareWithOperator("=", false, "false")
-	assert.True(t, result, "Boolean false should match string 'false'")
-
-	// String number vs numeric comparison
-	result = compareWithOperator(">", "100", "50")
-	assert.True(t, result, "String '100' should be greater than '50' numerically")
-}
-
 // [AI] Test eventMatchesAllFilters with not equal operator
 func TestEventMatchesFilters_NotEqualOperator(t *testing.T) {
 	event := &model.Event{
@@ -1122,7 +1051,7 @@ func TestEventMatchesFilters_NotEqualOperator(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match when kind is not Deployment")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match when kind is not Deployment")
 
 	// Filter: kind != Pod (should not match)
 	kindValueNoMatch := "!=Pod"
@@ -1131,7 +1060,7 @@ func TestEventMatchesFilters_NotEqualOperator(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValueNoMatch}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNoMatch), "Should not match when kind equals Pod with != operator")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputNoMatch)), "Should not match when kind equals Pod with != operator")
 
 	// Filter: namespace ! kube-system (should match default)
 	nsFilter := "namespace"
@@ -1141,10 +1070,10 @@ func TestEventMatchesFilters_NotEqualOperator(t *testing.T) {
 			{Property: nsFilter, Values: []*string{&nsValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputNs), "Should match when namespace is not kube-system")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputNs)), "Should match when namespace is not kube-system")
 }
 
-func TestMatchesWildcard(t *testing.T) {
+func Test_likeMatches(t *testing.T) {
 	tests := []struct {
 		value, pattern string
 		expected       bool
@@ -1166,9 +1095,10 @@ func TestMatchesWildcard(t *testing.T) {
 		{"ab", "a*a*a", false},           // multiple wildcards no match
 	}
 	for _, tt := range tests {
-		result := matchesWildcard(tt.value, tt.pattern)
+		pattern := strings.ReplaceAll(tt.pattern, "*", "%") // Same as getPartialMatchFilter()
+		result := likeMatches(tt.value, pattern)
 		if result != tt.expected {
-			t.Errorf("matchesWildcard(%q, %q) = %v, want %v", tt.value, tt.pattern, result, tt.expected)
+			t.Errorf("likeMatches(%q, %q) = %v, want %v", tt.value, pattern, result, tt.expected)
 		}
 	}
 }
@@ -1192,7 +1122,7 @@ func TestEventMatchesFilters_WildcardProperty(t *testing.T) {
 			{Property: nameFilter, Values: []*string{&nameValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match name with suffix wildcard")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match name with suffix wildcard")
 
 	// Prefix wildcard on name
 	nameValuePrefix := "*-abc"
@@ -1201,7 +1131,7 @@ func TestEventMatchesFilters_WildcardProperty(t *testing.T) {
 			{Property: nameFilter, Values: []*string{&nameValuePrefix}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputPrefix), "Should match name with prefix wildcard")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputPrefix)), "Should match name with prefix wildcard")
 
 	// Wildcard on namespace
 	nsFilter := "namespace"
@@ -1211,7 +1141,7 @@ func TestEventMatchesFilters_WildcardProperty(t *testing.T) {
 			{Property: nsFilter, Values: []*string{&nsValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputNs), "Should match namespace with prefix wildcard")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputNs)), "Should match namespace with prefix wildcard")
 
 	// Wildcard that does not match
 	nsValueNoMatch := "dev*"
@@ -1220,7 +1150,7 @@ func TestEventMatchesFilters_WildcardProperty(t *testing.T) {
 			{Property: nsFilter, Values: []*string{&nsValueNoMatch}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNsNoMatch), "Should not match namespace with non-matching wildcard")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputNsNoMatch)), "Should not match namespace with non-matching wildcard")
 }
 
 // [AI] Test wildcards with explicit equality operator
@@ -1242,7 +1172,7 @@ func TestEventMatchesFilters_WildcardWithExplicitOperator(t *testing.T) {
 			{Property: nameFilter, Values: []*string{&nameValueEq}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputEq), "Should match name with explicit = and wildcard")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputEq)), "Should match name with explicit = and wildcard")
 
 	// Other operators with wildcard should not match (wildcards only work with =)
 	nameValueGt := ">nginx-*"
@@ -1251,7 +1181,7 @@ func TestEventMatchesFilters_WildcardWithExplicitOperator(t *testing.T) {
 			{Property: nameFilter, Values: []*string{&nameValueGt}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputGt), "Should not match name with > and wildcard")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputGt)), "Should not match name with > and wildcard")
 }
 
 // [AI] Test eventMatchesAllFilters with comparison operators on numeric values
@@ -1274,7 +1204,7 @@ func TestEventMatchesFilters_NumericComparison(t *testing.T) {
 			{Property: replicasFilter, Values: []*string{&replicasValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match replicas > 2")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match replicas > 2")
 
 	// replicas >= 3
 	replicasValueGte := ">=3"
@@ -1283,7 +1213,7 @@ func TestEventMatchesFilters_NumericComparison(t *testing.T) {
 			{Property: replicasFilter, Values: []*string{&replicasValueGte}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputGte), "Should match replicas >= 3")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputGte)), "Should match replicas >= 3")
 
 	// replicas < 5
 	replicasValueLt := "<5"
@@ -1292,7 +1222,7 @@ func TestEventMatchesFilters_NumericComparison(t *testing.T) {
 			{Property: replicasFilter, Values: []*string{&replicasValueLt}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputLt), "Should match replicas < 5")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputLt)), "Should match replicas < 5")
 
 	// replicas <= 3
 	replicasValueLte := "<=3"
@@ -1301,7 +1231,7 @@ func TestEventMatchesFilters_NumericComparison(t *testing.T) {
 			{Property: replicasFilter, Values: []*string{&replicasValueLte}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputLte), "Should match replicas <= 3")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputLte)), "Should match replicas <= 3")
 
 	// age > 200 (should not match)
 	ageFilter := "age"
@@ -1311,7 +1241,7 @@ func TestEventMatchesFilters_NumericComparison(t *testing.T) {
 			{Property: ageFilter, Values: []*string{&ageValue}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNoMatch), "Should not match age > 200")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputNoMatch)), "Should not match age > 200")
 }
 
 // [AI] Test eventMatchesAllFilters with comparison operators on string values
@@ -1333,7 +1263,7 @@ func TestEventMatchesFilters_StringComparison(t *testing.T) {
 			{Property: nameFilter, Values: []*string{&nameValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match name > 'my' alphabetically")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match name > 'my' alphabetically")
 
 	// name < "zebra"
 	nameValueLt := "<zebra"
@@ -1342,7 +1272,7 @@ func TestEventMatchesFilters_StringComparison(t *testing.T) {
 			{Property: nameFilter, Values: []*string{&nameValueLt}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputLt), "Should match name < 'zebra' alphabetically")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputLt)), "Should match name < 'zebra' alphabetically")
 }
 
 // [AI] Test eventMatchesAllFilters with multiple operators
@@ -1368,7 +1298,7 @@ func TestEventMatchesFilters_MultipleOperators(t *testing.T) {
 			{Property: replicasFilter, Values: []*string{&replicasValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match all filters with operators")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match all filters with operators")
 
 	// One filter doesn't match
 	replicasValueNoMatch := ">5"
@@ -1378,7 +1308,7 @@ func TestEventMatchesFilters_MultipleOperators(t *testing.T) {
 			{Property: replicasFilter, Values: []*string{&replicasValueNoMatch}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNoMatch), "Should not match when one operator filter doesn't match")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputNoMatch)), "Should not match when one operator filter doesn't match")
 }
 
 // [AI] Test eventMatchesAllFilters with operators and OR logic within a filter
@@ -1402,7 +1332,7 @@ func TestEventMatchesFilters_OperatorsWithOR(t *testing.T) {
 			{Property: replicasFilter, Values: []*string{&val1, &val2}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match when one of the OR conditions is true")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match when one of the OR conditions is true")
 
 	// Both conditions false
 	val3 := ">10"
@@ -1412,7 +1342,7 @@ func TestEventMatchesFilters_OperatorsWithOR(t *testing.T) {
 			{Property: replicasFilter, Values: []*string{&val3, &val4}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNoMatch), "Should not match when all OR conditions are false")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputNoMatch)), "Should not match when all OR conditions are false")
 }
 
 // [AI] Test that kind filter still works case-insensitively with = operator
@@ -1433,7 +1363,7 @@ func TestEventMatchesFilters_KindCaseInsensitiveWithOperator(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match kind case-insensitively with implicit =")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match kind case-insensitively with implicit =")
 
 	// Kind with explicit = operator
 	kindValueExplicit := "=pod"
@@ -1442,25 +1372,25 @@ func TestEventMatchesFilters_KindCaseInsensitiveWithOperator(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValueExplicit}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputExplicit), "Should match kind case-insensitively with explicit =")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputExplicit)), "Should match kind case-insensitively with explicit =")
 
-	// Kind with != operator should also be case-insensitive
+	// Kind with != operator
 	kindValueNe := "!=deployment"
 	inputNe := &model.SearchInput{
 		Filters: []*model.SearchFilter{
 			{Property: kindFilter, Values: []*string{&kindValueNe}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, inputNe), "Kind with != should be case-insensitive (Pod != deployment)")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputNe)), "Kind with != deployment should match Pod")
 
-	// Kind with != same value (case-insensitive) should not match
+	// Same as search, != compares kind with the exact case.
 	kindValueNeSame := "!=pod"
 	inputNeSame := &model.SearchInput{
 		Filters: []*model.SearchFilter{
 			{Property: kindFilter, Values: []*string{&kindValueNeSame}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNeSame), "Kind with != pod should not match Pod (case-insensitive)")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(inputNeSame)), "Kind with != pod should match Pod (case-sensitive)")
 }
 
 func TestEventMatchesFilters_WildcardKindCaseSensitive(t *testing.T) {
@@ -1481,7 +1411,7 @@ func TestEventMatchesFilters_WildcardKindCaseSensitive(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match kind wildcard with correct case")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match kind wildcard with correct case")
 
 	// Lowercase pattern should NOT match (wildcard is case-sensitive for streaming)
 	kindValueLower := "deploy*"
@@ -1490,7 +1420,7 @@ func TestEventMatchesFilters_WildcardKindCaseSensitive(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValueLower}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputLower), "Should not match kind wildcard with wrong case")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputLower)), "Should not match kind wildcard with wrong case")
 }
 
 func TestEventMatchesFilters_WildcardMatchAll(t *testing.T) {
@@ -1511,7 +1441,7 @@ func TestEventMatchesFilters_WildcardMatchAll(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&kindValue}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Wildcard '*' should match any value")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Wildcard '*' should match any value")
 }
 
 func TestEventMatchesFilters_WildcardOrLogic(t *testing.T) {
@@ -1533,7 +1463,7 @@ func TestEventMatchesFilters_WildcardOrLogic(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&exactNoMatch, &wildcardMatch}},
 		},
 	}
-	assert.True(t, eventMatchesAllFilters(event, input), "Should match when one wildcard value in OR list matches")
+	assert.True(t, eventMatchesAllFilters(event, newEventFilter(input)), "Should match when one wildcard value in OR list matches")
 
 	// No wildcard or exact value matches
 	exactNoMatch2 := "Deployment"
@@ -1543,7 +1473,7 @@ func TestEventMatchesFilters_WildcardOrLogic(t *testing.T) {
 			{Property: kindFilter, Values: []*string{&exactNoMatch2, &wildcardNoMatch}},
 		},
 	}
-	assert.False(t, eventMatchesAllFilters(event, inputNoMatch), "Should not match when no value in OR list matches")
+	assert.False(t, eventMatchesAllFilters(event, newEventFilter(inputNoMatch)), "Should not match when no value in OR list matches")
 }
 
 func TestWatchSubscription_WildcardFilterAccepted(t *testing.T) {
@@ -1564,7 +1494,7 @@ func TestWatchSubscription_WildcardFilterAccepted(t *testing.T) {
 			{Property: "kind", Values: []*string{&valWild}},
 		},
 	}
-	resultChan, err := WatchSubscription(ctx, input)
+	resultChan, err := WatchSubscription(ctx, input, nil, nil, nil, nil, nil, nil)
 	assert.Nil(t, err, "Wildcard filter should be accepted")
 	assert.NotNil(t, resultChan, "Result channel should be returned")
 }
@@ -1757,3 +1687,53 @@ func TestEventMatchesRbacFineGrainedRbac_Disallowed(t *testing.T) {
 	// Then: user doesn't have permission
 	assert.Equal(t, result, false, "Expected user not to have permission to see event")
 }
+
+func TestSendMissedEvents_Replay(t *testing.T) {
+	ctx := rbac.CreateTestContext("test-user-1", "testuser1")
+	userData := rbac.CreateTestUserWatchData("watch", "v1", "pods", "foo", true, time.Now(), 1*time.Minute)
+	rbac.SetupWatchCacheWithUserData(ctx, userData)
+	defer rbac.CleanupWatchCache(ctx)
+	newEvent := func(uid string, seq int) *model.Event {
+		return &model.Event{UID: uid, Operation: "UPDATE", Seq: &seq, NewData: map[string]any{
+			"kind": "Pod", "apigroup": "v1", "kind_plural": "pods", "namespace": "foo",
+			"cluster": "local-cluster", "_hubClusterResource": true}}
+	}
+	kind := "Pod"
+	input := &model.SearchInput{Filters: []*model.SearchFilter{{Property: "kind", Values: []*string{&kind}}}}
+	result := make(chan *model.Event, 10)
+
+	lastSeq, sent := sendMissedEvents(ctx, "test-sub", watchInput(input), []*model.Event{newEvent("a", 11), newEvent("b", 12)},
+		false, result)
+
+	assert.True(t, sent)
+	assert.Equal(t, 12, lastSeq)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "a", (<-result).UID)
+	assert.Equal(t, "b", (<-result).UID)
+}
+
+func TestSendMissedEvents_Resync(t *testing.T) {
+	result := make(chan *model.Event, 10)
+
+	lastSeq, sent := sendMissedEvents(context.Background(), "test-sub", watchInput(nil), nil, true, result)
+
+	assert.True(t, sent)
+	assert.Equal(t, 0, lastSeq)
+	event := <-result
+	assert.Equal(t, eventResync, event.Operation)
+	assert.Equal(t, database.LastEventSeq(), *event.Seq)
+}
+
+func Test_eventFilter_ParsedOncePerType(t *testing.T) {
+	filter := newEventFilter(filterTestInput("restarts", []string{">2"}))
+	number := &model.Event{NewData: map[string]any{"restarts": float64(3)}}
+	text := &model.Event{NewData: map[string]any{"restarts": "10"}}
+
+	assert.True(t, eventMatchesAllFilters(number, filter))
+	parsed := filter.conditions[0]["number"]
+	assert.False(t, eventMatchesAllFilters(text, filter), "A string is compared as text.")
+	assert.True(t, eventMatchesAllFilters(number, filter))
+
+	assert.Len(t, filter.conditions[0], 2, "The filter should be parsed once for each type.")
+	assert.Same(t, parsed, filter.conditions[0]["number"])
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/stolostron/search-v2-api/pkg/federated"
 	"github.com/stolostron/search-v2-api/pkg/metrics"
 	"github.com/stolostron/search-v2-api/pkg/rbac"
+	"github.com/stolostron/search-v2-api/pkg/resolver"
 )
 
 func StartAndListen(ctx context.Context) {
@@ -53,6 +54,23 @@ func StartAndListen(ctx context.Context) {
 		klog.Infof("Federated search is disabled. To enable set env variable FEATURES_FEDERATED_SEARCH=true")
 	}
 
+	// Export streams the results until all rows are written or the client disconnects,
+	// so it doesn't use the timeout middleware. Must be added before the /searchapi (ContextPath) subroute.
+	exportSubrouter := router.PathPrefix(config.Cfg.ContextPath + "/export").Subrouter()
+	exportSubrouter.Use(metrics.PrometheusMiddleware)
+	exportSubrouter.Use(rbac.CheckDBAvailability)
+	exportSubrouter.Use(rbac.AuthenticateUser)
+	exportSubrouter.Use(rbac.AuthorizeUser)
+	exportSubrouter.HandleFunc("", resolver.ExportHandler).Methods("POST")
+
+	// Watch streams the events as Server-Sent Events until the client disconnects, so it doesn't use the timeout
+	// middleware. Must be added before the /searchapi (ContextPath) subroute.
+	watchSubrouter := router.PathPrefix(config.Cfg.ContextPath + "/watch").Subrouter()
+	watchSubrouter.Use(rbac.CheckDBAvailability)
+	watchSubrouter.Use(rbac.AuthenticateUser)
+	watchSubrouter.Use(rbac.AuthorizeUser)
+	watchSubrouter.HandleFunc("", resolver.WatchStreamHandler).Methods("GET")
+
 	// Add authentication middleware to the /searchapi (ContextPath) subroute.
 	apiSubrouter := router.PathPrefix(config.Cfg.ContextPath).Subrouter()
 
@@ -62,12 +80,15 @@ func StartAndListen(ctx context.Context) {
 	apiSubrouter.Use(rbac.AuthenticateUser)
 	apiSubrouter.Use(rbac.AuthorizeUser)
 
-	graphqlSrv := handler.New(generated.NewExecutableSchema(
-		generated.Config{Resolvers: &graph.Resolver{}}),
-	)
+	graphqlSrv := handler.New(generated.NewExecutableSchema(generated.Config{
+		Resolvers:  &graph.Resolver{},
+		Directives: generated.DirectiveRoot{Stream: resolver.StreamDirective},
+	}))
 	// Add transports to the graphQLSrv
 	graphqlSrv.AddTransport(transport.Options{})
 	graphqlSrv.AddTransport(transport.GET{})
+	// Incremental delivery (@defer and @stream) for clients that accept multipart/mixed. Must be added before POST.
+	graphqlSrv.AddTransport(transport.MultipartMixed{Boundary: "graphql", DeliveryTimeout: 10 * time.Millisecond})
 	graphqlSrv.AddTransport(transport.POST{})
 	graphqlSrv.AddTransport(transport.MultipartForm{})
 	graphqlSrv.AddTransport(transport.Websocket{
@@ -78,6 +99,8 @@ func StartAndListen(ctx context.Context) {
 		PingPongInterval:      10 * time.Second,
 		MissingPongOk:         true,
 	})
+	// Sends the items of the lists with @stream after the response, for the clients that accept multipart/mixed.
+	graphqlSrv.AroundOperations(resolver.StreamOperations)
 	apiSubrouter.Handle("/graphql", graphqlSrv)
 
 	if config.Cfg.ApiDocumentation {
@@ -1,7 +1,9 @@
 package server
 
 import (
+	"context"
 	"net/http"
+	"strings"
 	"time"
 
 	klog "k8s.io/klog/v2"
@@ -23,6 +25,19 @@ func TimeoutHandler(timeout time.Duration) func(http.Handler) http.Handler {
 				return
 			}
 
+			// Skip the timeout handler for incremental delivery (@defer and @stream) requests.
+			// The http.TimeoutHandler buffers the response and doesn't implement http.Flusher, so the
+			// multipart/mixed parts would only be sent when the request completes. The request context
+			// still has the timeout, so the queries are cancelled if the request takes too long.
+			if strings.Contains(r.Header.Get("Accept"), "multipart/mixed") {
+				klog.V(8).Infof("Applying %v context timeout for incremental delivery request: %s %s",
+					timeout, r.Method, r.URL.Path)
+				ctx, cancel := context.WithTimeout(r.Context(), timeout)
+				defer cancel()
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			klog.V(8).Infof("Applying %v timeout for HTTP request: %s %s", timeout, r.Method, r.URL.Path)
 			handler := http.TimeoutHandler(next, timeout, "Request timed out")
 			handler.ServeHTTP(w, r)
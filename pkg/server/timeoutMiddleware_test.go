@@ -154,3 +154,24 @@ func TestTimeoutMiddleware_SkipsWebSocketUpgrades(t *testing.T) {
 	require.True(t, gotHijacker,
 		"handler should receive a ResponseWriter that implements http.Hijacker when the bypass is active")
 }
+
+// TestTimeoutMiddleware_IncrementalDelivery verifies that multipart/mixed (@defer) requests
+// get the original ResponseWriter, so each part can be flushed, and a request context with the timeout.
+func TestTimeoutMiddleware_IncrementalDelivery(t *testing.T) {
+	var gotFlusher, gotDeadline bool
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, gotFlusher = w.(http.Flusher)
+		_, gotDeadline = r.Context().Deadline()
+	})
+	handler := TimeoutHandler(50 * time.Millisecond)
+
+	req := httptest.NewRequest("POST", "/searchapi/graphql", nil)
+	req.Header.Set("Accept", "multipart/mixed;deferSpec=20220824, application/json")
+	rr := httptest.NewRecorder()
+
+	handler(nextHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, gotFlusher, "handler should receive a ResponseWriter that implements http.Flusher")
+	assert.True(t, gotDeadline, "request context should have the timeout")
+}